@@ -2,13 +2,120 @@ package main
 
 import (
 	_ "embed"
-	"github.com/jacobtread/gomes/server"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
 )
 
+// Version is the version of GoMES, set at build time using
+// -ldflags "-X main.Version=..."
+var Version = "dev"
+
+type command struct {
+	Name        string
+	Usage       string
+	Description string
+	Run         func(flags *flag.FlagSet, args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"serve", "[flags]", "Run the main server along with the HTTP, QoS, telemetry and ticker servers", serve},
+		{"redirector", "[flags]", "Run only the redirector which sends clients to the main server", redirector},
+		{"decode", "[flags] [file]", "Decode packets from a hex, base64 or binary dump read from a file or stdin", decode},
+		{"encode", "[flags] [file]", "Encode packets from the json written by decode -json", encode},
+		{"pcap", "[flags] <file>", "Print the packets of the Blaze connections in a pcap or pcapng capture", pcap},
+		{"replay", "[flags] <recording>", "Replay a recorded connection against an in-process server and compare the responses", replay},
+		{"certs", "[flags]", "Generate certificates and print their fingerprints", certs},
+		{"user", "create -name <name> -password <password> [-email <email>] | list | ban [-unban] [-server <address>] <name>", "Manage players in the data store", user},
+		{"broadcast", "[flags] <message>", "Send a message to every player through a running server", broadcast},
+		{"migrate", "[flags]", "Upgrade the data store to the current version", migrate},
+		{"version", "", "Print the version", version},
+		{"help", "[command]", "Show help for a command", help},
+	}
+}
+
 func main() {
-	go server.StartMain()
-	go server.StartRedirector()
-	for true {
+	args := os.Args[1:]
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name = args[0]
+		args = args[1:]
+	}
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+		printUsage()
+		os.Exit(2)
+	}
+	flags := newFlagSet(cmd)
+	if err := cmd.Run(flags, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].Name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func newFlagSet(cmd *command) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: gomes %s %s\n\n%s\n", cmd.Name, cmd.Usage, cmd.Description)
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(flags.Output(), "\nFlags:")
+			flags.PrintDefaults()
+		}
+	}
+	return flags
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: gomes <command> [flags]\n\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.Name, cmd.Description)
+	}
+	fmt.Fprintln(os.Stderr, "\nUse \"gomes help <command>\" for more information about a command.")
+}
+
+func help(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		printUsage()
+		return nil
+	}
+	cmd := findCommand(flags.Arg(0))
+	if cmd == nil {
+		return fmt.Errorf("unknown command %q", flags.Arg(0))
+	}
+	other := newFlagSet(cmd)
+	other.SetOutput(os.Stdout)
+	// Running the command with -h registers its flags and prints the usage
+	_ = cmd.Run(other, []string{"-h"})
+	return nil
+}
 
+func version(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
 	}
+	fmt.Println("GoMES", Version)
+	return nil
 }
@@ -2,10 +2,10 @@ package blaze
 
 import (
 	"bytes"
-	"container/list"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"strings"
 )
@@ -270,15 +270,65 @@ var NotificationNames = map[uint32]string{
 	0x000400CA: "NotifyAdminListChange",
 	0x000400DC: "NotifyCreateDynamicDedicatedServerGame",
 	0x000400E6: "NotifyGameNameChange",
+	// Messaging Component
+	0x000F0001: "NotifyMessage",
+	// Association Lists Component
+	0x00190001: "NotifyUpdateListMembership",
+	// User Sessions Component
+	0x78020002: "NotifyUserAdded",
+	0x78020003: "NotifyUserRemoved",
 }
 
+// Packet message types stored in the upper bits of Packet.QType
+const (
+	RequestType      uint16 = 0x0000
+	ResponseType     uint16 = 0x1000
+	NotificationType uint16 = 0x2000
+	ErrorType        uint16 = 0x3000
+)
+
 type Connection struct {
-	*PacketBuff
 	net.Conn
 }
 
-func NewConnection(conn net.Conn) {
+func NewConnection(conn net.Conn) *Connection {
+	return &Connection{Conn: conn}
+}
+
+// ReadPacket reads the next packet from the connection blocking until
+// the entire packet has been received
+func (c *Connection) ReadPacket() (*Packet, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(c.Conn, header); err != nil {
+		return nil, err
+	}
+	packet := Packet{
+		Length:    binary.BigEndian.Uint16(header[0:]),
+		Component: binary.BigEndian.Uint16(header[2:]),
+		Command:   binary.BigEndian.Uint16(header[4:]),
+		Error:     binary.BigEndian.Uint16(header[6:]),
+		QType:     binary.BigEndian.Uint16(header[8:]),
+		Id:        binary.BigEndian.Uint16(header[10:]),
+	}
+	if (packet.QType & 0x10) != 0 {
+		if _, err := io.ReadFull(c.Conn, header[:2]); err != nil {
+			return nil, err
+		}
+		packet.ExtLength = binary.BigEndian.Uint16(header)
+	}
+	l := int(packet.Length) + int(packet.ExtLength)<<16
+	packet.Content = make([]byte, l)
+	if _, err := io.ReadFull(c.Conn, packet.Content); err != nil {
+		return nil, err
+	}
+	return &packet, nil
+}
 
+// WritePacket encodes and writes the provided packet to the connection
+func (c *Connection) WritePacket(packet *Packet) error {
+	buf := PacketBuff{}
+	_, err := c.Conn.Write(buf.EncodePacketRaw(*packet))
+	return err
 }
 
 type PacketBuff struct {
@@ -320,93 +370,112 @@ func (b *PacketBuff) Float64() float64 {
 	return out
 }
 
-// WriteVarInt writes a var int to the packet buffer
+// WriteVarInt writes a var int to the packet buffer. The first byte holds
+// six bits of the value along with a sign bit, every following byte holds
+// seven bits with the high bit marking a continuation
 func (b *PacketBuff) WriteVarInt(value int64) {
-	ux := uint64(value) << 1
+	ux := uint64(value)
+	first := byte(0)
 	if value < 0 {
-		ux = ^ux
+		ux = uint64(-value)
+		first |= 0x40
+	}
+	first |= byte(ux & 0x3F)
+	ux >>= 6
+	if ux > 0 {
+		first |= 0x80
 	}
-	i := 0
-	for ux >= 0x80 {
-		_ = b.WriteByte(byte(ux) | 0x80)
+	_ = b.WriteByte(first)
+	for ux > 0 {
+		by := byte(ux & 0x7F)
 		ux >>= 7
-		i++
+		if ux > 0 {
+			by |= 0x80
+		}
+		_ = b.WriteByte(by)
 	}
-	_ = b.WriteByte(byte(ux))
 }
 
 // ReadVarInt reads a var int from the packet buffer
-func (b *PacketBuff) ReadVarInt() uint64 {
-	var x uint64
-	var s uint
-	for i := 0; i < 10; i++ {
-		b, err := b.ReadByte()
+func (b *PacketBuff) ReadVarInt() int64 {
+	first, err := b.ReadByte()
+	if err != nil {
+		return 0
+	}
+	x := uint64(first & 0x3F)
+	s := uint(6)
+	cont := first&0x80 != 0
+	for cont && s < 64 {
+		by, err := b.ReadByte()
 		if err != nil {
-			return x
-		}
-		if b < 0x80 {
-			if i == 9 && b > 1 {
-				return x
-			}
-			return x | uint64(b)<<s
+			break
 		}
-		x |= uint64(b&0x7f) << s
+		x |= uint64(by&0x7F) << s
 		s += 7
+		cont = by&0x80 != 0
+	}
+	if first&0x40 != 0 {
+		return -int64(x)
 	}
-	return x
+	return int64(x)
 }
 
 // WriteNum takes any number type and writes it to the packet
 func (b *PacketBuff) WriteNum(value any) {
+	// Floats are written directly as they are the only numbers written
+	// while encoding values
+	if f, ok := value.(float64); ok {
+		var out [8]byte
+		binary.BigEndian.PutUint64(out[:], math.Float64bits(f))
+		_, _ = b.Write(out[:])
+		return
+	}
 	_ = binary.Write(b, binary.BigEndian, value)
 }
 
 // ReadString reads a string from the buffer
 func (b *PacketBuff) ReadString() string {
 	l := b.ReadVarInt()
-	buf := make([]byte, 0, l)
+	if l <= 0 || l > int64(b.Len()) {
+		return ""
+	}
+	buf := make([]byte, l)
 	_, _ = io.ReadFull(b, buf)
-	_, _ = b.ReadByte() // Strings end with a zero byte
-	return string(buf)
+	// Strings end with a zero byte which is included in the length
+	return strings.TrimSuffix(string(buf), "\x00")
 }
 
-// WriteString writes a string to the buffer
-func (b *PacketBuff) WriteString(value string) {
-	var l int
-	if strings.HasSuffix(value, "\x00") {
-		l = len(value)
-	} else {
-		l = len(value) + 1
+// ReadBlob reads a length prefixed byte array from the buffer
+func (b *PacketBuff) ReadBlob() []byte {
+	l := b.ReadVarInt()
+	if l < 0 || l > int64(b.Len()) {
+		l = int64(b.Len())
 	}
-	b.WriteVarInt(int64(l))
-	_, _ = b.Write([]byte(value))
+	buf := make([]byte, l)
+	_, _ = io.ReadFull(b, buf)
+	return buf
+}
+
+// WriteString writes a string to the buffer. The zero byte ending the
+// string is always written, even when the string already ends with one, as
+// one zero byte is always removed when strings are read
+func (b *PacketBuff) WriteString(value string) {
+	b.WriteVarInt(int64(len(value) + 1))
+	_, _ = b.Buffer.WriteString(value)
 	_ = b.WriteByte(0)
 }
 
-// ReadPacket reads a game packet from the provided packet reader
+// ReadPacket reads a game packet from the provided packet reader. Nil is
+// returned and nothing is consumed when the buffer doesn't hold a whole
+// packet
 func (b *PacketBuff) ReadPacket() *Packet {
-	packet := Packet{
-		Length:    b.UInt16(),
-		Component: b.UInt16(),
-		Command:   b.UInt16(),
-		Error:     b.UInt16(),
-		QType:     b.UInt16(),
-		Id:        b.UInt16(),
-	}
-	if (packet.QType & 0x10) != 0 {
-		packet.ExtLength = b.UInt16()
-	} else {
-		packet.ExtLength = 0
-	}
-	// Calculate the total size with the extension length
-	l := int32(packet.Length) + (int32(packet.ExtLength) << 16)
-	by := make([]byte, 0, l)     // Create an empty byte array for the content
-	_, err := io.ReadFull(b, by) // Read all the content bytes
+	packet, n, err := DecodePacket(b.Bytes())
 	if err != nil {
-		panic(err)
 		return nil
 	}
-	packet.Content = by
+	// The content is copied as the buffer reuses its memory
+	packet.Content = append([]byte{}, packet.Content...)
+	b.Next(n)
 	return &packet
 }
 
@@ -414,6 +483,9 @@ func (b *PacketBuff) ReadPacket() *Packet {
 // but only reads the heading portion of the packet skips over the packet
 // contents.
 func (b *PacketBuff) ReadPacketHeading() *Packet {
+	if b.Len() < 12 {
+		return nil
+	}
 	packet := Packet{
 		Length:    b.UInt16(),
 		Component: b.UInt16(),
@@ -423,94 +495,80 @@ func (b *PacketBuff) ReadPacketHeading() *Packet {
 		Id:        b.UInt16(),
 	}
 	if (packet.QType & 0x10) != 0 {
+		if b.Len() < 2 {
+			return nil
+		}
 		packet.ExtLength = b.UInt16()
 	} else {
 		packet.ExtLength = 0
 	}
 	// Calculate the total size with the extension length
-	l := int32(packet.Length) + (int32(packet.ExtLength) << 16)
+	l := int(packet.Length) + int(packet.ExtLength)<<16
 	by := make([]byte, 0, l) // Create an empty byte array for the content
 	packet.Content = by
 	return &packet
 }
 
-func (b *PacketBuff) ReadAllPackets() *list.List {
-	out := list.New()
+func (b *PacketBuff) ReadAllPackets() []Packet {
+	var out []Packet
 	for b.Len() > 0 {
-		out.PushBack(b.ReadPacket())
+		packet := b.ReadPacket()
+		if packet == nil {
+			break
+		}
+		out = append(out, *packet)
 	}
 	return out
 }
 
-func (b *PacketBuff) EncodePacket(comp uint16, cmd uint16, err uint16, qType uint16, id uint16, content list.List) []byte {
-	buf := &PacketBuff{Buffer: &bytes.Buffer{}}
+// EncodePacket writes the provided content into a new packet with the provided
+// header values returning the encoded bytes
+func (b *PacketBuff) EncodePacket(comp uint16, cmd uint16, err uint16, qType uint16, id uint16, content []Tdf) []byte {
 	contentBuff := &PacketBuff{Buffer: &bytes.Buffer{}}
-	for l := content.Front(); l != nil; l = l.Next() {
-		WriteTdf(contentBuff, l.Value.(Tdf))
+	for _, value := range content {
+		WriteTdf(contentBuff, value)
 	}
-	c := contentBuff.Bytes()
-	l := len(c)
-
-	_ = buf.WriteByte(byte((l & 0xFFFF) >> 8))
-	_ = buf.WriteByte(byte(l & 0xFF))
-	_ = buf.WriteByte(0)
-	_ = binary.Write(buf, binary.BigEndian, comp)
-	_ = binary.Write(buf, binary.BigEndian, cmd)
-	_ = binary.Write(buf, binary.BigEndian, err)
+	return b.EncodePacketRaw(NewPacket(comp, cmd, err, qType, id, contentBuff.Bytes()))
+}
 
-	buf.WriteByte(byte(qType >> 8))
-	if l > 0xFFFF {
-		buf.WriteByte(0x10)
-	} else {
-		buf.WriteByte(0x00)
+// NewPacket creates a packet from already encoded content calculating the
+// length and extended length fields
+func NewPacket(comp uint16, cmd uint16, err uint16, qType uint16, id uint16, content []byte) Packet {
+	l := len(content)
+	packet := Packet{
+		Length:    uint16(l & 0xFFFF),
+		Component: comp,
+		Command:   cmd,
+		Error:     err,
+		QType:     qType &^ 0x10,
+		Id:        id,
+		Content:   content,
 	}
-
-	_ = binary.Write(buf, binary.BigEndian, id)
-
 	if l > 0xFFFF {
-		buf.WriteByte(byte((l & 0xFF000000) >> 24))
-		buf.WriteByte(byte((l & 0x00FF0000) >> 16))
+		packet.QType |= 0x10
+		packet.ExtLength = uint16((l & 0xFFFF0000) >> 16)
 	}
-
-	_, _ = buf.Write(c)
-	return buf.Bytes()
+	return packet
 }
 
 func (b *PacketBuff) EncodePacketRaw(packet Packet) []byte {
-	buf := &PacketBuff{Buffer: &bytes.Buffer{}}
-	_ = buf.WriteByte(byte(packet.Length >> 8))
-	_ = buf.WriteByte(byte(packet.Length & 0xFF))
-	_ = buf.WriteByte(0)
-	_ = binary.Write(buf, binary.BigEndian, packet.Component)
-	_ = binary.Write(buf, binary.BigEndian, packet.Command)
-	_ = binary.Write(buf, binary.BigEndian, packet.Error)
-	_ = binary.Write(buf, binary.BigEndian, packet.QType)
-	_ = binary.Write(buf, binary.BigEndian, packet.Id)
-	if (packet.QType & 0x10) != 0 {
-		buf.WriteByte(byte(packet.ExtLength >> 8))
-		buf.WriteByte(byte(packet.ExtLength & 0xFF))
-	}
-	_, _ = buf.Write(packet.Content)
-	return buf.Bytes()
+	return AppendPacket(make([]byte, 0, 14+len(packet.Content)), packet)
 }
 
-func (p *Packet) ReadContent() *list.List {
-	buff := PacketBuff{Buffer: bytes.NewBuffer(p.Content)}
-	out := list.New()
-	for buff.Len() > 0 {
-		out.PushBack(buff.ReadTdf())
-	}
-	return out
+// ReadContent decodes the values in the content of the packet. Blobs
+// within the values share their memory with the content
+func (p *Packet) ReadContent() Values {
+	return DecodeContent(p.Content)
 }
 
 func (p *Packet) ToDescriptor() string {
-	compString := fmt.Sprintf("0x%0d", p.Component)
-	cmdString := fmt.Sprintf("0x%04d", p.Command)
+	compString := fmt.Sprintf("0x%02X", p.Component)
+	cmdString := fmt.Sprintf("0x%02X", p.Command)
 	compName, exists := ComponentNames[p.Component]
 	if exists {
 		compString = compName
 		cmdKey := (uint32(p.Component) << 16) + uint32(p.Command)
-		if p.QType == 0x2000 {
+		if p.QType&0xF000 == NotificationType {
 			nName, exists := NotificationNames[cmdKey]
 			if exists {
 				cmdString = nName
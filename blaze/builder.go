@@ -0,0 +1,121 @@
+package blaze
+
+import (
+	"sort"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+// Builder creates a list of values by chaining calls
+//
+//	content := NewBuilder().
+//		String("NAME", "Shepard").
+//		Struct("PDTL", func(b *Builder) {
+//			b.Int("PID", 1).IntList("IDS", 1, 2)
+//		}).
+//		Values()
+type Builder struct {
+	values Values
+}
+
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Values returns the values that have been added
+func (b *Builder) Values() Values {
+	return b.values
+}
+
+// Add adds already created values
+func (b *Builder) Add(values ...Tdf) *Builder {
+	b.values = append(b.values, values...)
+	return b
+}
+
+func (b *Builder) Int(label string, value int64) *Builder {
+	return b.Add(NewInt64(label, value))
+}
+
+func (b *Builder) String(label string, value string) *Builder {
+	return b.Add(NewString(label, value))
+}
+
+func (b *Builder) Blob(label string, value []byte) *Builder {
+	return b.Add(NewBlob(label, value))
+}
+
+func (b *Builder) Float(label string, value float64) *Builder {
+	return b.Add(NewFloat(label, value))
+}
+
+func (b *Builder) Pair(label string, value Pair) *Builder {
+	return b.Add(NewPair(label, value))
+}
+
+func (b *Builder) Triple(label string, value Triple) *Builder {
+	return b.Add(NewTriple(label, value))
+}
+
+// Struct adds a struct with the values added by build
+func (b *Builder) Struct(label string, build func(b *Builder)) *Builder {
+	return b.Add(NewStruct(label, buildValues(build)...))
+}
+
+// Struct2 adds a struct starting with the 2 byte with the values added by build
+func (b *Builder) Struct2(label string, build func(b *Builder)) *Builder {
+	return b.Add(NewStruct2(label, buildValues(build)...))
+}
+
+// Union adds a union holding the provided value
+func (b *Builder) Union(label string, unionType TdfType, value Tdf) *Builder {
+	return b.Add(NewUnion(label, unionType, value))
+}
+
+// EmptyUnion adds a union without a value
+func (b *Builder) EmptyUnion(label string) *Builder {
+	return b.Add(NewUnion(label, EmptyType, nil))
+}
+
+func (b *Builder) IntList(label string, values ...int64) *Builder {
+	return b.Add(NewList(label, values))
+}
+
+func (b *Builder) StringList(label string, values ...string) *Builder {
+	return b.Add(NewList(label, values))
+}
+
+// StructList adds a list of structs where build is called once for each
+// struct with its index
+func (b *Builder) StructList(label string, count int, build func(i int, b *Builder)) *Builder {
+	values := make([]StructTdf, count)
+	for i := range values {
+		values[i] = NewStructStub(buildValues(func(b *Builder) { build(i, b) }), false)
+	}
+	return b.Add(NewList(label, values))
+}
+
+func (b *Builder) VarIntList(label string, values ...int64) *Builder {
+	return b.Add(NewVarIntList(label, values))
+}
+
+// StringMap adds a map of strings with the keys in sorted order so that the
+// same map always encodes to the same bytes
+func (b *Builder) StringMap(label string, values map[string]string) *Builder {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	items := make([]string, len(keys))
+	for i, key := range keys {
+		items[i] = values[key]
+	}
+	return b.Add(NewMap(label, keys, items))
+}
+
+func buildValues(build func(b *Builder)) Values {
+	inner := NewBuilder()
+	build(inner)
+	return inner.values
+}
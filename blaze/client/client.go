@@ -0,0 +1,306 @@
+// Package client connects to Blaze servers the way the game does so that
+// servers can be tested and bots written without the game. Dial asks the
+// redirector for the main server and connects to it, after which commands
+// are sent with Call or Invoke and notifications are received from
+// subscriptions
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/blaze/me3"
+)
+
+// ErrClosed is returned by calls on a closed client. When the connection
+// was lost the error wraps it
+var ErrClosed = errors.New("client: closed")
+
+// ErrTooManyRequests is returned by calls made while every request id is
+// waiting for a response
+var ErrTooManyRequests = errors.New("client: too many requests waiting for responses")
+
+// ResponseError is an error response to a request
+type ResponseError struct {
+	Component uint16
+	Command   uint16
+	Code      uint16
+}
+
+func (e *ResponseError) Error() string {
+	packet := blaze.Packet{Component: e.Component, Command: e.Command}
+	return fmt.Sprintf("client: %s failed with error 0x%X", packet.ToDescriptor(), e.Code)
+}
+
+// DefaultPingInterval is the time between the pings sent to keep the
+// connection alive when Options.PingInterval is zero
+const DefaultPingInterval = 15 * time.Second
+
+// DefaultNotificationBuffer is the number of notifications a subscription
+// holds when Options.NotificationBuffer is zero
+const DefaultNotificationBuffer = 16
+
+// Options change how a client connects. The zero value is the defaults
+type Options struct {
+	// Dialer is used for both the redirector and main server connections
+	Dialer *net.Dialer
+	// RedirectorTLS is used to connect to the redirector when set.
+	// Otherwise the redirector is connected to without TLS
+	RedirectorTLS *tls.Config
+	// TLSConfig is used when the redirector says the main server uses SSL.
+	// When nil certificates aren't verified like in the game as servers use
+	// self signed certificates
+	TLSConfig *tls.Config
+	// Instance is sent to the redirector. When nil DefaultInstance is used
+	Instance *me3.GetServerInstanceRequest
+	// PingInterval is the time between pings. Negative values turn off
+	// pinging. A ping that isn't answered within the interval closes the
+	// client
+	PingInterval time.Duration
+	// NotificationBuffer is the number of notifications a subscription
+	// holds before further notifications are dropped
+	NotificationBuffer int
+}
+
+func (o *Options) withDefaults() Options {
+	var out Options
+	if o != nil {
+		out = *o
+	}
+	if out.Dialer == nil {
+		out.Dialer = &net.Dialer{}
+	}
+	if out.TLSConfig == nil {
+		out.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	if out.Instance == nil {
+		out.Instance = &DefaultInstance
+	}
+	if out.PingInterval == 0 {
+		out.PingInterval = DefaultPingInterval
+	}
+	if out.NotificationBuffer <= 0 {
+		out.NotificationBuffer = DefaultNotificationBuffer
+	}
+	return out
+}
+
+// Client is a connection to a Blaze server. Its methods can be used from
+// multiple goroutines
+type Client struct {
+	conn      *blaze.Connection
+	options   Options
+	writeLock sync.Mutex
+
+	lock   sync.Mutex
+	nextId uint16
+	// pending are the responses being waited for by packet id
+	pending       map[uint16]chan *blaze.Packet
+	subscriptions map[uint32][]*Subscription
+	err           error
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New creates a client using an existing connection. The client reads from
+// the connection until it is closed and pings the server unless pinging is
+// turned off in the options
+func New(conn net.Conn, options *Options) *Client {
+	c := &Client{
+		conn:          blaze.NewConnection(conn),
+		options:       options.withDefaults(),
+		pending:       map[uint16]chan *blaze.Packet{},
+		subscriptions: map[uint32][]*Subscription{},
+		done:          make(chan struct{}),
+	}
+	go c.read()
+	if c.options.PingInterval > 0 {
+		go c.ping(c.options.PingInterval)
+	}
+	return c
+}
+
+// Call sends a request and waits for the response to it until the context
+// is done. Error responses are returned along with a *ResponseError
+func (c *Client) Call(ctx context.Context, component uint16, command uint16, content []blaze.Tdf) (*blaze.Packet, error) {
+	id, response, err := c.register()
+	if err != nil {
+		return nil, err
+	}
+	defer c.unregister(id)
+	if err := c.write(component, command, id, content); err != nil {
+		return nil, err
+	}
+	select {
+	case packet := <-response:
+		if packet.QType&0xF000 == blaze.ErrorType || packet.Error != 0 {
+			return packet, &ResponseError{Component: component, Command: command, Code: packet.Error}
+		}
+		return packet, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.done:
+		return nil, c.Err()
+	}
+}
+
+// Request is the content of a request such as the request types in the
+// me3 package
+type Request interface {
+	Values() []blaze.Tdf
+}
+
+// Response is decoded from the content of a response such as the response
+// types in the me3 package
+type Response interface {
+	Decode(values blaze.Values) error
+}
+
+// Invoke calls a command using typed request and response values. Either
+// of them can be nil when the command has no content
+func (c *Client) Invoke(ctx context.Context, component uint16, command uint16, request Request, response Response) error {
+	var content []blaze.Tdf
+	if request != nil {
+		content = request.Values()
+	}
+	packet, err := c.Call(ctx, component, command, content)
+	if err != nil || response == nil {
+		return err
+	}
+	return response.Decode(packet.ReadContent())
+}
+
+// register picks an id that isn't waiting for a response
+func (c *Client) register() (uint16, chan *blaze.Packet, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.err != nil {
+		return 0, nil, c.err
+	}
+	// Ids are tried in order from the next id wrapping around until every
+	// one of the 0x10000 ids has been tried
+	for i := 0; i <= 0xFFFF; i++ {
+		id := c.nextId
+		c.nextId++
+		if _, used := c.pending[id]; !used {
+			// The channel is buffered so that the reader never waits on a
+			// caller that has given up
+			response := make(chan *blaze.Packet, 1)
+			c.pending[id] = response
+			return id, response, nil
+		}
+	}
+	return 0, nil, ErrTooManyRequests
+}
+
+func (c *Client) unregister(id uint16) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.pending, id)
+}
+
+func (c *Client) write(component uint16, command uint16, id uint16, content []blaze.Tdf) error {
+	encoder := blaze.AcquireEncoder()
+	defer encoder.Release()
+	data := encoder.Packet(component, command, 0, blaze.RequestType, id, content)
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if _, err := c.conn.Write(data); err != nil {
+		c.closeWithError(err)
+		return c.Err()
+	}
+	return nil
+}
+
+// read passes the packets from the server to the callers waiting for them
+// and the subscriptions until the connection fails
+func (c *Client) read() {
+	for {
+		packet, err := c.conn.ReadPacket()
+		if err != nil {
+			c.closeWithError(err)
+			return
+		}
+		switch packet.QType & 0xF000 {
+		case blaze.ResponseType, blaze.ErrorType:
+			c.lock.Lock()
+			response, ok := c.pending[packet.Id]
+			delete(c.pending, packet.Id)
+			c.lock.Unlock()
+			// Responses nobody is waiting for are late responses to calls
+			// whose context is done
+			if ok {
+				response <- packet
+			}
+		case blaze.NotificationType:
+			c.notify(packet)
+		}
+	}
+}
+
+func (c *Client) ping(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := c.Invoke(ctx, me3.UtilComponent, me3.UtilPing, &me3.PingRequest{}, nil)
+		cancel()
+		// Any response even an error shows the server is still there
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.closeWithError(errors.New("ping timed out"))
+			return
+		}
+	}
+}
+
+// Done is closed once the client is closed
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the client was closed or nil while it is open
+func (c *Client) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.err
+}
+
+// Close closes the connection and the subscriptions
+func (c *Client) Close() error {
+	return c.closeWithError(nil)
+}
+
+// closeWithError closes the client the first time it is called recording
+// the reason
+func (c *Client) closeWithError(reason error) error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = c.conn.Close()
+		c.lock.Lock()
+		c.err = ErrClosed
+		if reason != nil {
+			c.err = fmt.Errorf("%w: %v", ErrClosed, reason)
+		}
+		for _, subscriptions := range c.subscriptions {
+			for _, subscription := range subscriptions {
+				close(subscription.c)
+			}
+		}
+		c.subscriptions = nil
+		c.lock.Unlock()
+		close(c.done)
+	})
+	return err
+}
@@ -0,0 +1,277 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/blaze/me3"
+)
+
+// serve runs a server on a local port passing each request to respond
+// along with the connection so that it can send notifications. Requests
+// that respond returns nil for go unanswered
+func serve(t *testing.T, respond func(conn *blaze.Connection, packet *blaze.Packet) []byte) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				bc := blaze.NewConnection(conn)
+				defer bc.Close()
+				for {
+					packet, err := bc.ReadPacket()
+					if err != nil {
+						return
+					}
+					if data := respond(bc, packet); data != nil {
+						_, _ = bc.Write(data)
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func reply(packet *blaze.Packet, content ...blaze.Tdf) []byte {
+	buf := blaze.PacketBuff{}
+	return buf.EncodePacket(packet.Component, packet.Command, 0, blaze.ResponseType, packet.Id, content)
+}
+
+func noPing() *Options {
+	return &Options{PingInterval: -1}
+}
+
+func TestDial(t *testing.T) {
+	main := serve(t, func(_ *blaze.Connection, packet *blaze.Packet) []byte {
+		request := &me3.FetchClientConfigRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			t.Error(err)
+		}
+		response := &me3.FetchClientConfigResponse{CONF: map[string]string{"ID": request.CFID}}
+		return reply(packet, response.Values()...)
+	})
+	host, port, _ := net.SplitHostPort(main)
+	redirector := serve(t, func(_ *blaze.Connection, packet *blaze.Packet) []byte {
+		request := &me3.GetServerInstanceRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil || request.NAME != DefaultInstance.NAME {
+			t.Errorf("unexpected request %+v %v", request, err)
+		}
+		portValue, _ := strconv.Atoi(port)
+		response := &me3.GetServerInstanceResponse{
+			ADDR: me3.GetServerInstanceResponseADDR{VALU: &me3.GetServerInstanceResponseADDRVALU{IP: 0x7F000001, PORT: int64(portValue)}},
+		}
+		return reply(packet, response.Values()...)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server, err := Resolve(ctx, redirector, noPing())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.Address() != net.JoinHostPort(host, port) || server.Secure {
+		t.Errorf("unexpected server %+v", server)
+	}
+	c, err := Dial(ctx, redirector, noPing())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	response := &me3.FetchClientConfigResponse{}
+	if err := c.Invoke(ctx, me3.UtilComponent, me3.UtilFetchClientConfig, &me3.FetchClientConfigRequest{CFID: "ME3_DATA"}, response); err != nil {
+		t.Fatal(err)
+	}
+	if response.CONF["ID"] != "ME3_DATA" {
+		t.Errorf("unexpected response %v", response.CONF)
+	}
+}
+
+func TestCallCorrelatesIds(t *testing.T) {
+	// The first request is answered after the second so that the responses
+	// arrive in the opposite order to the requests
+	held := make(chan []byte, 1)
+	address := serve(t, func(conn *blaze.Connection, packet *blaze.Packet) []byte {
+		data := reply(packet, blaze.NewInt64("CMD", int64(packet.Command)))
+		if packet.Command == 1 {
+			held <- data
+			return nil
+		}
+		_, _ = conn.Write(data)
+		return <-held
+	})
+	c, err := DialServer(context.Background(), serverAt(t, address), noPing())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	results := make(chan error, 2)
+	call := func(command uint16) {
+		packet, err := c.Call(context.Background(), 0x9, command, nil)
+		if err == nil {
+			if value, ok := packet.ReadContent().Get("CMD"); !ok || value.(blaze.Int64Tdf).Value != int64(command) {
+				err = errors.New("response to another request")
+			}
+		}
+		results <- err
+	}
+	go call(1)
+	for len(held) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	go call(2)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCallErrors(t *testing.T) {
+	address := serve(t, func(_ *blaze.Connection, packet *blaze.Packet) []byte {
+		if packet.Command == 1 {
+			buf := blaze.PacketBuff{}
+			return buf.EncodePacket(packet.Component, packet.Command, 0x2, blaze.ErrorType, packet.Id, nil)
+		}
+		return nil
+	})
+	c, err := DialServer(context.Background(), serverAt(t, address), noPing())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var responseErr *ResponseError
+	if _, err := c.Call(context.Background(), 0xF, 1, nil); !errors.As(err, &responseErr) || responseErr.Code != 0x2 {
+		t.Errorf("expected a response error got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Call(ctx, 0xF, 2, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a timeout got %v", err)
+	}
+	_ = c.Close()
+	if _, err := c.Call(context.Background(), 0xF, 1, nil); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected closed got %v", err)
+	}
+}
+
+func TestRegisterWrapsAndRunsOut(t *testing.T) {
+	conn, other := net.Pipe()
+	defer other.Close()
+	c := New(conn, noPing())
+	defer c.Close()
+	// Every id but one is waiting and the free id is before the next id
+	c.lock.Lock()
+	for id := 0; id <= 0xFFFF; id++ {
+		if id != 0x1234 {
+			c.pending[uint16(id)] = nil
+		}
+	}
+	c.nextId = 0x1235
+	c.lock.Unlock()
+	id, _, err := c.register()
+	if err != nil || id != 0x1234 {
+		t.Fatalf("registered %d %v", id, err)
+	}
+	if _, _, err := c.register(); !errors.Is(err, ErrTooManyRequests) {
+		t.Errorf("expected too many requests got %v", err)
+	}
+	c.unregister(0x10)
+	if id, _, err := c.register(); err != nil || id != 0x10 {
+		t.Errorf("registered %d %v after an id was freed", id, err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	address := serve(t, func(conn *blaze.Connection, packet *blaze.Packet) []byte {
+		buf := blaze.PacketBuff{}
+		notification := &me3.NotifyMessage{NAME: "Shepard"}
+		_, _ = conn.Write(buf.EncodePacket(me3.MessagingComponent, me3.MessagingNotifyMessage, 0, blaze.NotificationType, 0, notification.Values()))
+		return reply(packet)
+	})
+	c, err := DialServer(context.Background(), serverAt(t, address), noPing())
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscription := c.Subscribe(me3.MessagingComponent, me3.MessagingNotifyMessage)
+	other := c.Subscribe(me3.UtilComponent, me3.UtilPing)
+	if _, err := c.Call(context.Background(), me3.MessagingComponent, me3.MessagingSendMessage, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case packet := <-subscription.C:
+		notification := &me3.NotifyMessage{}
+		if err := notification.Decode(packet.ReadContent()); err != nil || notification.NAME != "Shepard" {
+			t.Errorf("unexpected notification %+v %v", notification, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification")
+	}
+	subscription.Close()
+	if _, ok := <-subscription.C; ok {
+		t.Error("closed subscription received a notification")
+	}
+	_ = c.Close()
+	if _, ok := <-other.C; ok {
+		t.Error("subscription is open after the client closed")
+	}
+}
+
+func TestPing(t *testing.T) {
+	var pings int32
+	address := serve(t, func(_ *blaze.Connection, packet *blaze.Packet) []byte {
+		if packet.Component == me3.UtilComponent && packet.Command == me3.UtilPing {
+			atomic.AddInt32(&pings, 1)
+		}
+		return reply(packet)
+	})
+	c, err := DialServer(context.Background(), serverAt(t, address), &Options{PingInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&pings) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if atomic.LoadInt32(&pings) < 3 || c.Err() != nil {
+		t.Errorf("%d pings %v", pings, c.Err())
+	}
+}
+
+func TestPingTimeout(t *testing.T) {
+	address := serve(t, func(*blaze.Connection, *blaze.Packet) []byte { return nil })
+	c, err := DialServer(context.Background(), serverAt(t, address), &Options{PingInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.Done():
+		if !errors.Is(c.Err(), ErrClosed) {
+			t.Errorf("unexpected error %v", c.Err())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("client wasn't closed")
+	}
+}
+
+func serverAt(t *testing.T, address string) Server {
+	host, port, _ := net.SplitHostPort(address)
+	value, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Server{Host: host, Port: value}
+}
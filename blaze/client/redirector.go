@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/jacobtread/gomes/blaze/me3"
+)
+
+// DefaultInstance is the getServerInstance request sent by the PC version
+// of the game
+var DefaultInstance = me3.GetServerInstanceRequest{
+	BSDK: "3.15.6.0",
+	BTIM: "Dec 21 2012 12:47:10",
+	CLNT: "MassEffect3-pc",
+	CSKU: "134845",
+	CVER: "05427.124",
+	DSDK: "8.14.7.1",
+	ENV:  "prod",
+	LOC:  0x656e5553,
+	NAME: "masseffect-3-pc",
+	PLAT: "Windows",
+	PROF: "standardSecure_v3",
+}
+
+// Server is the main server the redirector sends clients to
+type Server struct {
+	Host   string
+	Port   int
+	Secure bool
+	// Message is shown to players by the game when it isn't empty
+	Message string
+}
+
+// Address returns the host and port of the server
+func (s Server) Address() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// Resolve asks the redirector at the provided address which main server
+// to connect to
+func Resolve(ctx context.Context, redirector string, options *Options) (Server, error) {
+	opts := options.withDefaults()
+	conn, err := dial(ctx, &opts, redirector, opts.RedirectorTLS)
+	if err != nil {
+		return Server{}, err
+	}
+	opts.PingInterval = -1
+	c := New(conn, &opts)
+	defer c.Close()
+	response := &me3.GetServerInstanceResponse{}
+	if err := c.Invoke(ctx, me3.RedirectComponent, me3.RedirectGetServerInstance, opts.Instance, response); err != nil {
+		return Server{}, err
+	}
+	address := response.ADDR.VALU
+	if address == nil {
+		return Server{}, errors.New("client: redirector didn't provide an address")
+	}
+	server := Server{Host: address.HOST, Port: int(address.PORT), Secure: response.SECU == 1}
+	// The ip address is used when there is no host name to look up
+	if server.Host == "" {
+		ip := uint32(address.IP)
+		server.Host = net.IPv4(byte(ip>>24), byte(ip>>16), byte(ip>>8), byte(ip)).String()
+	}
+	if response.AMSG != nil {
+		server.Message = *response.AMSG
+	}
+	return server, nil
+}
+
+// Dial resolves the main server using the redirector at the provided
+// address and connects to it
+func Dial(ctx context.Context, redirector string, options *Options) (*Client, error) {
+	server, err := Resolve(ctx, redirector, options)
+	if err != nil {
+		return nil, err
+	}
+	return DialServer(ctx, server, options)
+}
+
+// DialServer connects to a main server without asking the redirector
+func DialServer(ctx context.Context, server Server, options *Options) (*Client, error) {
+	opts := options.withDefaults()
+	var config *tls.Config
+	if server.Secure {
+		config = opts.TLSConfig
+	}
+	conn, err := dial(ctx, &opts, server.Address(), config)
+	if err != nil {
+		return nil, err
+	}
+	return New(conn, &opts), nil
+}
+
+func dial(ctx context.Context, options *Options, address string, config *tls.Config) (net.Conn, error) {
+	if config == nil {
+		return options.Dialer.DialContext(ctx, "tcp", address)
+	}
+	dialer := &tls.Dialer{NetDialer: options.Dialer, Config: config}
+	return dialer.DialContext(ctx, "tcp", address)
+}
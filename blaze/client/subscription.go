@@ -0,0 +1,60 @@
+package client
+
+import "github.com/jacobtread/gomes/blaze"
+
+// Subscription receives the notifications with a component and command
+type Subscription struct {
+	// C receives the notifications. It is closed when the subscription or
+	// the client is closed
+	C <-chan *blaze.Packet
+
+	c      chan *blaze.Packet
+	client *Client
+	key    uint32
+}
+
+// Subscribe creates a subscription for the notifications with the provided
+// component and command such as me3.MessagingComponent and
+// me3.MessagingNotifyMessage. Notifications are dropped when the channel
+// is full so that a slow subscriber can't hold up responses
+func (c *Client) Subscribe(component uint16, command uint16) *Subscription {
+	ch := make(chan *blaze.Packet, c.options.NotificationBuffer)
+	s := &Subscription{C: ch, c: ch, client: c, key: uint32(component)<<16 | uint32(command)}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.subscriptions == nil {
+		close(ch)
+		return s
+	}
+	c.subscriptions[s.key] = append(c.subscriptions[s.key], s)
+	return s
+}
+
+// Close stops the subscription and closes its channel
+func (s *Subscription) Close() {
+	c := s.client
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	subscriptions := c.subscriptions[s.key]
+	for i, other := range subscriptions {
+		if other == s {
+			c.subscriptions[s.key] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+			if len(c.subscriptions[s.key]) == 0 {
+				delete(c.subscriptions, s.key)
+			}
+			close(s.c)
+			return
+		}
+	}
+}
+
+func (c *Client) notify(packet *blaze.Packet) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, s := range c.subscriptions[uint32(packet.Component)<<16|uint32(packet.Command)] {
+		select {
+		case s.c <- packet:
+		default:
+		}
+	}
+}
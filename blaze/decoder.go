@@ -0,0 +1,197 @@
+package blaze
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// Decoder reads values directly from a byte slice. Unlike PacketBuff nothing
+// is copied out of the slice except strings, so blobs that are read share
+// their memory with the slice being decoded
+type Decoder struct {
+	data []byte
+	off  int
+}
+
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Reset starts decoding the provided data
+func (d *Decoder) Reset(data []byte) {
+	d.data = data
+	d.off = 0
+}
+
+// Len is the number of bytes that haven't been read
+func (d *Decoder) Len() int {
+	return len(d.data) - d.off
+}
+
+// Offset is the number of bytes that have been read
+func (d *Decoder) Offset() int {
+	return d.off
+}
+
+func (d *Decoder) ReadByte() (byte, error) {
+	if d.off >= len(d.data) {
+		return 0, io.EOF
+	}
+	b := d.data[d.off]
+	d.off++
+	return b, nil
+}
+
+func (d *Decoder) UnreadByte() error {
+	if d.off == 0 {
+		return errors.New("blaze: UnreadByte at the start of the data")
+	}
+	d.off--
+	return nil
+}
+
+// next consumes n bytes returning nil and consuming the rest of the data
+// when there are fewer than n bytes left
+func (d *Decoder) next(n int) []byte {
+	if n > d.Len() {
+		d.off = len(d.data)
+		return nil
+	}
+	out := d.data[d.off : d.off+n]
+	d.off += n
+	return out
+}
+
+func (d *Decoder) UInt16() uint16 {
+	if b := d.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *Decoder) UInt32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *Decoder) Float64() float64 {
+	if b := d.next(8); b != nil {
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+// ReadVarInt reads a var int in the same format as PacketBuff.ReadVarInt
+func (d *Decoder) ReadVarInt() int64 {
+	if d.off >= len(d.data) {
+		return 0
+	}
+	first := d.data[d.off]
+	d.off++
+	x := uint64(first & 0x3F)
+	s := uint(6)
+	cont := first&0x80 != 0
+	for cont && s < 64 && d.off < len(d.data) {
+		by := d.data[d.off]
+		d.off++
+		x |= uint64(by&0x7F) << s
+		s += 7
+		cont = by&0x80 != 0
+	}
+	if first&0x40 != 0 {
+		return -int64(x)
+	}
+	return int64(x)
+}
+
+// ReadString reads a string allocating only the returned string
+func (d *Decoder) ReadString() string {
+	l := d.ReadVarInt()
+	if l <= 0 || l > int64(d.Len()) {
+		return ""
+	}
+	b := d.next(int(l))
+	// Strings end with a zero byte which is included in the length
+	if b[len(b)-1] == 0 {
+		b = b[:len(b)-1]
+	}
+	return string(b)
+}
+
+// ReadBlob reads a blob without copying it
+func (d *Decoder) ReadBlob() []byte {
+	l := d.ReadVarInt()
+	if l < 0 || l > int64(d.Len()) {
+		l = int64(d.Len())
+	}
+	return d.next(int(l))[:l:l]
+}
+
+// ReadTdf reads the next labelled value returning nil when there are no
+// more values or the value can't be read
+func (d *Decoder) ReadTdf() Tdf {
+	return readTdf(d)
+}
+
+func (d *Decoder) ReadStructValues() ([]Tdf, bool) {
+	return readStructValues(d)
+}
+
+// ReadContent reads every value until the end of the data
+func (d *Decoder) ReadContent() Values {
+	var out Values
+	for d.Len() > 0 {
+		value := readTdf(d)
+		if value == nil {
+			break
+		}
+		out = append(out, value)
+	}
+	return out
+}
+
+// DecodeContent decodes the values in the content of a packet. The blobs
+// within the values share their memory with data
+func DecodeContent(data []byte) Values {
+	d := Decoder{data: data}
+	return d.ReadContent()
+}
+
+// ErrShortPacket is returned by DecodePacket when the data doesn't hold an
+// entire packet
+var ErrShortPacket = errors.New("blaze: data is shorter than the packet")
+
+// DecodePacket decodes the packet at the start of data returning the packet
+// and the number of bytes it used. The content of the packet is a slice of
+// data rather than a copy
+func DecodePacket(data []byte) (Packet, int, error) {
+	if len(data) < 12 {
+		return Packet{}, 0, ErrShortPacket
+	}
+	packet := Packet{
+		Length:    binary.BigEndian.Uint16(data[0:]),
+		Component: binary.BigEndian.Uint16(data[2:]),
+		Command:   binary.BigEndian.Uint16(data[4:]),
+		Error:     binary.BigEndian.Uint16(data[6:]),
+		QType:     binary.BigEndian.Uint16(data[8:]),
+		Id:        binary.BigEndian.Uint16(data[10:]),
+	}
+	n := 12
+	if (packet.QType & 0x10) != 0 {
+		if len(data) < 14 {
+			return Packet{}, 0, ErrShortPacket
+		}
+		packet.ExtLength = binary.BigEndian.Uint16(data[12:])
+		n = 14
+	}
+	l := int(packet.Length) + int(packet.ExtLength)<<16
+	if len(data)-n < l {
+		return Packet{}, 0, ErrShortPacket
+	}
+	packet.Content = data[n : n+l : n+l]
+	return packet, n + l, nil
+}
@@ -0,0 +1,149 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// readContentBuff reads content through PacketBuff which is what
+// Packet.ReadContent used before the Decoder
+func readContentBuff(data []byte) []Tdf {
+	buff := PacketBuff{Buffer: bytes.NewBuffer(data)}
+	var out []Tdf
+	for buff.Len() > 0 {
+		value := buff.ReadTdf()
+		if value == nil {
+			break
+		}
+		out = append(out, value)
+	}
+	return out
+}
+
+// benchContent is similar to a setPlayerAttributes request
+func benchContent() []Tdf {
+	return NewBuilder().
+		Int("BUID", 1).
+		StringMap("ATTR", map[string]string{
+			"class":      "soldier",
+			"level":      "20",
+			"challenges": "0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0",
+			"credits":    "100000",
+			"inventory":  "0000000000000000000000000000000000000000",
+		}).
+		Struct("PDTL", func(b *Builder) {
+			b.String("DSNM", "Shepard").Int("XUID", 12345).Blob("BLOB", []byte{1, 2, 3, 4})
+		}).
+		Values()
+}
+
+func TestDecoderMatchesPacketBuff(t *testing.T) {
+	wire := encodeContent(roundTripContent()...)
+	expected := encodeContent(readContentBuff(wire)...)
+	if out := encodeContent(DecodeContent(wire)...); !bytes.Equal(out, expected) {
+		t.Errorf("decoder read different values\n%x\n%x", expected, out)
+	}
+	if !bytes.Equal(expected, wire) {
+		t.Errorf("PacketBuff changed the bytes")
+	}
+}
+
+func TestDecodePacket(t *testing.T) {
+	buff := PacketBuff{}
+	data := buff.EncodePacket(0x9, 0x7, 0, ResponseType, 3, benchContent())
+	data = append(data, 0xFF)
+	packet, n, err := DecodePacket(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(data)-1 || packet.Component != 0x9 || packet.Command != 0x7 || packet.Id != 3 || packet.QType != ResponseType {
+		t.Errorf("unexpected packet %+v used %d bytes", packet, n)
+	}
+	if !bytes.Equal(buff.EncodePacketRaw(packet), data[:n]) {
+		t.Error("packet encoded differently")
+	}
+	if _, _, err := DecodePacket(data[:n-1]); !errors.Is(err, ErrShortPacket) {
+		t.Errorf("expected short packet error got %v", err)
+	}
+}
+
+func TestReadPacketShortHeader(t *testing.T) {
+	headers := [][]byte{
+		{},
+		{0x00},
+		make([]byte, 11),
+		// The extended length flag needs two more header bytes
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0x10, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0x10, 0, 0, 0},
+	}
+	for _, data := range headers {
+		buff := PacketBuff{Buffer: bytes.NewBuffer(data)}
+		if packet := buff.ReadPacket(); packet != nil {
+			t.Errorf("read %x as %+v", data, packet)
+		}
+		if buff.Len() != len(data) {
+			t.Errorf("reading %x consumed %d bytes", data, len(data)-buff.Len())
+		}
+		if _, _, err := DecodePacket(data); !errors.Is(err, ErrShortPacket) {
+			t.Errorf("decoding %x gave %v", data, err)
+		}
+	}
+}
+
+func TestEncoderMatchesEncodePacket(t *testing.T) {
+	large := NewBlob("BLOB", make([]byte, 0x10010))
+	for _, content := range [][]Tdf{nil, benchContent(), {large}} {
+		buff := PacketBuff{}
+		expected := buff.EncodePacket(0x4, 0x1, 0, NotificationType, 0, content)
+		encoder := AcquireEncoder()
+		out := encoder.Packet(0x4, 0x1, 0, NotificationType, 0, content)
+		if !bytes.Equal(out, expected) {
+			t.Errorf("encoder wrote different bytes\n%x\n%x", expected[:16], out[:16])
+		}
+		encoder.Release()
+	}
+}
+
+func BenchmarkReadContentPacketBuff(b *testing.B) {
+	buff := PacketBuff{}
+	data := buff.EncodePacket(0x7802, 0x1A, 0, RequestType, 1, benchContent())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		packet := PacketBuff{Buffer: bytes.NewBuffer(data)}
+		_ = readContentBuff(packet.ReadPacket().Content)
+	}
+}
+
+func BenchmarkReadContentDecoder(b *testing.B) {
+	buff := PacketBuff{}
+	data := buff.EncodePacket(0x7802, 0x1A, 0, RequestType, 1, benchContent())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		packet, _, _ := DecodePacket(data)
+		_ = DecodeContent(packet.Content)
+	}
+}
+
+func BenchmarkEncodePacketBuff(b *testing.B) {
+	content := benchContent()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buff := PacketBuff{}
+		_ = buff.EncodePacket(0x7802, 0x1A, 0, ResponseType, 1, content)
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	content := benchContent()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoder := AcquireEncoder()
+		_ = encoder.Packet(0x7802, 0x1A, 0, ResponseType, 1, content)
+		encoder.Release()
+	}
+}
@@ -0,0 +1,639 @@
+package blaze
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+// The text format describes values the same way FormatCompact writes them:
+//
+//	PDTL { NAME: "Shepard"; PID: 12345 }
+//	IDS: [1, 2, 3]
+//	CONF: { "key": "value" }
+//	ADDR: union(0) VALU { HOST: "127.0.0.1"; PORT: 14219 }
+//	BLOB: <deadbeef>
+//	BOID: (25, 1, 100)
+//	GRID: [[1, 2], list<int> []]
+//	VALS: [map { 1: "a" }, union(1) NAME: "x", varlist [1]]
+//	GNRC: generic(5) VALU: 1
+//
+// Values are separated by semicolons, commas or new lines. Floats need a
+// decimal point or exponent to tell them apart from ints. The types of
+// lists and maps are worked out from their items but can be given as
+// list<string> [] or map<int, struct> {}. A struct that starts with the 2
+// byte is written as struct2 { ... } and an empty union as union(empty).
+// Within lists and maps braces are a struct so maps are written as map {}.
+// Comments start with # or // and run to the end of the line
+
+// SyntaxError is an error in the text format along with where it occurred
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// ParseTdf parses a single value from the text format
+func ParseTdf(text string) (Tdf, error) {
+	p, err := newParser(text)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSeparators()
+	value, err := p.entry()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSeparators()
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorAt(t, "unexpected %s after value", t)
+	}
+	return value, nil
+}
+
+// ParseContent parses a list of values from the text format such as the
+// content of a packet
+func ParseContent(text string) ([]Tdf, error) {
+	p, err := newParser(text)
+	if err != nil {
+		return nil, err
+	}
+	return p.content(tokenEOF)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenBlob
+	tokenPunct
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return "string " + t.text
+	case tokenBlob:
+		return "blob <" + t.text + ">"
+	default:
+		return strconv.Quote(t.text)
+	}
+}
+
+// lex splits the text into tokens
+func lex(text string) ([]token, error) {
+	var tokens []token
+	runes := []rune(text)
+	line, column := 1, 1
+	i := 0
+	advance := func(n int) {
+		for ; n > 0; n-- {
+			if runes[i] == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+			i++
+		}
+	}
+	for i < len(runes) {
+		r := runes[i]
+		start := token{line: line, column: column}
+		switch {
+		case unicode.IsSpace(r):
+			advance(1)
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				advance(1)
+			}
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				if end < len(runes) && runes[end] == '\n' {
+					break
+				}
+				end++
+			}
+			if end >= len(runes) || runes[end] != '"' {
+				return nil, &SyntaxError{line, column, "unterminated string"}
+			}
+			start.kind, start.text = tokenString, string(runes[i:end+1])
+			advance(end + 1 - i)
+			tokens = append(tokens, start)
+		case r == '<' && isBlob(runes[i+1:]):
+			end := i + 1
+			for runes[end] != '>' {
+				end++
+			}
+			start.kind = tokenBlob
+			start.text = strings.Join(strings.Fields(string(runes[i+1:end])), "")
+			advance(end + 1 - i)
+			tokens = append(tokens, start)
+		case unicode.IsDigit(r) || ((r == '-' || r == '+') && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '.' ||
+				((runes[end] == '-' || runes[end] == '+') && (runes[end-1] == 'e' || runes[end-1] == 'E'))) {
+				end++
+			}
+			number := string(runes[i:end])
+			start.kind, start.text = tokenInt, number
+			lower := strings.ToLower(number)
+			if !strings.Contains(lower, "0x") && strings.ContainsAny(lower, ".e") {
+				start.kind = tokenFloat
+			}
+			advance(end - i)
+			tokens = append(tokens, start)
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			start.kind, start.text = tokenIdent, string(runes[i:end])
+			advance(end - i)
+			tokens = append(tokens, start)
+		case strings.ContainsRune("{}[]():;,<>", r):
+			start.kind, start.text = tokenPunct, string(r)
+			advance(1)
+			tokens = append(tokens, start)
+		default:
+			return nil, &SyntaxError{line, column, fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line, column: column}), nil
+}
+
+// isBlob checks whether the text after a < is hex followed by > which tells
+// a blob apart from the type of a list or map
+func isBlob(runes []rune) bool {
+	for _, r := range runes {
+		switch {
+		case r == '>':
+			return true
+		case unicode.IsSpace(r) || strings.ContainsRune("0123456789abcdefABCDEF", r):
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func newParser(text string) (*parser, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorAt(t token, format string, args ...any) error {
+	return &SyntaxError{Line: t.line, Column: t.column, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isPunct(text string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.text == text
+}
+
+func (p *parser) expect(text string) error {
+	t := p.next()
+	if t.kind != tokenPunct || t.text != text {
+		return p.errorAt(t, "expected %q but found %s", text, t)
+	}
+	return nil
+}
+
+func (p *parser) skipSeparators() {
+	for p.isPunct(";") || p.isPunct(",") {
+		p.next()
+	}
+}
+
+// content parses labelled values until the closing token
+func (p *parser) content(until tokenKind) ([]Tdf, error) {
+	out := []Tdf{}
+	for {
+		p.skipSeparators()
+		t := p.peek()
+		if t.kind == tokenEOF || (until == tokenPunct && p.isPunct("}")) {
+			return out, nil
+		}
+		value, err := p.entry()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+}
+
+// entry parses a single labelled value
+func (p *parser) entry() (Tdf, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return nil, p.errorAt(t, "expected a label but found %s", t)
+	}
+	label := t.text
+	if _, err := ParseLabel(label); err != nil {
+		return nil, p.errorAt(t, "%v", err)
+	}
+	if p.isPunct("{") {
+		values, err := p.structValues()
+		if err != nil {
+			return nil, err
+		}
+		return NewStruct(label, values...), nil
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	return p.typed(label)
+}
+
+// structValues parses the values between the braces of a struct
+func (p *parser) structValues() ([]Tdf, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	values, err := p.content(tokenPunct)
+	if err != nil {
+		return nil, err
+	}
+	return values, p.expect("}")
+}
+
+// typed parses the value after the label and colon
+func (p *parser) typed(label string) (Tdf, error) {
+	t := p.peek()
+	if t.kind == tokenIdent {
+		switch t.text {
+		case "struct", "struct2":
+			p.next()
+			values, err := p.structValues()
+			if err != nil {
+				return nil, err
+			}
+			out := NewStruct(label, values...)
+			out.Start2 = t.text == "struct2"
+			return out, nil
+		case "list":
+			p.next()
+			subType := TdfType(0xFF)
+			if p.isPunct("<") {
+				types, err := p.typeParams(1)
+				if err != nil {
+					return nil, err
+				}
+				subType = types[0]
+			}
+			return p.list(label, subType)
+		case "map":
+			p.next()
+			keyType, valueType := TdfType(0xFF), TdfType(0xFF)
+			if p.isPunct("<") {
+				types, err := p.typeParams(2)
+				if err != nil {
+					return nil, err
+				}
+				keyType, valueType = types[0], types[1]
+			}
+			return p.pairs(label, keyType, valueType)
+		case "varlist":
+			p.next()
+			items, _, err := p.items("[", "]", IntType)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]int64, len(items))
+			for i, item := range items {
+				values[i] = item.(int64)
+			}
+			return NewVarIntList(label, values), nil
+		case "union":
+			p.next()
+			return p.union(label)
+		case "generic":
+			p.next()
+			return p.generic(label)
+		default:
+			return nil, p.errorAt(t, "unknown type %q", t.text)
+		}
+	}
+	switch {
+	case p.isPunct("["):
+		return p.list(label, 0xFF)
+	case p.isPunct("{"):
+		return p.pairs(label, 0xFF, 0xFF)
+	}
+	item, itemType, err := p.item(0xFF)
+	if err != nil {
+		return nil, err
+	}
+	if itemType == StructType {
+		return nil, p.errorAt(t, "struct values are written as %s { ... }", label)
+	}
+	return labelItem(label, itemType, item)
+}
+
+// typeParams parses the types between angle brackets
+func (p *parser) typeParams(count int) ([]TdfType, error) {
+	if err := p.expect("<"); err != nil {
+		return nil, err
+	}
+	var out []TdfType
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		t := p.next()
+		if t.kind != tokenIdent {
+			return nil, p.errorAt(t, "expected a type but found %s", t)
+		}
+		parsed, err := ParseTdfType(t.text)
+		if err != nil {
+			return nil, p.errorAt(t, "%v", err)
+		}
+		out = append(out, parsed)
+	}
+	return out, p.expect(">")
+}
+
+func (p *parser) list(label string, subType TdfType) (Tdf, error) {
+	start := p.peek()
+	items, itemType, err := p.items("[", "]", subType)
+	if err != nil {
+		return nil, err
+	}
+	if itemType == 0xFF {
+		itemType = IntType
+	}
+	out, err := NewListOf(label, SubType(itemType), items)
+	if err != nil {
+		return nil, p.errorAt(start, "%v", err)
+	}
+	return out, nil
+}
+
+// items parses a bracketed list of items which must all have the same type.
+// The type is worked out from the first item when it is unknown (0xFF)
+func (p *parser) items(open string, close string, itemType TdfType) ([]any, TdfType, error) {
+	if err := p.expect(open); err != nil {
+		return nil, itemType, err
+	}
+	out := []any{}
+	for {
+		p.skipSeparators()
+		if p.isPunct(close) {
+			p.next()
+			return out, itemType, nil
+		}
+		t := p.peek()
+		if t.kind == tokenEOF {
+			return nil, itemType, p.errorAt(t, "expected %q but found %s", close, t)
+		}
+		item, parsedType, err := p.item(itemType)
+		if err != nil {
+			return nil, itemType, err
+		}
+		if itemType == 0xFF {
+			itemType = parsedType
+		}
+		out = append(out, item)
+	}
+}
+
+func (p *parser) pairs(label string, keyType TdfType, valueType TdfType) (Tdf, error) {
+	start := p.peek()
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var keys, values []any
+	for {
+		p.skipSeparators()
+		if p.isPunct("}") {
+			p.next()
+			break
+		}
+		if t := p.peek(); t.kind == tokenEOF {
+			return nil, p.errorAt(t, "expected \"}\" but found %s", t)
+		}
+		key, parsedType, err := p.item(keyType)
+		if err != nil {
+			return nil, err
+		}
+		keyType = parsedType
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, parsedType, err := p.item(valueType)
+		if err != nil {
+			return nil, err
+		}
+		valueType = parsedType
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if keyType == 0xFF {
+		keyType = StringType
+	}
+	if valueType == 0xFF {
+		valueType = StringType
+	}
+	out, err := NewMapOf(label, SubType(keyType), SubType(valueType), keys, values)
+	if err != nil {
+		return nil, p.errorAt(start, "%v", err)
+	}
+	return out, nil
+}
+
+func (p *parser) union(label string) (Tdf, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	if t.kind == tokenIdent && t.text == "empty" {
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return NewUnion(label, EmptyType, nil), nil
+	}
+	if t.kind != tokenInt {
+		return nil, p.errorAt(t, "expected the union type but found %s", t)
+	}
+	unionType, err := strconv.ParseUint(t.text, 0, 8)
+	if err != nil {
+		return nil, p.errorAt(t, "invalid union type %s", t.text)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	content, err := p.entry()
+	if err != nil {
+		return nil, err
+	}
+	return NewUnion(label, TdfType(unionType), content), nil
+}
+
+func (p *parser) generic(label string) (Tdf, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	if t.kind == tokenIdent && t.text == "empty" {
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return NewGeneric(label, 0, nil), nil
+	}
+	if t.kind != tokenInt {
+		return nil, p.errorAt(t, "expected the generic type id but found %s", t)
+	}
+	id, err := strconv.ParseInt(t.text, 0, 64)
+	if err != nil {
+		return nil, p.errorAt(t, "invalid generic type id %s", t.text)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	value, err := p.entry()
+	if err != nil {
+		return nil, err
+	}
+	return NewGeneric(label, id, value), nil
+}
+
+// item parses an unlabelled value. When the expected type is known the
+// value must be of that type
+func (p *parser) item(expected TdfType) (any, TdfType, error) {
+	t := p.peek()
+	var value any
+	var itemType TdfType
+	switch {
+	case t.kind == tokenInt:
+		p.next()
+		number, err := strconv.ParseInt(t.text, 0, 64)
+		if err != nil {
+			unsigned, uerr := strconv.ParseUint(t.text, 0, 64)
+			if uerr != nil {
+				return nil, 0, p.errorAt(t, "invalid int %s", t.text)
+			}
+			number = int64(unsigned)
+		}
+		if expected == FloatType {
+			return float64(number), FloatType, nil
+		}
+		value, itemType = number, IntType
+	case t.kind == tokenFloat:
+		p.next()
+		number, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, 0, p.errorAt(t, "invalid float %s", t.text)
+		}
+		value, itemType = number, FloatType
+	case t.kind == tokenString:
+		p.next()
+		text, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, 0, p.errorAt(t, "invalid string %s", t.text)
+		}
+		value, itemType = text, StringType
+	case t.kind == tokenBlob:
+		p.next()
+		data, err := hex.DecodeString(t.text)
+		if err != nil {
+			return nil, 0, p.errorAt(t, "invalid blob: %v", err)
+		}
+		value, itemType = data, BlobType
+	case t.kind == tokenPunct && t.text == "(":
+		numbers, _, err := p.items("(", ")", IntType)
+		if err != nil {
+			return nil, 0, err
+		}
+		ints := make([]int64, len(numbers))
+		for i, number := range numbers {
+			ints[i] = number.(int64)
+		}
+		switch len(ints) {
+		case 2:
+			value, itemType = Pair{A: ints[0], B: ints[1]}, PairType
+		case 3:
+			value, itemType = Triple{A: ints[0], B: ints[1], C: ints[2]}, TripleType
+		default:
+			return nil, 0, p.errorAt(t, "expected a pair or triple but found %d values", len(ints))
+		}
+	case t.kind == tokenPunct && t.text == "{", t.kind == tokenIdent && (t.text == "struct" || t.text == "struct2"):
+		start2 := false
+		if t.kind == tokenIdent {
+			p.next()
+			start2 = t.text == "struct2"
+		}
+		values, err := p.structValues()
+		if err != nil {
+			return nil, 0, err
+		}
+		value, itemType = NewStructStub(values, start2), StructType
+	case t.kind == tokenPunct && t.text == "[", t.kind == tokenIdent && (t.text == "list" || t.text == "map" || t.text == "varlist" || t.text == "union"):
+		nested, err := p.typed("")
+		if err != nil {
+			return nil, 0, err
+		}
+		switch v := nested.(type) {
+		case ListValue:
+			value, itemType = NestedList{v}, ListType
+		case MapValue:
+			value, itemType = NestedMap{v}, PairListType
+		case UnionTdf:
+			value, itemType = v, UnionType
+		case VarIntListTdf:
+			value, itemType = v, VarIntListType
+		}
+	default:
+		return nil, 0, p.errorAt(t, "expected a value but found %s", t)
+	}
+	if expected != 0xFF && expected != itemType {
+		return nil, 0, p.errorAt(t, "expected %s but found %s", expected, itemType)
+	}
+	return value, itemType, nil
+}
@@ -0,0 +1,96 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+func TestParseTdf(t *testing.T) {
+	value, err := ParseTdf(`PDTL { NAME: "Shepard"; PID: 12345 }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := NewStruct("PDTL", NewString("NAME", "Shepard"), NewInt64("PID", 12345))
+	if !bytes.Equal(encodeContent(value), encodeContent(expected)) {
+		t.Errorf("unexpected value %s", Format(value))
+	}
+}
+
+func TestParseContent(t *testing.T) {
+	text := `
+		# Comments and new lines separate values
+		CONF: { "a": "1", "b": "2" }
+		IDS: list<int> []
+		FLTS: [1.5, 2.0]
+		MAP: map<int, struct> { 1: { A: 1 } }
+		ADDR: union(0) VALU {
+			HOST: "127.0.0.1" // trailing comment
+			PORT: 0x378B
+		}
+		HEAD: struct2 { VALU: -1 }
+		BLOB: <de ad>
+		BOID: (25, 1, 100)
+	`
+	values, err := ParseContent(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Tdf{
+		NewMap("CONF", []string{"a", "b"}, []string{"1", "2"}),
+		NewList("IDS", []int64{}),
+		NewList("FLTS", []float64{1.5, 2.0}),
+		NewMap("MAP", []int64{1}, []StructTdf{NewStructStub([]Tdf{NewInt64("A", 1)}, false)}),
+		NewUnion("ADDR", 0, NewStruct("VALU", NewString("HOST", "127.0.0.1"), NewInt64("PORT", 14219))),
+		NewStruct2("HEAD", NewInt64("VALU", -1)),
+		NewBlob("BLOB", []byte{0xDE, 0xAD}),
+		NewTriple("BOID", Triple{A: 25, B: 1, C: 100}),
+	}
+	if !bytes.Equal(encodeContent(values...), encodeContent(expected...)) {
+		t.Errorf("unexpected values\n%s", FormatContent(values, DefaultFormatOptions))
+	}
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	options := CompactFormatOptions
+	options.MaxString, options.MaxBlob, options.MaxItems = 0, 0, 0
+	wire := encodeContent(roundTripContent()...)
+	text := FormatContent(decodeContent(wire), options)
+	values, err := ParseContent(text)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, text)
+	}
+	if out := encodeContent(values...); !bytes.Equal(out, wire) {
+		t.Errorf("round trip changed the bytes\n%s\n%s", text, FormatContent(values, options))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		text   string
+		line   int
+		column int
+	}{
+		{`NAME: "Shepard`, 1, 7},
+		{"PDTL {\n  NAME \"x\"\n}", 2, 8},
+		{`TOOLONG: 1`, 1, 1},
+		{`IDS: [1, "a"]`, 1, 10},
+		{`ADDR: union(x) VALU {}`, 1, 13},
+		{"A: 1\nB: @", 2, 4},
+		{`PDTL { A: 1`, 1, 12},
+		{`A: list<nope> []`, 1, 9},
+	}
+	for _, c := range cases {
+		_, err := ParseContent(c.text)
+		var syntax *SyntaxError
+		if !errors.As(err, &syntax) {
+			t.Errorf("%q: expected syntax error got %v", c.text, err)
+			continue
+		}
+		if syntax.Line != c.line || syntax.Column != c.column {
+			t.Errorf("%q: expected error at %d:%d got %v", c.text, c.line, c.column, err)
+		}
+	}
+}
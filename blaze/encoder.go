@@ -0,0 +1,90 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+)
+
+// maxHeaderSize is the size of a packet header with the extended length
+const maxHeaderSize = 14
+
+// Encoder encodes packets into a buffer that is reused between packets.
+// Encoders are taken from a pool with AcquireEncoder and given back with
+// Release once the encoded bytes are no longer needed
+type Encoder struct {
+	buf PacketBuff
+}
+
+var encoderPool = sync.Pool{
+	New: func() any {
+		return &Encoder{buf: PacketBuff{Buffer: &bytes.Buffer{}}}
+	},
+}
+
+// maxPooledSize stops unusually large buffers from being kept in the pool
+const maxPooledSize = 64 * 1024
+
+// AcquireEncoder takes an encoder from the pool
+func AcquireEncoder() *Encoder {
+	return encoderPool.Get().(*Encoder)
+}
+
+// Release gives the encoder back to the pool. The bytes returned by the
+// encoder must not be used after it is released
+func (e *Encoder) Release() {
+	if e.buf.Cap() > maxPooledSize {
+		return
+	}
+	encoderPool.Put(e)
+}
+
+// Packet encodes a packet with the provided content. The returned bytes are
+// only valid until the next call or until the encoder is released
+func (e *Encoder) Packet(comp uint16, cmd uint16, err uint16, qType uint16, id uint16, content []Tdf) []byte {
+	e.buf.Reset()
+	// Room is left for the largest header which is filled in once the
+	// length of the content is known
+	var header [maxHeaderSize]byte
+	_, _ = e.buf.Write(header[:])
+	for _, value := range content {
+		WriteTdf(&e.buf, value)
+	}
+	data := e.buf.Bytes()
+	l := len(data) - maxHeaderSize
+	start := 2
+	qType &^= 0x10
+	if l > 0xFFFF {
+		start = 0
+		qType |= 0x10
+		binary.BigEndian.PutUint16(data[12:], uint16(l>>16))
+	}
+	putHeader(data[start:], uint16(l), comp, cmd, err, qType, id)
+	return data[start:]
+}
+
+// AppendPacket appends the encoded packet to dst
+func AppendPacket(dst []byte, packet Packet) []byte {
+	n := 12
+	if (packet.QType & 0x10) != 0 {
+		n = 14
+	}
+	start := len(dst)
+	for i := 0; i < n; i++ {
+		dst = append(dst, 0)
+	}
+	putHeader(dst[start:], packet.Length, packet.Component, packet.Command, packet.Error, packet.QType, packet.Id)
+	if n == 14 {
+		binary.BigEndian.PutUint16(dst[start+12:], packet.ExtLength)
+	}
+	return append(dst, packet.Content...)
+}
+
+func putHeader(dst []byte, length uint16, comp uint16, cmd uint16, err uint16, qType uint16, id uint16) {
+	binary.BigEndian.PutUint16(dst[0:], length)
+	binary.BigEndian.PutUint16(dst[2:], comp)
+	binary.BigEndian.PutUint16(dst[4:], cmd)
+	binary.BigEndian.PutUint16(dst[6:], err)
+	binary.BigEndian.PutUint16(dst[8:], qType)
+	binary.BigEndian.PutUint16(dst[10:], id)
+}
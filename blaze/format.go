@@ -0,0 +1,441 @@
+package blaze
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+// FormatOptions change how values are formatted
+type FormatOptions struct {
+	// Compact writes everything on a single line
+	Compact bool
+	// Indent is repeated for each level of nesting
+	Indent string
+	// MaxString is the number of characters shown for strings before they
+	// are truncated, zero shows everything
+	MaxString int
+	// MaxBlob is the number of bytes shown for blobs
+	MaxBlob int
+	// MaxItems is the number of items shown for lists and maps
+	MaxItems int
+	// Schema adds a comment with the meaning of each field described by
+	// the schema. Comments are only written when not compact
+	Schema *Schema
+}
+
+// DefaultFormatOptions are the options used by Format
+var DefaultFormatOptions = FormatOptions{
+	Indent:    "  ",
+	MaxString: 256,
+	MaxBlob:   64,
+	MaxItems:  64,
+}
+
+// CompactFormatOptions are the options used by FormatCompact
+var CompactFormatOptions = FormatOptions{
+	Compact:   true,
+	MaxString: 64,
+	MaxBlob:   16,
+	MaxItems:  8,
+}
+
+var typeNames = map[TdfType]string{
+	IntType:        "int",
+	StringType:     "string",
+	BlobType:       "blob",
+	StructType:     "struct",
+	ListType:       "list",
+	PairListType:   "map",
+	UnionType:      "union",
+	VarIntListType: "varlist",
+	PairType:       "pair",
+	TripleType:     "triple",
+	FloatType:      "float",
+	GenericType:    "generic",
+	EmptyType:      "empty",
+}
+
+func (t TdfType) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type(%d)", byte(t))
+}
+
+// Format formats the value as an indented tree with a line for each value
+// showing its label, type and value
+func Format(value Tdf) string {
+	return FormatWith(value, DefaultFormatOptions)
+}
+
+// FormatCompact formats the value on a single line for use in log lines
+func FormatCompact(value Tdf) string {
+	return FormatWith(value, CompactFormatOptions)
+}
+
+// FormatWith formats the value using the provided options
+func FormatWith(value Tdf, options FormatOptions) string {
+	f := newFormatter(options)
+	f.tdf(value, 0)
+	return strings.TrimSuffix(f.out.String(), "\n")
+}
+
+// FormatContent formats a list of values such as the contents of a packet
+func FormatContent(values []Tdf, options FormatOptions) string {
+	f := newFormatter(options)
+	f.values(values, 0)
+	return strings.TrimSuffix(f.out.String(), "\n")
+}
+
+type formatter struct {
+	options FormatOptions
+	out     strings.Builder
+	// fields describes the values being written when there is a schema
+	fields []Field
+}
+
+func newFormatter(options FormatOptions) *formatter {
+	f := &formatter{options: options}
+	if options.Schema != nil && !options.Compact {
+		f.fields = options.Schema.Fields
+	}
+	return f
+}
+
+// line starts a new line at the provided depth. In compact mode values are
+// separated with the separator instead
+func (f *formatter) line(depth int, first bool, separator string) {
+	if f.options.Compact {
+		if !first {
+			f.out.WriteString(separator)
+		}
+		return
+	}
+	f.out.WriteString(strings.Repeat(f.options.Indent, depth))
+}
+
+func (f *formatter) end() {
+	if !f.options.Compact {
+		f.out.WriteByte('\n')
+	}
+}
+
+// open starts a block of nested values and close ends it
+func (f *formatter) open(bracket string) {
+	f.out.WriteString(bracket)
+	if f.options.Compact {
+		f.out.WriteByte(' ')
+	} else {
+		f.out.WriteByte('\n')
+	}
+}
+
+func (f *formatter) close(depth int, bracket string) {
+	if f.options.Compact {
+		f.out.WriteString(" " + bracket)
+		return
+	}
+	f.out.WriteString(strings.Repeat(f.options.Indent, depth) + bracket)
+}
+
+func (f *formatter) values(values []Tdf, depth int) {
+	first := true
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		f.line(depth, first, "; ")
+		f.tdf(value, depth)
+		f.end()
+		first = false
+	}
+}
+
+// tdf writes a labelled value without the leading indent or trailing line
+func (f *formatter) tdf(value Tdf, depth int) {
+	head := value.GetHead()
+	label := strings.TrimRight(head.Label, " \x00")
+	if !f.options.Compact {
+		// The fields of nested values are used while writing this value
+		parent := f.fields
+		field, ok := lookupField(parent, head.Tag)
+		if ok && field.Doc != "" {
+			f.out.WriteString("// " + field.Doc + "\n" + strings.Repeat(f.options.Indent, depth))
+		}
+		f.fields = field.Fields
+		f.out.WriteString(label + " (" + f.typeName(value) + ") = ")
+		f.value(value, depth)
+		f.fields = parent
+		return
+	}
+	// The compact form is the text format read by ParseTdf so any types
+	// that can't be worked out from the value are written before it
+	switch v := value.(type) {
+	case StructTdf:
+		if !v.Start2 {
+			f.out.WriteString(label + " ")
+			f.value(value, depth)
+			return
+		}
+		f.out.WriteString(label + ": struct2 ")
+	case UnionTdf:
+		if v.Type == EmptyType || v.Content == nil {
+			f.out.WriteString(label + ": union(empty)")
+			return
+		}
+		_, _ = fmt.Fprintf(&f.out, "%s: union(%d) ", label, v.Type)
+		f.tdf(v.Content, depth)
+		return
+	case GenericTdf:
+		if v.Value == nil {
+			f.out.WriteString(label + ": generic(empty)")
+			return
+		}
+		_, _ = fmt.Fprintf(&f.out, "%s: generic(%d) ", label, v.Id)
+		f.tdf(v.Value, depth)
+		return
+	case ListValue:
+		f.out.WriteString(label + ": ")
+		if v.Len() == 0 {
+			_, _ = fmt.Fprintf(&f.out, "list<%s> ", TdfType(v.ItemType()))
+		}
+	case MapValue:
+		f.out.WriteString(label + ": ")
+		if v.Len() == 0 {
+			_, _ = fmt.Fprintf(&f.out, "map<%s, %s> ", TdfType(v.KeyType()), TdfType(v.ValueType()))
+		}
+	case VarIntListTdf:
+		f.out.WriteString(label + ": varlist ")
+	default:
+		f.out.WriteString(label + ": ")
+	}
+	f.value(value, depth)
+}
+
+// typeName describes the type of a value including the type of list items
+func (f *formatter) typeName(value Tdf) string {
+	switch v := value.(type) {
+	case ListValue:
+		return fmt.Sprintf("list<%s>[%d]", TdfType(v.ItemType()), v.Len())
+	case MapValue:
+		return fmt.Sprintf("map<%s, %s>[%d]", TdfType(v.KeyType()), TdfType(v.ValueType()), v.Len())
+	case VarIntListTdf:
+		return fmt.Sprintf("varlist[%d]", len(v.Values))
+	case UnionTdf:
+		if v.Type == EmptyType {
+			return "union<empty>"
+		}
+		return fmt.Sprintf("union<%d>", v.Type)
+	case GenericTdf:
+		if v.Value == nil {
+			return "generic<empty>"
+		}
+		return fmt.Sprintf("generic<%d>", v.Id)
+	case BlobTdf:
+		return fmt.Sprintf("blob[%d]", len(v.Data))
+	case StructTdf:
+		if v.Start2 {
+			return "struct2"
+		}
+		return "struct"
+	default:
+		return value.GetHead().Type.String()
+	}
+}
+
+// value writes any value found within a tdf tree
+func (f *formatter) value(value any, depth int) {
+	switch v := value.(type) {
+	case Int64Tdf:
+		f.int(v.Value)
+	case int64:
+		f.int(v)
+	case StringTdf:
+		f.string(v.Value)
+	case string:
+		f.string(v)
+	case BlobTdf:
+		f.blob(v.Data)
+	case []byte:
+		f.blob(v)
+	case FloatTdf:
+		f.float(v.Value)
+	case float64:
+		f.float(v)
+	case PairTdf:
+		f.value(v.Pair, depth)
+	case Pair:
+		_, _ = fmt.Fprintf(&f.out, "(%d, %d)", v.A, v.B)
+	case TripleTdf:
+		f.value(v.Triple, depth)
+	case Triple:
+		_, _ = fmt.Fprintf(&f.out, "(%d, %d, %d)", v.A, v.B, v.C)
+	case StructTdf:
+		if v.Start2 && f.options.Compact && v.Label == "" {
+			f.out.WriteString("struct2 ")
+		}
+		if len(v.Values) == 0 {
+			f.out.WriteString("{}")
+			return
+		}
+		f.open("{")
+		f.values(v.Values, depth+1)
+		f.close(depth, "}")
+	case UnionTdf:
+		if f.options.Compact && v.Label == "" {
+			// Unions within lists are written the same way as labelled
+			// unions in the text format
+			if v.Type == EmptyType || v.Content == nil {
+				f.out.WriteString("union(empty)")
+				return
+			}
+			_, _ = fmt.Fprintf(&f.out, "union(%d) ", v.Type)
+			f.tdf(v.Content, depth)
+			return
+		}
+		f.nested(v.Content, v.Type == EmptyType, depth)
+	case GenericTdf:
+		f.nested(v.Value, false, depth)
+	case NestedList:
+		if f.options.Compact && v.Len() == 0 {
+			_, _ = fmt.Fprintf(&f.out, "list<%s> ", TdfType(v.ItemType()))
+		}
+		f.value(v.ListValue, depth)
+	case NestedMap:
+		// Braces on their own are read as a struct within lists
+		if f.options.Compact {
+			f.out.WriteString("map ")
+			if v.Len() == 0 {
+				_, _ = fmt.Fprintf(&f.out, "<%s, %s> ", TdfType(v.KeyType()), TdfType(v.ValueType()))
+			}
+		}
+		f.value(v.MapValue, depth)
+	case ListValue:
+		f.list(v.Len(), v.Item, depth)
+	case VarIntListTdf:
+		if f.options.Compact && v.Label == "" {
+			f.out.WriteString("varlist ")
+		}
+		f.list(len(v.Values), func(i int) any { return v.Values[i] }, depth)
+	case MapValue:
+		f.pairs(v, depth)
+	case nil:
+		f.out.WriteString("<nil>")
+	default:
+		_, _ = fmt.Fprintf(&f.out, "%v", v)
+	}
+}
+
+// nested writes the value within a union or generic
+func (f *formatter) nested(value Tdf, empty bool, depth int) {
+	if empty || value == nil {
+		f.out.WriteString("<empty>")
+		return
+	}
+	f.open("{")
+	f.line(depth+1, true, "")
+	f.tdf(value, depth+1)
+	f.end()
+	f.close(depth, "}")
+}
+
+func (f *formatter) int(value int64) {
+	if f.options.Compact || (value >= 0 && value < 10) {
+		f.out.WriteString(strconv.FormatInt(value, 10))
+		return
+	}
+	_, _ = fmt.Fprintf(&f.out, "%d (0x%X)", value, uint64(value))
+}
+
+// float always includes a decimal point or exponent so that floats can be
+// told apart from ints
+func (f *formatter) float(value float64) {
+	text := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".eIN") {
+		text += ".0"
+	}
+	f.out.WriteString(text)
+}
+
+func (f *formatter) string(value string) {
+	max := f.options.MaxString
+	runes := []rune(value)
+	if max > 0 && len(runes) > max {
+		_, _ = fmt.Fprintf(&f.out, "%s... (%d chars)", strconv.Quote(string(runes[:max])), len(runes))
+		return
+	}
+	f.out.WriteString(strconv.Quote(value))
+}
+
+func (f *formatter) blob(data []byte) {
+	max := f.options.MaxBlob
+	if max > 0 && len(data) > max {
+		_, _ = fmt.Fprintf(&f.out, "<%s...> (%d bytes)", hex.EncodeToString(data[:max]), len(data))
+		return
+	}
+	f.out.WriteString("<" + hex.EncodeToString(data) + ">")
+}
+
+// more writes the number of items that were not shown
+func (f *formatter) more(depth int, first bool, count int) {
+	f.line(depth, first, ", ")
+	_, _ = fmt.Fprintf(&f.out, "... %d more", count)
+	f.end()
+}
+
+// list writes count items where item returns the item at an index
+func (f *formatter) list(count int, item func(i int) any, depth int) {
+	if count == 0 {
+		f.out.WriteString("[]")
+		return
+	}
+	f.open("[")
+	for i := 0; i < count; i++ {
+		if f.options.MaxItems > 0 && i >= f.options.MaxItems {
+			f.more(depth+1, false, count-i)
+			break
+		}
+		f.line(depth+1, i == 0, ", ")
+		f.value(item(i), depth+1)
+		f.end()
+	}
+	f.close(depth, "]")
+}
+
+func (f *formatter) pairs(value MapValue, depth int) {
+	count := value.Len()
+	if count == 0 {
+		f.out.WriteString("{}")
+		return
+	}
+	f.open("{")
+	for i := 0; i < count; i++ {
+		if f.options.MaxItems > 0 && i >= f.options.MaxItems {
+			f.more(depth+1, false, count-i)
+			break
+		}
+		f.line(depth+1, i == 0, ", ")
+		f.value(value.Key(i), depth+1)
+		f.out.WriteString(": ")
+		f.value(value.Value(i), depth+1)
+		f.end()
+	}
+	f.close(depth, "}")
+}
+
+func (t Int64Tdf) String() string      { return Format(t) }
+func (t StringTdf) String() string     { return Format(t) }
+func (t BlobTdf) String() string       { return Format(t) }
+func (t StructTdf) String() string     { return Format(t) }
+func (t List[T]) String() string       { return Format(t) }
+func (t Map[K, V]) String() string     { return Format(t) }
+func (t UnionTdf) String() string      { return Format(t) }
+func (t VarIntListTdf) String() string { return Format(t) }
+func (t PairTdf) String() string       { return Format(t) }
+func (t TripleTdf) String() string     { return Format(t) }
+func (t FloatTdf) String() string      { return Format(t) }
+func (t GenericTdf) String() string    { return Format(t) }
@@ -0,0 +1,59 @@
+package blaze
+
+import (
+	"strings"
+	"testing"
+)
+
+func formatTestValue() StructTdf {
+	return NewStruct("PDTL",
+		NewString("NAME", "Shepard"),
+		NewInt64("PID", 12345),
+		NewBlob("BLOB", []byte{0xDE, 0xAD}),
+		NewList("IDS", []int64{1, 2}),
+	)
+}
+
+func TestFormat(t *testing.T) {
+	expected := strings.Join([]string{
+		`PDTL (struct) = {`,
+		`  NAME (string) = "Shepard"`,
+		`  PID (int) = 12345 (0x3039)`,
+		`  BLOB (blob[2]) = <dead>`,
+		`  IDS (list<int>[2]) = [`,
+		`    1`,
+		`    2`,
+		`  ]`,
+		`}`,
+	}, "\n")
+	value := formatTestValue()
+	if out := Format(value); out != expected {
+		t.Errorf("unexpected format\n%s", out)
+	}
+	if out := value.String(); out != expected {
+		t.Errorf("String differs from Format\n%s", out)
+	}
+}
+
+func TestFormatCompact(t *testing.T) {
+	expected := `PDTL { NAME: "Shepard"; PID: 12345; BLOB: <dead>; IDS: [ 1, 2 ] }`
+	if out := FormatCompact(formatTestValue()); out != expected {
+		t.Errorf("unexpected compact format\n%s", out)
+	}
+}
+
+func TestFormatTruncate(t *testing.T) {
+	options := FormatOptions{Compact: true, MaxString: 3, MaxBlob: 2, MaxItems: 1}
+	cases := map[string]Tdf{
+		`NAME: "She"... (7 chars)`:  NewString("NAME", "Shepard"),
+		`BLOB: <0102...> (4 bytes)`: NewBlob("BLOB", []byte{1, 2, 3, 4}),
+		`LIST: [ "a", ... 2 more ]`: NewList("LIST", []string{"a", "b", "c"}),
+		`ADDR: union(0) VALU {}`:    NewUnion("ADDR", 0, NewStruct("VALU")),
+		`ADDR: union(empty)`:        NewUnion("ADDR", EmptyType, nil),
+	}
+	for expected, value := range cases {
+		if out := FormatWith(value, options); out != expected {
+			t.Errorf("expected %s got %s", expected, out)
+		}
+	}
+}
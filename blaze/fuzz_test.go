@@ -0,0 +1,89 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func FuzzReadPacket(f *testing.F) {
+	for _, wire := range wirePackets {
+		data, _ := hex.DecodeString(wire.hex)
+		f.Add(data)
+	}
+	f.Add([]byte{0, 2, 0, 0, 0, 0, 0, 0, 0, 0x10, 0, 0, 0x80, 0})
+	// Headers cut short before and within the extended length
+	f.Add([]byte{0})
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0x10, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		buff := PacketBuff{Buffer: bytes.NewBuffer(append([]byte(nil), data...))}
+		packet := buff.ReadPacket()
+		decoded, n, err := DecodePacket(data)
+		if err != nil {
+			// Both readers reject the same data and nothing is consumed
+			if packet != nil {
+				t.Fatalf("DecodePacket failed with %v but ReadPacket read %+v", err, packet)
+			}
+			if buff.Len() != len(data) {
+				t.Fatalf("ReadPacket failed but consumed %d bytes", len(data)-buff.Len())
+			}
+			return
+		}
+		if packet == nil {
+			t.Fatalf("DecodePacket read %d bytes but ReadPacket failed", n)
+		}
+		if buff.Len() != len(data)-n {
+			t.Fatalf("DecodePacket read %d bytes but ReadPacket read %d", n, len(data)-buff.Len())
+		}
+		if !bytes.Equal(buff.EncodePacketRaw(*packet), data[:n]) {
+			t.Errorf("ReadPacket encoded differently")
+		}
+		if !bytes.Equal(AppendPacket(nil, decoded), data[:n]) {
+			t.Errorf("DecodePacket encoded differently")
+		}
+		_ = decoded.ReadContent()
+	})
+}
+
+func FuzzReadTdf(f *testing.F) {
+	f.Add(encodeContent(roundTripContent()...))
+	f.Add(encodeContent(benchContent()...))
+	for _, wire := range wirePackets {
+		f.Add(encodeContent(wire.content...))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		values := readContentBuff(data)
+		encoded := encodeContent(values...)
+		if decoded := encodeContent(DecodeContent(data)...); !bytes.Equal(decoded, encoded) {
+			t.Fatalf("Decoder and PacketBuff read different values\n%x\n%x", encoded, decoded)
+		}
+		// Anything that has been read once reads back the same way
+		if again := encodeContent(DecodeContent(encoded)...); !bytes.Equal(again, encoded) {
+			t.Fatalf("values changed after being written\n%x\n%x", encoded, again)
+		}
+		r := NewLazyReader(data)
+		for r.Next() {
+		}
+		_ = FormatContent(values, DefaultFormatOptions)
+		_, _ = ContentToJSON(values)
+	})
+}
+
+func FuzzReadVarInt(f *testing.F) {
+	f.Add([]byte{0x3F}, int64(0))
+	f.Add([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}, int64(-1))
+	f.Add([]byte{0xC0, 0x80, 0x80}, int64(1<<40))
+	f.Fuzz(func(t *testing.T, data []byte, value int64) {
+		buff := PacketBuff{Buffer: bytes.NewBuffer(append([]byte(nil), data...))}
+		d := NewDecoder(data)
+		if a, b := buff.ReadVarInt(), d.ReadVarInt(); a != b || buff.Len() != d.Len() {
+			t.Fatalf("PacketBuff read %d leaving %d bytes, Decoder read %d leaving %d", a, buff.Len(), b, d.Len())
+		}
+
+		out := PacketBuff{Buffer: &bytes.Buffer{}}
+		out.WriteVarInt(value)
+		if read := out.ReadVarInt(); read != value || out.Len() != 0 {
+			t.Fatalf("%d was read back as %d", value, read)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+//go:build ignore
+
+// gen_tags generates tags.go from the labels listed in labels.txt. Each
+// label is checked with ParseLabel so that a bad label stops generation
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+func main() {
+	file, err := os.Open("labels.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	seen := map[blaze.Label]bool{}
+	var labels []blaze.Label
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		label, err := blaze.ParseLabel(text)
+		if err != nil {
+			log.Fatalf("labels.txt:%d: %v", line, err)
+		}
+		if seen[label] {
+			log.Fatalf("labels.txt:%d: %s is listed twice", line, label)
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i] < labels[j] })
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen_tags.go from labels.txt. DO NOT EDIT.\n\n")
+	out.WriteString("package blaze\n\n")
+	out.WriteString("// Tags of the labels in labels.txt for use in switches and other places\n")
+	out.WriteString("// that need a constant\n")
+	out.WriteString("const (\n")
+	for _, label := range labels {
+		fmt.Fprintf(&out, "\tTag%s uint32 = 0x%08X\n", label, label.Tag())
+	}
+	out.WriteString(")\n\n")
+	out.WriteString("// knownTags maps each label in labels.txt to its tag constant\n")
+	out.WriteString("var knownTags = map[Label]uint32{\n")
+	for _, label := range labels {
+		fmt.Fprintf(&out, "\t%q: Tag%s,\n", label, label)
+	}
+	out.WriteString("}\n")
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("tags.go", source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,516 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+// jsonTdf is the json form of a single value. Everything needed to write the
+// exact same bytes is kept: the label, the type, list subtypes, the union
+// type, the generic type id and whether a struct starts with the 2 byte
+type jsonTdf struct {
+	Label     string          `json:"label,omitempty"`
+	Type      string          `json:"type"`
+	SubType   string          `json:"subtype,omitempty"`
+	KeyType   string          `json:"keyType,omitempty"`
+	ValueType string          `json:"valueType,omitempty"`
+	UnionType *int            `json:"unionType,omitempty"`
+	GenericId *int64          `json:"genericId,omitempty"`
+	Start2    bool            `json:"start2,omitempty"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// TdfToJSON converts a value to its json form
+func TdfToJSON(value Tdf) ([]byte, error) {
+	out, err := tdfToJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// TdfFromJSON converts the json form of a value back into the value
+func TdfFromJSON(data []byte) (Tdf, error) {
+	var node jsonTdf
+	if err := unmarshalJSON(data, &node); err != nil {
+		return nil, err
+	}
+	return tdfFromJSON(node)
+}
+
+// ContentToJSON converts a list of values such as the content of a packet
+// into a json array
+func ContentToJSON(values []Tdf) ([]byte, error) {
+	out, err := valuesToJSON(values)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// ContentFromJSON converts a json array created by ContentToJSON back into a
+// list of values
+func ContentFromJSON(data []byte) ([]Tdf, error) {
+	var nodes []jsonTdf
+	if err := unmarshalJSON(data, &nodes); err != nil {
+		return nil, err
+	}
+	return valuesFromJSON(nodes)
+}
+
+// unmarshalJSON decodes numbers as json.Number so that large ints are not
+// rounded through float64
+func unmarshalJSON(data []byte, value any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+// ParseTdfType parses the name of a type as returned by TdfType.String
+func ParseTdfType(name string) (TdfType, error) {
+	for t, typeName := range typeNames {
+		if typeName == name {
+			return t, nil
+		}
+	}
+	if strings.HasPrefix(name, "type(") && strings.HasSuffix(name, ")") {
+		value, err := strconv.ParseUint(name[5:len(name)-1], 10, 8)
+		if err == nil {
+			return TdfType(value), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown tdf type %q", name)
+}
+
+func marshalRaw(value any) json.RawMessage {
+	data, _ := json.Marshal(value)
+	return data
+}
+
+func valuesToJSON(values []Tdf) ([]jsonTdf, error) {
+	out := make([]jsonTdf, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			return nil, errors.New("struct value is nil")
+		}
+		node, err := tdfToJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, node)
+	}
+	return out, nil
+}
+
+func valuesFromJSON(nodes []jsonTdf) ([]Tdf, error) {
+	out := make([]Tdf, 0, len(nodes))
+	for _, node := range nodes {
+		value, err := tdfFromJSON(node)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+func tdfToJSON(value Tdf) (jsonTdf, error) {
+	head := value.GetHead()
+	node := jsonTdf{
+		Label: strings.TrimRight(head.Label, " \x00"),
+		Type:  head.Type.String(),
+	}
+	switch v := value.(type) {
+	case StructTdf:
+		node.Type = StructType.String()
+		children, err := valuesToJSON(v.Values)
+		if err != nil {
+			return node, err
+		}
+		node.Start2 = v.Start2
+		node.Value = marshalRaw(children)
+	case ListValue:
+		node.Type = ListType.String()
+		node.SubType = TdfType(v.ItemType()).String()
+		items, err := listToJSON(TdfType(v.ItemType()), v.Len(), v.Item)
+		if err != nil {
+			return node, err
+		}
+		node.Value = marshalRaw(items)
+	case MapValue:
+		node.Type = PairListType.String()
+		node.KeyType = TdfType(v.KeyType()).String()
+		node.ValueType = TdfType(v.ValueType()).String()
+		pairs := make([][2]json.RawMessage, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			key, err := itemToJSON(TdfType(v.KeyType()), v.Key(i))
+			if err != nil {
+				return node, err
+			}
+			item, err := itemToJSON(TdfType(v.ValueType()), v.Value(i))
+			if err != nil {
+				return node, err
+			}
+			pairs = append(pairs, [2]json.RawMessage{key, item})
+		}
+		node.Value = marshalRaw(pairs)
+	case UnionTdf:
+		node.Type = UnionType.String()
+		unionType := int(v.Type)
+		node.UnionType = &unionType
+		if v.Type == EmptyType || v.Content == nil {
+			node.Value = json.RawMessage("null")
+			break
+		}
+		content, err := tdfToJSON(v.Content)
+		if err != nil {
+			return node, err
+		}
+		node.Value = marshalRaw(content)
+	case GenericTdf:
+		node.Type = GenericType.String()
+		if v.Value == nil {
+			node.Value = json.RawMessage("null")
+			break
+		}
+		id := v.Id
+		node.GenericId = &id
+		content, err := tdfToJSON(v.Value)
+		if err != nil {
+			return node, err
+		}
+		node.Value = marshalRaw(content)
+	case VarIntListTdf:
+		node.Type = VarIntListType.String()
+		items, err := listToJSON(IntType, len(v.Values), func(i int) any { return v.Values[i] })
+		if err != nil {
+			return node, err
+		}
+		node.Value = marshalRaw(items)
+	default:
+		item, err := itemToJSON(head.Type, value)
+		if err != nil {
+			return node, err
+		}
+		node.Value = item
+	}
+	return node, nil
+}
+
+// listToJSON converts count items where item returns the item at an index
+func listToJSON(subType TdfType, count int, item func(i int) any) ([]json.RawMessage, error) {
+	out := make([]json.RawMessage, 0, count)
+	for i := 0; i < count; i++ {
+		value, err := itemToJSON(subType, item(i))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+// itemToJSON converts a value without a label such as a list item or the
+// value of a labelled tdf
+func itemToJSON(t TdfType, value any) (json.RawMessage, error) {
+	switch v := value.(type) {
+	case Int64Tdf:
+		return marshalRaw(v.Value), nil
+	case int64:
+		return marshalRaw(v), nil
+	case StringTdf:
+		return marshalRaw(v.Value), nil
+	case string:
+		return marshalRaw(v), nil
+	case BlobTdf:
+		return marshalRaw(hex.EncodeToString(v.Data)), nil
+	case []byte:
+		return marshalRaw(hex.EncodeToString(v)), nil
+	case FloatTdf:
+		return marshalRaw(v.Value), nil
+	case float64:
+		return marshalRaw(v), nil
+	case PairTdf:
+		return marshalRaw([]int64{v.A, v.B}), nil
+	case Pair:
+		return marshalRaw([]int64{v.A, v.B}), nil
+	case TripleTdf:
+		return marshalRaw([]int64{v.A, v.B, v.C}), nil
+	case Triple:
+		return marshalRaw([]int64{v.A, v.B, v.C}), nil
+	case StructTdf:
+		children, err := valuesToJSON(v.Values)
+		if err != nil {
+			return nil, err
+		}
+		return marshalRaw(jsonTdf{Type: StructType.String(), Start2: v.Start2, Value: marshalRaw(children)}), nil
+	case NestedList:
+		return nestedToJSON(v.ListValue)
+	case NestedMap:
+		return nestedToJSON(v.MapValue)
+	case UnionTdf:
+		return nestedToJSON(v)
+	case VarIntListTdf:
+		return nestedToJSON(v)
+	default:
+		return nil, fmt.Errorf("can't convert %T to json as %s", value, t)
+	}
+}
+
+// nestedToJSON converts a list, map or union within a list to the same
+// form as a labelled value but without the label
+func nestedToJSON(value Tdf) (json.RawMessage, error) {
+	node, err := tdfToJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	return marshalRaw(node), nil
+}
+
+func tdfFromJSON(node jsonTdf) (Tdf, error) {
+	if node.Label == "" {
+		return nil, fmt.Errorf("%s value is missing a label", node.Type)
+	}
+	return valueFromJSON(node)
+}
+
+// valueFromJSON converts a node which may be missing its label when it is
+// an item within a list
+func valueFromJSON(node jsonTdf) (Tdf, error) {
+	t, err := ParseTdfType(node.Type)
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case StructType:
+		var children []jsonTdf
+		if err := unmarshalJSON(node.Value, &children); err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		values, err := valuesFromJSON(children)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%w", node.Label, err)
+		}
+		out := NewStruct(node.Label, values...)
+		out.Start2 = node.Start2
+		return out, nil
+	case ListType:
+		subType, err := ParseTdfType(node.SubType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		items, err := listFromJSON(subType, node.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		out, err := NewListOf(node.Label, SubType(subType), items)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		return out, nil
+	case PairListType:
+		keyType, err := ParseTdfType(node.KeyType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		valueType, err := ParseTdfType(node.ValueType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		var pairs [][2]json.RawMessage
+		if err := unmarshalJSON(node.Value, &pairs); err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		keys := make([]any, 0, len(pairs))
+		values := make([]any, 0, len(pairs))
+		for _, pair := range pairs {
+			key, err := itemFromJSON(keyType, pair[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", node.Label, err)
+			}
+			value, err := itemFromJSON(valueType, pair[1])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", node.Label, err)
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		out, err := NewMapOf(node.Label, SubType(keyType), SubType(valueType), keys, values)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		return out, nil
+	case UnionType:
+		unionType := EmptyType
+		if node.UnionType != nil {
+			unionType = TdfType(*node.UnionType)
+		}
+		if unionType == EmptyType {
+			return NewUnion(node.Label, unionType, nil), nil
+		}
+		if len(node.Value) == 0 || string(node.Value) == "null" {
+			return nil, fmt.Errorf("%s: union of type %d is missing its value", node.Label, unionType)
+		}
+		var content jsonTdf
+		if err := unmarshalJSON(node.Value, &content); err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		value, err := tdfFromJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%w", node.Label, err)
+		}
+		return NewUnion(node.Label, unionType, value), nil
+	case GenericType:
+		if node.GenericId == nil {
+			return NewGeneric(node.Label, 0, nil), nil
+		}
+		var content jsonTdf
+		if err := unmarshalJSON(node.Value, &content); err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		value, err := tdfFromJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%w", node.Label, err)
+		}
+		return NewGeneric(node.Label, *node.GenericId, value), nil
+	case VarIntListType:
+		items, err := listFromJSON(IntType, node.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		values := make([]int64, len(items))
+		for i, item := range items {
+			values[i] = item.(int64)
+		}
+		return NewVarIntList(node.Label, values), nil
+	default:
+		item, err := itemFromJSON(t, node.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Label, err)
+		}
+		return labelItem(node.Label, t, item)
+	}
+}
+
+func listFromJSON(subType TdfType, data json.RawMessage) ([]any, error) {
+	var items []json.RawMessage
+	if err := unmarshalJSON(data, &items); err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(items))
+	for _, raw := range items {
+		item, err := itemFromJSON(subType, raw)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// itemFromJSON converts an unlabelled value into the type used for it in
+// lists and maps
+func itemFromJSON(t TdfType, data json.RawMessage) (any, error) {
+	switch t {
+	case IntType:
+		var value json.Number
+		if err := unmarshalJSON(data, &value); err != nil {
+			return nil, err
+		}
+		return value.Int64()
+	case StringType:
+		var value string
+		err := unmarshalJSON(data, &value)
+		return value, err
+	case BlobType:
+		var value string
+		if err := unmarshalJSON(data, &value); err != nil {
+			return nil, err
+		}
+		return hex.DecodeString(value)
+	case FloatType:
+		var value json.Number
+		if err := unmarshalJSON(data, &value); err != nil {
+			return nil, err
+		}
+		return value.Float64()
+	case PairType, TripleType:
+		var values []int64
+		if err := unmarshalJSON(data, &values); err != nil {
+			return nil, err
+		}
+		if t == PairType {
+			if len(values) != 2 {
+				return nil, errors.New("pair needs 2 values")
+			}
+			return Pair{A: values[0], B: values[1]}, nil
+		}
+		if len(values) != 3 {
+			return nil, errors.New("triple needs 3 values")
+		}
+		return Triple{A: values[0], B: values[1], C: values[2]}, nil
+	case StructType:
+		var node jsonTdf
+		if err := unmarshalJSON(data, &node); err != nil {
+			return nil, err
+		}
+		var children []jsonTdf
+		if err := unmarshalJSON(node.Value, &children); err != nil {
+			return nil, err
+		}
+		values, err := valuesFromJSON(children)
+		if err != nil {
+			return nil, err
+		}
+		return NewStructStub(values, node.Start2), nil
+	case ListType, PairListType, UnionType, VarIntListType:
+		var node jsonTdf
+		if err := unmarshalJSON(data, &node); err != nil {
+			return nil, err
+		}
+		if nodeType, err := ParseTdfType(node.Type); err != nil || nodeType != t {
+			return nil, fmt.Errorf("expected %s but found %q", t, node.Type)
+		}
+		value, err := valueFromJSON(node)
+		if err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case ListValue:
+			return NestedList{v}, nil
+		case MapValue:
+			return NestedMap{v}, nil
+		default:
+			return v, nil
+		}
+	default:
+		return nil, fmt.Errorf("can't convert json to %s", t)
+	}
+}
+
+// labelItem wraps an item in the tdf for its type
+func labelItem(label string, t TdfType, item any) (Tdf, error) {
+	switch value := item.(type) {
+	case int64:
+		return NewInt64(label, value), nil
+	case string:
+		return NewString(label, value), nil
+	case []byte:
+		return NewBlob(label, value), nil
+	case float64:
+		return NewFloat(label, value), nil
+	case Pair:
+		return NewPair(label, value), nil
+	case Triple:
+		return NewTriple(label, value), nil
+	default:
+		return nil, fmt.Errorf("can't create %s tdf", t)
+	}
+}
@@ -0,0 +1,103 @@
+package blaze
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+func encodeContent(values ...Tdf) []byte {
+	buf := &PacketBuff{Buffer: &bytes.Buffer{}}
+	for _, value := range values {
+		WriteTdf(buf, value)
+	}
+	return buf.Bytes()
+}
+
+func decodeContent(data []byte) []Tdf {
+	packet := Packet{Content: data}
+	return packet.ReadContent()
+}
+
+// roundTripContent has a value of every type that can be read
+func roundTripContent() []Tdf {
+	return []Tdf{
+		NewInt64("PID", -12345678901),
+		NewString("NAME", "Shepard"),
+		NewBlob("BLOB", []byte{0, 1, 0xFF}),
+		NewStruct2("PDTL", NewString("DSNM", "Shepard"), NewInt64("XUID", 1)),
+		NewList("IDS", []int64{1, 2}),
+		NewList("NAMS", []string{"a"}),
+		NewList("BLBS", [][]byte{{1, 2}, {}}),
+		NewList("STRS", []StructTdf{NewStructStub([]Tdf{NewInt64("A", 1)}, false)}),
+		NewList("TRPS", []Triple{{A: 1, B: 2, C: 3}}),
+		NewList("FLTS", []float64{0.5}),
+		NewMap("MAP", []string{"x", "y"}, []int64{1, 2}),
+		NewMap("FMAP", []int64{1}, []float64{0.5}),
+		NewUnion("ADDR", 0, NewStruct("VALU", NewString("HOST", "127.0.0.1"), NewInt64("PORT", 14219))),
+		NewUnion("EMPT", EmptyType, nil),
+		NewVarIntList("VARS", []int64{4, 5, -6}),
+		NewPair("PAIR", Pair{A: 1, B: 2}),
+		NewTriple("BOID", Triple{A: 0x19, B: 1, C: 100}),
+		NewFloat("FLT", 1.25),
+		NewList("GRID", []NestedList{{NewList("", []int64{1, 2})}, {NewList("", []string{})}}),
+		NewList("MAPS", []NestedMap{{NewMap("", []int64{1}, []string{"a"})}}),
+		NewList("UNIS", []UnionTdf{NewUnion("", 1, NewInt64("VALU", 1)), NewUnion("", EmptyType, nil)}),
+		NewList("VLST", []VarIntListTdf{NewVarIntList("", []int64{1, 2})}),
+		NewList("OBJS", []Pair{{A: 0x19, B: 1}}),
+		NewMap("PMAP", []Pair{{A: 1, B: 2}}, []UnionTdf{NewUnion("", 2, NewBlob("DATA", []byte{1}))}),
+		NewUnion("BLBU", 2, NewBlob("DATA", []byte{0xDE, 0xAD})),
+		NewGeneric("GNRC", 5, NewStruct("VALU", NewInt64("A", 1))),
+		NewGeneric("GEMP", 0, nil),
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	wire := encodeContent(roundTripContent()...)
+
+	data, err := ContentToJSON(decodeContent(wire))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := ContentFromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := encodeContent(values...); !bytes.Equal(out, wire) {
+		t.Errorf("round trip changed the bytes\n%x\n%x\n%s", wire, out, data)
+	}
+
+	// Converting the values created from json gives the same json
+	again, err := ContentToJSON(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Errorf("json changed\n%s\n%s", data, again)
+	}
+}
+
+func TestTdfFromJSON(t *testing.T) {
+	value, err := TdfFromJSON([]byte(`{"label":"PDTL","type":"struct","value":[{"label":"NAME","type":"string","value":"Shepard"},{"label":"PID","type":"int","value":9007199254740993}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := NewStruct("PDTL", NewString("NAME", "Shepard"), NewInt64("PID", 9007199254740993))
+	if !bytes.Equal(encodeContent(value), encodeContent(expected)) {
+		t.Errorf("unexpected value %s", Format(value))
+	}
+
+	invalid := []string{
+		`{"label":"A","type":"nope","value":1}`,
+		`{"type":"int","value":1}`,
+		`{"label":"A","type":"int","value":"x"}`,
+		`{"label":"A","type":"union","unionType":0,"value":null}`,
+		`{"label":"A","type":"list","subtype":"int","value":["x"]}`,
+	}
+	for _, data := range invalid {
+		if _, err := TdfFromJSON([]byte(data)); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+//go:generate go run gen_tags.go
+
+// Label is a label that has been checked to convert to a tag and back
+// without changing. Labels are 1 to 4 upper case letters or digits
+type Label string
+
+// ErrInvalidLabel is returned by ParseLabel for labels that can't be
+// stored in a tag
+var ErrInvalidLabel = errors.New("blaze: invalid label")
+
+// ParseLabel checks that the label can be stored in a tag. LabelToTag
+// accepts anything but truncates long labels and mangles characters
+// outside of upper case letters and digits
+func ParseLabel(label string) (Label, error) {
+	if len(label) == 0 || len(label) > 4 {
+		return "", fmt.Errorf("%w: %q must be 1 to 4 characters", ErrInvalidLabel, label)
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return "", fmt.Errorf("%w: %q has %q which isn't an upper case letter or digit", ErrInvalidLabel, label, c)
+		}
+	}
+	return Label(label), nil
+}
+
+// MustLabel is ParseLabel for labels that are known to be valid. It panics
+// when the label is invalid
+func MustLabel(label string) Label {
+	out, err := ParseLabel(label)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// Tag is the tag the label is written as
+func (l Label) Tag() uint32 {
+	return LabelToTag(string(l))
+}
+
+func (l Label) String() string {
+	return string(l)
+}
+
+// LintLabels makes NewTdf log the labels that don't round trip through a
+// tag. Each bad label is only logged once
+var LintLabels = false
+
+var linted sync.Map
+
+// lintLabel logs the label when label linting is on and the label is bad
+func lintLabel(label string) {
+	// List items and struct stubs don't have labels
+	if !LintLabels || label == "" {
+		return
+	}
+	if _, err := ParseLabel(label); err != nil {
+		if _, seen := linted.LoadOrStore(label, true); !seen {
+			log.Printf("Label lint: %v", err)
+		}
+	}
+}
+
+// LabelError is a bad label found by LintValues along with the path to
+// the value it was found on
+type LabelError struct {
+	Path string
+	Err  error
+}
+
+func (e *LabelError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *LabelError) Unwrap() error {
+	return e.Err
+}
+
+// LintValues finds every label within the values that doesn't round trip
+// through a tag. Each problem includes the path to the value
+func LintValues(values []Tdf) []error {
+	var out []error
+	lintValues(&out, "", values)
+	return out
+}
+
+func lintValues(out *[]error, prefix string, values []Tdf) {
+	for _, value := range values {
+		if value != nil {
+			lintTdf(out, prefix, value)
+		}
+	}
+}
+
+func lintTdf(out *[]error, prefix string, value Tdf) {
+	head := value.GetHead()
+	label := strings.TrimRight(head.Label, " ")
+	path := prefix + label
+	if _, err := ParseLabel(label); err != nil {
+		*out = append(*out, &LabelError{Path: path, Err: err})
+	} else if head.Tag != LabelToTag(label) {
+		*out = append(*out, &LabelError{Path: path, Err: fmt.Errorf("%w: tag %x doesn't match %q", ErrInvalidLabel, head.Tag, label)})
+	}
+	lintNested(out, path, value)
+}
+
+// lintNested checks the values within a value including list items
+func lintNested(out *[]error, path string, value any) {
+	switch v := value.(type) {
+	case StructTdf:
+		lintValues(out, path+".", v.Values)
+	case UnionTdf:
+		if v.Content != nil {
+			lintTdf(out, path+".", v.Content)
+		}
+	case GenericTdf:
+		if v.Value != nil {
+			lintTdf(out, path+".", v.Value)
+		}
+	case ListValue:
+		for i := 0; i < v.Len(); i++ {
+			lintNested(out, fmt.Sprintf("%s[%d]", path, i), v.Item(i))
+		}
+	case MapValue:
+		for i := 0; i < v.Len(); i++ {
+			lintNested(out, fmt.Sprintf("%s[%d]", path, i), v.Key(i))
+			lintNested(out, fmt.Sprintf("%s[%d]", path, i), v.Value(i))
+		}
+	}
+}
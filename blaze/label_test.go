@@ -0,0 +1,75 @@
+package blaze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseLabel(t *testing.T) {
+	for _, label := range []string{"A", "PID", "DSNM", "TLM3", "0000"} {
+		if out, err := ParseLabel(label); err != nil || string(out) != label {
+			t.Errorf("%q: %v", label, err)
+		}
+	}
+	for _, label := range []string{"", "DSNMX", "dsnm", "PI D", "A_B", "É"} {
+		if _, err := ParseLabel(label); !errors.Is(err, ErrInvalidLabel) {
+			t.Errorf("%q: expected invalid label got %v", label, err)
+		}
+	}
+}
+
+func TestMustLabel(t *testing.T) {
+	if tag := MustLabel("CFID").Tag(); tag != 0x8E6A6400 {
+		t.Errorf("CFID tag = %x", tag)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a lower case label")
+		}
+	}()
+	MustLabel("cfid")
+}
+
+func TestKnownTags(t *testing.T) {
+	if len(knownTags) == 0 {
+		t.Fatal("no tags were generated")
+	}
+	for label, tag := range knownTags {
+		if LabelToTag(string(label)) != tag {
+			t.Errorf("Tag%s is %x but the label gives %x", label, tag, LabelToTag(string(label)))
+		}
+		if out := strings.TrimRight(TagToLabel(tag), " "); out != string(label) {
+			t.Errorf("Tag%s converts back to %q", label, out)
+		}
+	}
+	if TagCFID != LabelToTag("CFID") {
+		t.Error("TagCFID doesn't match CFID")
+	}
+}
+
+func TestLintValues(t *testing.T) {
+	values := []Tdf{
+		NewString("NAME", "Shepard"),
+		NewStruct("PDTL", NewInt64("pid", 1), NewInt64("XUID", 2)),
+		NewList("USRS", []StructTdf{NewStructStub([]Tdf{NewString("DSNMX", "")}, false)}),
+		NewUnion("ADDR", 0, NewStruct("va_u")),
+	}
+	errs := LintValues(values)
+	var paths []string
+	for _, err := range errs {
+		var labelErr *LabelError
+		if !errors.As(err, &labelErr) || !errors.Is(err, ErrInvalidLabel) {
+			t.Errorf("unexpected error %v", err)
+			continue
+		}
+		paths = append(paths, labelErr.Path)
+	}
+	expected := "PDTL.pid USRS[0].DSNMX ADDR.va_u"
+	if got := strings.Join(paths, " "); got != expected {
+		t.Errorf("found %q expected %q", got, expected)
+	}
+	if errs := LintValues(decodeContent(encodeContent(roundTripContent()...))); len(errs) > 0 {
+		t.Errorf("decoded values have bad labels %v", errs)
+	}
+}
@@ -0,0 +1,294 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// LazyReader iterates over the values of a packet or struct reading only
+// the head of each value. Values are only decoded when Value is called and
+// are skipped over without being decoded otherwise
+//
+//	r := NewLazyReader(packet.Content)
+//	for r.Next() {
+//		if r.Head().Label == "CFID" {
+//			value := r.Value()
+//		}
+//	}
+//	if err := r.Err(); err != nil {
+//		...
+//	}
+type LazyReader struct {
+	d Decoder
+	// inStruct is set when reading the values of a struct which end at a
+	// zero byte rather than at the end of the data
+	inStruct bool
+	head     TdfImpl
+	// pending is set while the value of head hasn't been read or skipped
+	pending bool
+	err     error
+}
+
+var ErrUnknownType = errors.New("blaze: unknown tdf type")
+
+// NewLazyReader creates a reader over encoded values such as the content of
+// a packet
+func NewLazyReader(data []byte) *LazyReader {
+	return &LazyReader{d: Decoder{data: data}}
+}
+
+// Next moves to the next value skipping the current value if it wasn't
+// read. It returns false at the end of the values or when an error occurs
+func (r *LazyReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	if r.pending {
+		if r.err = skipValue(&r.d, r.head.Type); r.err != nil {
+			return false
+		}
+		r.pending = false
+	}
+	if r.d.Len() == 0 {
+		if r.inStruct {
+			r.err = io.ErrUnexpectedEOF
+		}
+		return false
+	}
+	if r.inStruct {
+		if b, _ := r.d.ReadByte(); b == 0 {
+			return false
+		}
+		_ = r.d.UnreadByte()
+	}
+	head, ok := readHead(&r.d)
+	if !ok {
+		r.err = io.ErrUnexpectedEOF
+		return false
+	}
+	r.head = head
+	r.pending = true
+	return true
+}
+
+// Head is the head of the current value
+func (r *LazyReader) Head() TdfImpl {
+	return r.head
+}
+
+// Err is the error that stopped Next if there was one
+func (r *LazyReader) Err() error {
+	return r.err
+}
+
+// Value decodes the current value. Values can only be decoded once and nil
+// is returned if it has already been read or skipped
+func (r *LazyReader) Value() Tdf {
+	if !r.pending || r.err != nil {
+		return nil
+	}
+	r.pending = false
+	return readValue(&r.d, r.head)
+}
+
+// Skip skips the current value without decoding it
+func (r *LazyReader) Skip() error {
+	if !r.pending || r.err != nil {
+		return r.err
+	}
+	r.pending = false
+	r.err = skipValue(&r.d, r.head.Type)
+	return r.err
+}
+
+// Struct returns a reader over the values of the current value which must
+// be a struct. The values of the struct are skipped by this reader so the
+// returned reader can be used after Next has been called again
+func (r *LazyReader) Struct() (*LazyReader, error) {
+	if !r.pending || r.err != nil {
+		return nil, r.err
+	}
+	if r.head.Type != StructType {
+		return nil, fmt.Errorf("%w: %s is %s not struct", ErrWrongType, r.head.Label, r.head.Type)
+	}
+	start := r.d.off
+	if err := r.Skip(); err != nil {
+		return nil, err
+	}
+	inner := &LazyReader{d: Decoder{data: r.d.data[start:r.d.off]}, inStruct: true}
+	if b, _ := inner.d.ReadByte(); b != 2 {
+		_ = inner.d.UnreadByte()
+	}
+	return inner, nil
+}
+
+// Find decodes only the values with the provided labels skipping the rest
+func (r *LazyReader) Find(labels ...string) (Values, error) {
+	tags := make([]uint32, len(labels))
+	for i, label := range labels {
+		tags[i] = LabelToTag(label)
+	}
+	var out Values
+	for r.Next() {
+		for _, tag := range tags {
+			if r.head.Tag == tag {
+				if value := r.Value(); value != nil {
+					out = append(out, value)
+				}
+				break
+			}
+		}
+	}
+	return out, r.Err()
+}
+
+// ReadLabels decodes only the values in the content with the provided
+// labels. Values that can't be read are left out
+func (p *Packet) ReadLabels(labels ...string) Values {
+	out, _ := NewLazyReader(p.Content).Find(labels...)
+	return out
+}
+
+// skip moves past n bytes
+func skip(d *Decoder, n int64) error {
+	if n < 0 || n > int64(d.Len()) {
+		d.off = len(d.data)
+		return io.ErrUnexpectedEOF
+	}
+	d.off += int(n)
+	return nil
+}
+
+// skipVarInts moves past count var ints
+func skipVarInts(d *Decoder, count int64) error {
+	for ; count > 0; count-- {
+		for {
+			b, err := d.ReadByte()
+			if err != nil {
+				return io.ErrUnexpectedEOF
+			}
+			if b&0x80 == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// skipValue moves past a value of the provided type without decoding it.
+// List items are skipped with the same function as their subtypes use the
+// same numbers as the types
+func skipValue(d *Decoder, t TdfType) error {
+	switch t {
+	case IntType:
+		return skipVarInts(d, 1)
+	case StringType, BlobType:
+		// The length is checked for as reading it at the end of the data
+		// gives zero which would let huge lists of strings never end
+		if d.Len() == 0 {
+			return io.ErrUnexpectedEOF
+		}
+		return skip(d, d.ReadVarInt())
+	case StructType:
+		return skipStruct(d)
+	case ListType:
+		subType, err := d.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		return skipItems(d, d.ReadVarInt(), TdfType(subType))
+	case PairListType:
+		keyType, _ := d.ReadByte()
+		valueType, err := d.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		count := d.ReadVarInt()
+		for i := int64(0); i < count; i++ {
+			if err := skipValue(d, TdfType(keyType)); err != nil {
+				return err
+			}
+			if err := skipValue(d, TdfType(valueType)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case UnionType:
+		unionType, err := d.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		if TdfType(unionType) == EmptyType {
+			return nil
+		}
+		head, ok := readHead(d)
+		if !ok {
+			return io.ErrUnexpectedEOF
+		}
+		return skipValue(d, head.Type)
+	case VarIntListType:
+		return skipVarInts(d, d.ReadVarInt())
+	case PairType:
+		return skipVarInts(d, 2)
+	case TripleType:
+		return skipVarInts(d, 3)
+	case FloatType:
+		return skip(d, 8)
+	case GenericType:
+		present, err := d.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		if present == 0 {
+			return nil
+		}
+		if err := skipVarInts(d, 1); err != nil {
+			return err
+		}
+		head, ok := readHead(d)
+		if !ok {
+			return io.ErrUnexpectedEOF
+		}
+		if err := skipValue(d, head.Type); err != nil {
+			return err
+		}
+		return skip(d, 1)
+	default:
+		return fmt.Errorf("%w %d", ErrUnknownType, t)
+	}
+}
+
+func skipItems(d *Decoder, count int64, t TdfType) error {
+	for i := int64(0); i < count; i++ {
+		if err := skipValue(d, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipStruct moves past the values of a struct and the zero byte ending it
+func skipStruct(d *Decoder) error {
+	first := true
+	for {
+		b, err := d.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		if b == 0 {
+			return nil
+		}
+		if !(first && b == 2) {
+			_ = d.UnreadByte()
+			head, ok := readHead(d)
+			if !ok {
+				return io.ErrUnexpectedEOF
+			}
+			if err := skipValue(d, head.Type); err != nil {
+				return err
+			}
+		}
+		first = false
+	}
+}
@@ -0,0 +1,97 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestLazyReaderSkipsEveryType(t *testing.T) {
+	content := roundTripContent()
+	wire := encodeContent(content...)
+	r := NewLazyReader(wire)
+	var tags []uint32
+	for r.Next() {
+		tags = append(tags, r.Head().Tag)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != len(content) {
+		t.Fatalf("skipped to %d values expected %d", len(tags), len(content))
+	}
+	for i, value := range content {
+		if tags[i] != value.GetHead().Tag {
+			t.Errorf("value %d is %s expected %s", i, TagToLabel(tags[i]), value.GetHead().Label)
+		}
+	}
+}
+
+func TestLazyReaderFind(t *testing.T) {
+	content := lookupTestValues()
+	wire := encodeContent(content...)
+	found, err := NewLazyReader(wire).Find("DSNM", "IDS", "BOID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected []Tdf
+	for _, label := range []string{"DSNM", "IDS", "BOID"} {
+		value, _ := content.Get(label)
+		expected = append(expected, value)
+	}
+	if !bytes.Equal(encodeContent(found...), encodeContent(expected...)) {
+		t.Errorf("unexpected values\n%s", FormatContent(found, DefaultFormatOptions))
+	}
+	packet := Packet{Content: wire}
+	if value := packet.ReadLabels("PID").IntOr("PID", 0); value != 12345 {
+		t.Errorf("PID = %d", value)
+	}
+}
+
+func TestLazyReaderStruct(t *testing.T) {
+	wire := encodeContent(
+		NewStruct2("PDTL", NewString("NAME", "Shepard"), NewStruct("NQOS", NewInt64("NATT", 4))),
+		NewInt64("PID", 1),
+	)
+	r := NewLazyReader(wire)
+	if !r.Next() {
+		t.Fatal(r.Err())
+	}
+	inner, err := r.Struct()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The outer reader can carry on before the inner reader is used
+	if !r.Next() || r.Head().Tag != LabelToTag("PID") {
+		t.Fatalf("expected PID after the struct got %s", r.Head().Label)
+	}
+	found, err := inner.Find("NQOS")
+	if err != nil || len(found) != 1 || found.IntOr("NQOS.NATT", 0) != 4 {
+		t.Errorf("NQOS = %v, %v", found, err)
+	}
+	if _, err := r.Struct(); !errors.Is(err, ErrWrongType) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestLazyReaderTruncated(t *testing.T) {
+	wire := encodeContent(NewStruct("PDTL", NewString("NAME", "Shepard")), NewInt64("PID", 1))
+	r := NewLazyReader(wire[:len(wire)-6])
+	for r.Next() {
+	}
+	if !errors.Is(r.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("unexpected error %v", r.Err())
+	}
+}
+
+func BenchmarkLazyFind(b *testing.B) {
+	buff := PacketBuff{}
+	data := buff.EncodePacket(0x7802, 0x1A, 0, RequestType, 1, benchContent())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		packet, _, _ := DecodePacket(data)
+		_ = packet.ReadLabels("BUID")
+	}
+}
@@ -0,0 +1,316 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+// Values is a list of labelled values such as the content of a packet or
+// the values of a struct. Values are found by comparing the tag of their
+// label so labels are matched the same way the client matches them
+type Values []Tdf
+
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrWrongType = errors.New("wrong type")
+)
+
+// LookupError is returned when a path can't be followed or the value at the
+// end of the path isn't of the expected type
+type LookupError struct {
+	Path string
+	Err  error
+}
+
+func (e *LookupError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *LookupError) Unwrap() error {
+	return e.Err
+}
+
+// Get finds the value with the provided label
+func (v Values) Get(label string) (Tdf, bool) {
+	tag := LabelToTag(label)
+	for _, value := range v {
+		if value != nil && value.GetHead().Tag == tag {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Path finds a value within nested values. The path is a list of labels
+// separated by dots where each label is looked up within the struct found
+// by the previous label. Unions and generics are followed into their
+// content without needing the label of the content and list items are
+// picked with an index such as "LIST[2].NAME"
+func (v Values) Path(path string) (Tdf, bool) {
+	value, err := v.resolve(path)
+	if err != nil {
+		return nil, false
+	}
+	out, ok := value.(Tdf)
+	return out, ok
+}
+
+// Lookup is like Path but returns an error describing which part of the
+// path couldn't be found
+func (v Values) Lookup(path string) (Tdf, error) {
+	value, err := v.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := value.(Tdf)
+	if !ok {
+		return nil, &LookupError{Path: path, Err: fmt.Errorf("%w: list item %T is not a tdf", ErrWrongType, value)}
+	}
+	return out, nil
+}
+
+// Find looks up the value at the path returning it if it has the type T
+func Find[T Tdf](values Values, path string) (T, bool) {
+	out, err := Lookup[T](values, path)
+	return out, err == nil
+}
+
+// Lookup finds the value at the path returning an error when it can't be
+// found or doesn't have the type T
+func Lookup[T Tdf](values Values, path string) (T, error) {
+	var zero T
+	value, err := values.Lookup(path)
+	if err != nil {
+		return zero, err
+	}
+	out, ok := value.(T)
+	if !ok {
+		return zero, &LookupError{Path: path, Err: fmt.Errorf("%w: %s is not %T", ErrWrongType, typeNameOf(value), zero)}
+	}
+	return out, nil
+}
+
+// Int finds the int at the path
+func (v Values) Int(path string) (int64, bool) {
+	return scalar(v, path, func(value any) (int64, bool) {
+		switch value := value.(type) {
+		case Int64Tdf:
+			return value.Value, true
+		case int64:
+			return value, true
+		}
+		return 0, false
+	})
+}
+
+// IntOr finds the int at the path returning def if there isn't one
+func (v Values) IntOr(path string, def int64) int64 {
+	if value, ok := v.Int(path); ok {
+		return value
+	}
+	return def
+}
+
+// String finds the string at the path
+func (v Values) String(path string) (string, bool) {
+	return scalar(v, path, func(value any) (string, bool) {
+		switch value := value.(type) {
+		case StringTdf:
+			return value.Value, true
+		case string:
+			return value, true
+		}
+		return "", false
+	})
+}
+
+// StringOr finds the string at the path returning def if there isn't one
+func (v Values) StringOr(path string, def string) string {
+	if value, ok := v.String(path); ok {
+		return value
+	}
+	return def
+}
+
+// Blob finds the blob at the path
+func (v Values) Blob(path string) ([]byte, bool) {
+	return scalar(v, path, func(value any) ([]byte, bool) {
+		switch value := value.(type) {
+		case BlobTdf:
+			return value.Data, true
+		case []byte:
+			return value, true
+		}
+		return nil, false
+	})
+}
+
+// Float finds the float at the path
+func (v Values) Float(path string) (float64, bool) {
+	return scalar(v, path, func(value any) (float64, bool) {
+		switch value := value.(type) {
+		case FloatTdf:
+			return value.Value, true
+		case float64:
+			return value, true
+		}
+		return 0, false
+	})
+}
+
+// Pair finds the pair at the path
+func (v Values) Pair(path string) (Pair, bool) {
+	return scalar(v, path, func(value any) (Pair, bool) {
+		switch value := value.(type) {
+		case PairTdf:
+			return value.Pair, true
+		case Pair:
+			return value, true
+		}
+		return Pair{}, false
+	})
+}
+
+// Triple finds the triple at the path
+func (v Values) Triple(path string) (Triple, bool) {
+	return scalar(v, path, func(value any) (Triple, bool) {
+		switch value := value.(type) {
+		case TripleTdf:
+			return value.Triple, true
+		case Triple:
+			return value, true
+		}
+		return Triple{}, false
+	})
+}
+
+// scalar resolves the path and converts the value found with get which
+// accepts both tdf values and list items
+func scalar[T any](v Values, path string, get func(value any) (T, bool)) (T, bool) {
+	value, err := v.resolve(path)
+	if err != nil {
+		var zero T
+		return zero, false
+	}
+	return get(value)
+}
+
+// Struct finds the struct at the path returning its values. Unions holding
+// a struct give the values of that struct
+func (v Values) Struct(path string) (Values, bool) {
+	value, err := v.resolve(path)
+	if err != nil {
+		return nil, false
+	}
+	return structValues(value)
+}
+
+// structValues gets the values of a struct or of the struct within a union
+// or generic
+func structValues(value any) (Values, bool) {
+	switch value := value.(type) {
+	case StructTdf:
+		return value.Values, true
+	case UnionTdf:
+		if value.Content != nil {
+			return structValues(value.Content)
+		}
+	case GenericTdf:
+		if value.Value != nil {
+			return structValues(value.Value)
+		}
+	}
+	return nil, false
+}
+
+func typeNameOf(value any) string {
+	if value, ok := value.(Tdf); ok {
+		return value.GetHead().Type.String()
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// resolve follows the path returning the value at the end which is either
+// a Tdf or a list item
+func (v Values) resolve(path string) (any, error) {
+	segments := strings.Split(path, ".")
+	var current any = v
+	for i, segment := range segments {
+		at := strings.Join(segments[:i+1], ".")
+		label, index, err := parseSegment(segment)
+		if err != nil {
+			return nil, &LookupError{Path: at, Err: err}
+		}
+		value, ok := child(current, label)
+		if !ok {
+			return nil, &LookupError{Path: at, Err: ErrNotFound}
+		}
+		current = value
+		if index < 0 {
+			continue
+		}
+		switch list := value.(type) {
+		case ListValue:
+			if index >= list.Len() {
+				return nil, &LookupError{Path: at, Err: fmt.Errorf("%w: index %d of %d items", ErrNotFound, index, list.Len())}
+			}
+			current = list.Item(index)
+		case VarIntListTdf:
+			if index >= len(list.Values) {
+				return nil, &LookupError{Path: at, Err: fmt.Errorf("%w: index %d of %d items", ErrNotFound, index, len(list.Values))}
+			}
+			current = list.Values[index]
+		default:
+			return nil, &LookupError{Path: at, Err: fmt.Errorf("%w: %s is not a list", ErrWrongType, typeNameOf(value))}
+		}
+	}
+	return current, nil
+}
+
+// parseSegment splits a path segment into its label and list index. The
+// index is -1 when there isn't one
+func parseSegment(segment string) (string, int, error) {
+	open := strings.IndexByte(segment, '[')
+	if open < 0 {
+		return segment, -1, nil
+	}
+	if !strings.HasSuffix(segment, "]") {
+		return "", 0, fmt.Errorf("invalid index in %q", segment)
+	}
+	index, err := strconv.Atoi(segment[open+1 : len(segment)-1])
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("invalid index in %q", segment)
+	}
+	return segment[:open], index, nil
+}
+
+// child finds the labelled value within a container
+func child(container any, label string) (any, bool) {
+	switch container := container.(type) {
+	case Values:
+		return container.Get(label)
+	case UnionTdf:
+		if container.Content == nil {
+			return nil, false
+		}
+		if container.Content.GetHead().Tag == LabelToTag(label) {
+			return container.Content, true
+		}
+		return child(container.Content, label)
+	case GenericTdf:
+		if container.Value == nil {
+			return nil, false
+		}
+		if container.Value.GetHead().Tag == LabelToTag(label) {
+			return container.Value, true
+		}
+		return child(container.Value, label)
+	case StructTdf:
+		return container.Values.Get(label)
+	}
+	return nil, false
+}
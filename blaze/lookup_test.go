@@ -0,0 +1,105 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+func lookupTestValues() Values {
+	return NewBuilder().
+		Int("PID", 12345).
+		String("DSNM", "Shepard").
+		Struct("NQOS", func(b *Builder) {
+			b.Int("DBPS", 0x5F5E100).Int("NATT", 4)
+		}).
+		Union("NLMP", 2, NewStruct("VALU",
+			NewStruct("EXIP", NewInt64("IP", 0x7F000001), NewInt64("PORT", 3659)),
+		)).
+		StructList("USRS", 2, func(i int, b *Builder) {
+			b.Int("ID", int64(i+1))
+		}).
+		IntList("IDS", 4, 5).
+		Triple("BOID", Triple{A: 0x19, B: 1, C: 100}).
+		Values()
+}
+
+func TestValuesLookup(t *testing.T) {
+	values := lookupTestValues()
+	if value, ok := values.Int("PID"); !ok || value != 12345 {
+		t.Errorf("PID = %d, %t", value, ok)
+	}
+	if value, ok := values.String("DSNM"); !ok || value != "Shepard" {
+		t.Errorf("DSNM = %q, %t", value, ok)
+	}
+	if value, ok := values.Int("NQOS.DBPS"); !ok || value != 0x5F5E100 {
+		t.Errorf("NQOS.DBPS = %d, %t", value, ok)
+	}
+	// Unions can be followed with or without the label of their content
+	for _, path := range []string{"NLMP.EXIP.IP", "NLMP.VALU.EXIP.IP"} {
+		if value, ok := values.Int(path); !ok || value != 0x7F000001 {
+			t.Errorf("%s = %d, %t", path, value, ok)
+		}
+	}
+	if value, ok := values.Int("USRS[1].ID"); !ok || value != 2 {
+		t.Errorf("USRS[1].ID = %d, %t", value, ok)
+	}
+	if value, ok := values.Int("IDS[0]"); !ok || value != 4 {
+		t.Errorf("IDS[0] = %d, %t", value, ok)
+	}
+	if value, ok := values.Triple("BOID"); !ok || value.C != 100 {
+		t.Errorf("BOID = %v, %t", value, ok)
+	}
+	if qos, ok := values.Struct("NQOS"); !ok || qos.IntOr("NATT", 0) != 4 {
+		t.Errorf("NQOS = %v, %t", qos, ok)
+	}
+	if _, ok := values.String("PID"); ok {
+		t.Error("PID should not be a string")
+	}
+	if value := values.IntOr("MISS", -1); value != -1 {
+		t.Errorf("missing value gave %d", value)
+	}
+	if list, ok := Find[List[StructTdf]](values, "USRS"); !ok || len(list.Values) != 2 {
+		t.Errorf("USRS = %v, %t", list, ok)
+	}
+}
+
+func TestValuesLookupErrors(t *testing.T) {
+	values := lookupTestValues()
+	_, err := values.Lookup("NQOS.MISS.X")
+	var lookupErr *LookupError
+	if !errors.As(err, &lookupErr) || !errors.Is(err, ErrNotFound) || lookupErr.Path != "NQOS.MISS" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := values.Lookup("USRS[5]"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := values.Lookup("PID[0]"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := Lookup[StringTdf](values, "PID"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	built := NewBuilder().
+		String("NAME", "Shepard").
+		Struct2("PDTL", func(b *Builder) {
+			b.Int("PID", 1).VarIntList("VARS", 2, 3)
+		}).
+		StringMap("CONF", map[string]string{"b": "2", "a": "1"}).
+		EmptyUnion("ADDR").
+		Values()
+	expected := []Tdf{
+		NewString("NAME", "Shepard"),
+		NewStruct2("PDTL", NewInt64("PID", 1), NewVarIntList("VARS", []int64{2, 3})),
+		NewMap("CONF", []string{"a", "b"}, []string{"1", "2"}),
+		NewUnion("ADDR", EmptyType, nil),
+	}
+	if !bytes.Equal(encodeContent(built...), encodeContent(expected...)) {
+		t.Errorf("unexpected values\n%s", FormatContent(built, DefaultFormatOptions))
+	}
+}
@@ -0,0 +1,8 @@
+// Package me3 has go types for the payloads of the ME3 commands and
+// notifications registered in the blaze package along with a handler
+// interface for each component. The types are generated by schemagen so
+// adding a command means adding its schema to the blaze package and running
+// go generate
+package me3
+
+//go:generate go run ../schemagen -builtin -out schemas_gen.go
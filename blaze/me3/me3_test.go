@@ -0,0 +1,86 @@
+package me3
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+func decodeContent(t *testing.T, comp uint16, cmd uint16, qType uint16, content []blaze.Tdf) *blaze.Packet {
+	buf := blaze.PacketBuff{}
+	packet, _, err := blaze.DecodePacket(buf.EncodePacket(comp, cmd, 0, qType, 1, content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &packet
+}
+
+func TestGetServerInstanceResponse(t *testing.T) {
+	message := "maintenance"
+	response := &GetServerInstanceResponse{
+		ADDR: GetServerInstanceResponseADDR{VALU: &GetServerInstanceResponseADDRVALU{HOST: "127.0.0.1", IP: 0x7F000001, PORT: 14219}},
+		AMSG: &message,
+		SECU: 1,
+	}
+	packet := decodeContent(t, RedirectComponent, RedirectGetServerInstance, blaze.ResponseType, response.Values())
+	schema, _ := blaze.LookupSchema(RedirectComponent, RedirectGetServerInstance, blaze.ResponseType)
+	if errs := schema.Validate(packet.ReadContent()); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	decoded := &GetServerInstanceResponse{}
+	if err := decoded.Decode(packet.ReadContent()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, response) {
+		t.Errorf("expected %+v got %+v", response, decoded)
+	}
+}
+
+func TestDecodeWrongType(t *testing.T) {
+	packet := decodeContent(t, UtilComponent, UtilFetchClientConfig, blaze.RequestType, []blaze.Tdf{blaze.NewInt64("CFID", 1)})
+	var schemaErr *blaze.SchemaError
+	err := (&FetchClientConfigRequest{}).Decode(packet.ReadContent())
+	if !errors.As(err, &schemaErr) || schemaErr.Path != "CFID" || !errors.Is(err, blaze.ErrWrongType) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestDecodeMissing(t *testing.T) {
+	err := (&FetchClientConfigRequest{}).Decode(nil)
+	if !errors.Is(err, blaze.ErrMissingField) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+type configHandler struct {
+	UnimplementedUtilHandler
+}
+
+func (configHandler) FetchClientConfig(_ context.Context, request *FetchClientConfigRequest) (*FetchClientConfigResponse, error) {
+	return &FetchClientConfigResponse{CONF: map[string]string{"ID": request.CFID}}, nil
+}
+
+func TestDispatch(t *testing.T) {
+	request := &FetchClientConfigRequest{CFID: "ME3_DATA"}
+	packet := decodeContent(t, UtilComponent, UtilFetchClientConfig, blaze.RequestType, request.Values())
+	values, err := DispatchUtil(context.Background(), configHandler{}, packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := &FetchClientConfigResponse{}
+	if err := response.Decode(values); err != nil || response.CONF["ID"] != "ME3_DATA" {
+		t.Errorf("unexpected response %v %v", response.CONF, err)
+	}
+
+	packet = decodeContent(t, UtilComponent, UtilPing, blaze.RequestType, nil)
+	if _, err := DispatchUtil(context.Background(), configHandler{}, packet); !errors.Is(err, blaze.ErrUnimplemented) {
+		t.Errorf("expected unimplemented got %v", err)
+	}
+	packet = decodeContent(t, RedirectComponent, RedirectGetServerInstance, blaze.RequestType, nil)
+	if _, err := DispatchUtil(context.Background(), configHandler{}, packet); !errors.Is(err, blaze.ErrUnknownCommand) {
+		t.Errorf("expected unknown command got %v", err)
+	}
+}
@@ -0,0 +1,7911 @@
+// Code generated by schemagen. DO NOT EDIT.
+
+package me3
+
+import (
+	"context"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/types"
+)
+
+// Authentication component and the commands and notifications of it
+const (
+	AuthenticationComponent                    uint16 = 0x1
+	AuthenticationCreateAccount                uint16 = 0xA
+	AuthenticationUpdateAccount                uint16 = 0x14
+	AuthenticationUpdateParentalEmail          uint16 = 0x1C
+	AuthenticationListUserEntitlements2        uint16 = 0x1D
+	AuthenticationGetAccount                   uint16 = 0x1E
+	AuthenticationGrantEntitlement             uint16 = 0x1F
+	AuthenticationListEntitlements             uint16 = 0x20
+	AuthenticationHasEntitlement               uint16 = 0x21
+	AuthenticationGetUseCount                  uint16 = 0x22
+	AuthenticationDecrementUseCount            uint16 = 0x23
+	AuthenticationGetAuthToken                 uint16 = 0x24
+	AuthenticationGetHandoffToken              uint16 = 0x25
+	AuthenticationGetPasswordRules             uint16 = 0x26
+	AuthenticationGrantEntitlement2            uint16 = 0x27
+	AuthenticationLogin                        uint16 = 0x28
+	AuthenticationAcceptTos                    uint16 = 0x29
+	AuthenticationGetTosInfo                   uint16 = 0x2A
+	AuthenticationModifyEntitlement2           uint16 = 0x2B
+	AuthenticationConsumecode                  uint16 = 0x2C
+	AuthenticationPasswordForgot               uint16 = 0x2D
+	AuthenticationGetTermsAndConditionsContent uint16 = 0x2E
+	AuthenticationGetPrivacyPolicyContent      uint16 = 0x2F
+	AuthenticationListPersonaEntitlements2     uint16 = 0x30
+	AuthenticationSilentLogin                  uint16 = 0x32
+	AuthenticationCheckAgeReq                  uint16 = 0x33
+	AuthenticationGetOptIn                     uint16 = 0x34
+	AuthenticationEnableOptIn                  uint16 = 0x35
+	AuthenticationDisableOptIn                 uint16 = 0x36
+	AuthenticationExpressLogin                 uint16 = 0x3C
+	AuthenticationLogout                       uint16 = 0x46
+	AuthenticationCreatePersona                uint16 = 0x50
+	AuthenticationGetPersona                   uint16 = 0x5A
+	AuthenticationListPersonas                 uint16 = 0x64
+	AuthenticationLoginPersona                 uint16 = 0x6E
+	AuthenticationLogoutPersona                uint16 = 0x78
+	AuthenticationDeletePersona                uint16 = 0x8C
+	AuthenticationDisablePersona               uint16 = 0x8D
+	AuthenticationListDeviceAccounts           uint16 = 0x8F
+	AuthenticationXboxCreateAccount            uint16 = 0x96
+	AuthenticationOriginLogin                  uint16 = 0x98
+	AuthenticationXboxAssociateAccount         uint16 = 0xA0
+	AuthenticationXboxLogin                    uint16 = 0xAA
+	AuthenticationPs3CreateAccount             uint16 = 0xB4
+	AuthenticationPs3AssociateAccount          uint16 = 0xBE
+	AuthenticationPs3Login                     uint16 = 0xC8
+	AuthenticationValidateSessionKey           uint16 = 0xD2
+	AuthenticationCreateWalUserSession         uint16 = 0xE6
+	AuthenticationAcceptLegalDocs              uint16 = 0xF1
+	AuthenticationGetLegalDocsInfo             uint16 = 0xF2
+	AuthenticationGetTermsOfServiceContent     uint16 = 0xF6
+	AuthenticationDeviceLoginGuest             uint16 = 0x12C
+)
+
+// GetAuthTokenRequest is the request of Authentication getAuthToken which gets the token for the HTTP services
+type GetAuthTokenRequest struct {
+}
+
+// Values encodes the GetAuthTokenRequest as a list of values
+func (m *GetAuthTokenRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the GetAuthTokenRequest from decoded values
+func (m *GetAuthTokenRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// GetAuthTokenResponse is the response of Authentication getAuthToken which gets the token for the HTTP services
+type GetAuthTokenResponse struct {
+	// token for the HTTP services
+	AUTH string
+}
+
+// Values encodes the GetAuthTokenResponse as a list of values
+func (m *GetAuthTokenResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewString("AUTH", m.AUTH))
+	return values
+}
+
+// Decode sets the GetAuthTokenResponse from decoded values
+func (m *GetAuthTokenResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "AUTH", false); err != nil {
+		return err
+	} else if ok {
+		m.AUTH = v.Value
+	}
+	return nil
+}
+
+// LoginRequest is the request of Authentication login which logs in with an email and password
+type LoginRequest struct {
+	// device id
+	DVID *int64
+	// email address of the player
+	MAIL string
+	// password of the player
+	PASS string
+	// login token
+	TOKN *string
+	// login type
+	TYPE *int64
+}
+
+// Values encodes the LoginRequest as a list of values
+func (m *LoginRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 5)
+	if m.DVID != nil {
+		values = append(values, blaze.NewInt64("DVID", *m.DVID))
+	}
+	values = append(values, blaze.NewString("MAIL", m.MAIL))
+	values = append(values, blaze.NewString("PASS", m.PASS))
+	if m.TOKN != nil {
+		values = append(values, blaze.NewString("TOKN", *m.TOKN))
+	}
+	if m.TYPE != nil {
+		values = append(values, blaze.NewInt64("TYPE", *m.TYPE))
+	}
+	return values
+}
+
+// Decode sets the LoginRequest from decoded values
+func (m *LoginRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "DVID", true); err != nil {
+		return err
+	} else if ok {
+		m.DVID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "MAIL", false); err != nil {
+		return err
+	} else if ok {
+		m.MAIL = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PASS", false); err != nil {
+		return err
+	} else if ok {
+		m.PASS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "TOKN", true); err != nil {
+		return err
+	} else if ok {
+		m.TOKN = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", true); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = &v.Value
+	}
+	return nil
+}
+
+// LoginResponse is the response of Authentication login which logs in with an email and password
+type LoginResponse struct {
+	// host of the legal documents
+	LDHT string
+	// 1 when the terms of service must be accepted
+	NTOS int64
+	// token the client logs in with next time
+	PCTK string
+	// personas of the player
+	PLST []LoginResponsePLST
+	// uri of the privacy policy
+	PRIV string
+	// session key
+	SKEY string
+	// whether the player accepted marketing emails
+	SPAM int64
+	// host of the terms of service
+	THST string
+	// uri of the terms of service
+	TSUI string
+	// uri of the terms of service content
+	TURI string
+	// player id
+	UID int64
+}
+
+// Values encodes the LoginResponse as a list of values
+func (m *LoginResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 11)
+	values = append(values, blaze.NewString("LDHT", m.LDHT))
+	values = append(values, blaze.NewInt64("NTOS", m.NTOS))
+	values = append(values, blaze.NewString("PCTK", m.PCTK))
+	values = append(values, blaze.NewStructList("PLST", m.PLST, (*LoginResponsePLST).Values))
+	values = append(values, blaze.NewString("PRIV", m.PRIV))
+	values = append(values, blaze.NewString("SKEY", m.SKEY))
+	values = append(values, blaze.NewInt64("SPAM", m.SPAM))
+	values = append(values, blaze.NewString("THST", m.THST))
+	values = append(values, blaze.NewString("TSUI", m.TSUI))
+	values = append(values, blaze.NewString("TURI", m.TURI))
+	values = append(values, blaze.NewInt64("UID", m.UID))
+	return values
+}
+
+// Decode sets the LoginResponse from decoded values
+func (m *LoginResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LDHT", false); err != nil {
+		return err
+	} else if ok {
+		m.LDHT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "NTOS", false); err != nil {
+		return err
+	} else if ok {
+		m.NTOS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PCTK", false); err != nil {
+		return err
+	} else if ok {
+		m.PCTK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "PLST", false); err != nil {
+		return err
+	} else if ok {
+		m.PLST = make([]LoginResponsePLST, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.PLST[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("PLST", i, err)
+			}
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PRIV", false); err != nil {
+		return err
+	} else if ok {
+		m.PRIV = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SKEY", false); err != nil {
+		return err
+	} else if ok {
+		m.SKEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SPAM", false); err != nil {
+		return err
+	} else if ok {
+		m.SPAM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "THST", false); err != nil {
+		return err
+	} else if ok {
+		m.THST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "TSUI", false); err != nil {
+		return err
+	} else if ok {
+		m.TSUI = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "TURI", false); err != nil {
+		return err
+	} else if ok {
+		m.TURI = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", false); err != nil {
+		return err
+	} else if ok {
+		m.UID = v.Value
+	}
+	return nil
+}
+
+// LoginResponsePLST is an item of personas of the player
+type LoginResponsePLST struct {
+	// display name of the persona
+	DSNM string
+	// time the persona last logged in
+	LAST int64
+	// persona id
+	PID int64
+	// persona status
+	STAS int64
+	// external reference id
+	XREF int64
+	// external reference type
+	XTYP int64
+}
+
+// Values encodes the LoginResponsePLST as a list of values
+func (m *LoginResponsePLST) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewString("DSNM", m.DSNM))
+	values = append(values, blaze.NewInt64("LAST", m.LAST))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	values = append(values, blaze.NewInt64("STAS", m.STAS))
+	values = append(values, blaze.NewInt64("XREF", m.XREF))
+	values = append(values, blaze.NewInt64("XTYP", m.XTYP))
+	return values
+}
+
+// Decode sets the LoginResponsePLST from decoded values
+func (m *LoginResponsePLST) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DSNM", false); err != nil {
+		return err
+	} else if ok {
+		m.DSNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LAST", false); err != nil {
+		return err
+	} else if ok {
+		m.LAST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAS", false); err != nil {
+		return err
+	} else if ok {
+		m.STAS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XREF", false); err != nil {
+		return err
+	} else if ok {
+		m.XREF = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XTYP", false); err != nil {
+		return err
+	} else if ok {
+		m.XTYP = v.Value
+	}
+	return nil
+}
+
+// SilentLoginRequest is the request of Authentication silentLogin which logs in again with a token from an earlier login
+type SilentLoginRequest struct {
+	// token from an earlier login
+	AUTH string
+	// persona id
+	PID int64
+	// login type
+	TYPE *int64
+}
+
+// Values encodes the SilentLoginRequest as a list of values
+func (m *SilentLoginRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("AUTH", m.AUTH))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	if m.TYPE != nil {
+		values = append(values, blaze.NewInt64("TYPE", *m.TYPE))
+	}
+	return values
+}
+
+// Decode sets the SilentLoginRequest from decoded values
+func (m *SilentLoginRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "AUTH", false); err != nil {
+		return err
+	} else if ok {
+		m.AUTH = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", true); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = &v.Value
+	}
+	return nil
+}
+
+// SilentLoginResponse is the response of Authentication silentLogin which logs in again with a token from an earlier login
+type SilentLoginResponse struct {
+	// whether the player can be contacted
+	AGUP int64
+	// host of the legal documents
+	LDHT string
+	// 1 when the terms of service must be accepted
+	NTOS int64
+	// token the client logs in with next time
+	PCTK string
+	// uri of the privacy policy
+	PRIV string
+	// the session
+	SESS SilentLoginResponseSESS
+	// whether the player accepted marketing emails
+	SPAM int64
+	// host of the terms of service
+	THST string
+	// uri of the terms of service
+	TSUI string
+	// uri of the terms of service content
+	TURI string
+}
+
+// Values encodes the SilentLoginResponse as a list of values
+func (m *SilentLoginResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 10)
+	values = append(values, blaze.NewInt64("AGUP", m.AGUP))
+	values = append(values, blaze.NewString("LDHT", m.LDHT))
+	values = append(values, blaze.NewInt64("NTOS", m.NTOS))
+	values = append(values, blaze.NewString("PCTK", m.PCTK))
+	values = append(values, blaze.NewString("PRIV", m.PRIV))
+	values = append(values, blaze.NewStruct("SESS", m.SESS.Values()...))
+	values = append(values, blaze.NewInt64("SPAM", m.SPAM))
+	values = append(values, blaze.NewString("THST", m.THST))
+	values = append(values, blaze.NewString("TSUI", m.TSUI))
+	values = append(values, blaze.NewString("TURI", m.TURI))
+	return values
+}
+
+// Decode sets the SilentLoginResponse from decoded values
+func (m *SilentLoginResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "AGUP", false); err != nil {
+		return err
+	} else if ok {
+		m.AGUP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LDHT", false); err != nil {
+		return err
+	} else if ok {
+		m.LDHT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "NTOS", false); err != nil {
+		return err
+	} else if ok {
+		m.NTOS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PCTK", false); err != nil {
+		return err
+	} else if ok {
+		m.PCTK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PRIV", false); err != nil {
+		return err
+	} else if ok {
+		m.PRIV = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "SESS", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.SESS.Decode(v.Values); err != nil {
+			return blaze.NestedError("SESS", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SPAM", false); err != nil {
+		return err
+	} else if ok {
+		m.SPAM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "THST", false); err != nil {
+		return err
+	} else if ok {
+		m.THST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "TSUI", false); err != nil {
+		return err
+	} else if ok {
+		m.TSUI = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "TURI", false); err != nil {
+		return err
+	} else if ok {
+		m.TURI = v.Value
+	}
+	return nil
+}
+
+// SilentLoginResponseSESS is the session
+type SilentLoginResponseSESS struct {
+	// player id
+	BUID int64
+	// 1 on the first login of the player
+	FRST int64
+	// session key
+	KEY string
+	// time of the last login
+	LLOG int64
+	// email address of the player
+	MAIL string
+	// persona the session is logged into
+	PDTL SilentLoginResponseSESSPDTL
+	// player id
+	UID int64
+}
+
+// Values encodes the SilentLoginResponseSESS as a list of values
+func (m *SilentLoginResponseSESS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 7)
+	values = append(values, blaze.NewInt64("BUID", m.BUID))
+	values = append(values, blaze.NewInt64("FRST", m.FRST))
+	values = append(values, blaze.NewString("KEY", m.KEY))
+	values = append(values, blaze.NewInt64("LLOG", m.LLOG))
+	values = append(values, blaze.NewString("MAIL", m.MAIL))
+	values = append(values, blaze.NewStruct("PDTL", m.PDTL.Values()...))
+	values = append(values, blaze.NewInt64("UID", m.UID))
+	return values
+}
+
+// Decode sets the SilentLoginResponseSESS from decoded values
+func (m *SilentLoginResponseSESS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "BUID", false); err != nil {
+		return err
+	} else if ok {
+		m.BUID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FRST", false); err != nil {
+		return err
+	} else if ok {
+		m.FRST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "KEY", false); err != nil {
+		return err
+	} else if ok {
+		m.KEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LLOG", false); err != nil {
+		return err
+	} else if ok {
+		m.LLOG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "MAIL", false); err != nil {
+		return err
+	} else if ok {
+		m.MAIL = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "PDTL", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.PDTL.Decode(v.Values); err != nil {
+			return blaze.NestedError("PDTL", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", false); err != nil {
+		return err
+	} else if ok {
+		m.UID = v.Value
+	}
+	return nil
+}
+
+// SilentLoginResponseSESSPDTL is persona the session is logged into
+type SilentLoginResponseSESSPDTL struct {
+	// display name of the persona
+	DSNM string
+	// time the persona last logged in
+	LAST int64
+	// persona id
+	PID int64
+	// persona status
+	STAS int64
+	// external reference id
+	XREF int64
+	// external reference type
+	XTYP int64
+}
+
+// Values encodes the SilentLoginResponseSESSPDTL as a list of values
+func (m *SilentLoginResponseSESSPDTL) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewString("DSNM", m.DSNM))
+	values = append(values, blaze.NewInt64("LAST", m.LAST))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	values = append(values, blaze.NewInt64("STAS", m.STAS))
+	values = append(values, blaze.NewInt64("XREF", m.XREF))
+	values = append(values, blaze.NewInt64("XTYP", m.XTYP))
+	return values
+}
+
+// Decode sets the SilentLoginResponseSESSPDTL from decoded values
+func (m *SilentLoginResponseSESSPDTL) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DSNM", false); err != nil {
+		return err
+	} else if ok {
+		m.DSNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LAST", false); err != nil {
+		return err
+	} else if ok {
+		m.LAST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAS", false); err != nil {
+		return err
+	} else if ok {
+		m.STAS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XREF", false); err != nil {
+		return err
+	} else if ok {
+		m.XREF = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XTYP", false); err != nil {
+		return err
+	} else if ok {
+		m.XTYP = v.Value
+	}
+	return nil
+}
+
+// LogoutRequest is the request of Authentication logout which logs out
+type LogoutRequest struct {
+}
+
+// Values encodes the LogoutRequest as a list of values
+func (m *LogoutRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the LogoutRequest from decoded values
+func (m *LogoutRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// LogoutResponse is the response of Authentication logout which logs out
+type LogoutResponse struct {
+}
+
+// Values encodes the LogoutResponse as a list of values
+func (m *LogoutResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the LogoutResponse from decoded values
+func (m *LogoutResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// LoginPersonaRequest is the request of Authentication loginPersona which logs into a persona of the player
+type LoginPersonaRequest struct {
+	// name of the persona
+	PNAM string
+}
+
+// Values encodes the LoginPersonaRequest as a list of values
+func (m *LoginPersonaRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewString("PNAM", m.PNAM))
+	return values
+}
+
+// Decode sets the LoginPersonaRequest from decoded values
+func (m *LoginPersonaRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PNAM", false); err != nil {
+		return err
+	} else if ok {
+		m.PNAM = v.Value
+	}
+	return nil
+}
+
+// LoginPersonaResponse is the response of Authentication loginPersona which logs into a persona of the player
+type LoginPersonaResponse struct {
+	// player id
+	BUID int64
+	// 1 on the first login of the player
+	FRST int64
+	// session key
+	KEY string
+	// time of the last login
+	LLOG int64
+	// email address of the player
+	MAIL string
+	// persona the session is logged into
+	PDTL LoginPersonaResponsePDTL
+	// player id
+	UID int64
+}
+
+// Values encodes the LoginPersonaResponse as a list of values
+func (m *LoginPersonaResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 7)
+	values = append(values, blaze.NewInt64("BUID", m.BUID))
+	values = append(values, blaze.NewInt64("FRST", m.FRST))
+	values = append(values, blaze.NewString("KEY", m.KEY))
+	values = append(values, blaze.NewInt64("LLOG", m.LLOG))
+	values = append(values, blaze.NewString("MAIL", m.MAIL))
+	values = append(values, blaze.NewStruct("PDTL", m.PDTL.Values()...))
+	values = append(values, blaze.NewInt64("UID", m.UID))
+	return values
+}
+
+// Decode sets the LoginPersonaResponse from decoded values
+func (m *LoginPersonaResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "BUID", false); err != nil {
+		return err
+	} else if ok {
+		m.BUID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FRST", false); err != nil {
+		return err
+	} else if ok {
+		m.FRST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "KEY", false); err != nil {
+		return err
+	} else if ok {
+		m.KEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LLOG", false); err != nil {
+		return err
+	} else if ok {
+		m.LLOG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "MAIL", false); err != nil {
+		return err
+	} else if ok {
+		m.MAIL = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "PDTL", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.PDTL.Decode(v.Values); err != nil {
+			return blaze.NestedError("PDTL", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", false); err != nil {
+		return err
+	} else if ok {
+		m.UID = v.Value
+	}
+	return nil
+}
+
+// LoginPersonaResponsePDTL is persona the session is logged into
+type LoginPersonaResponsePDTL struct {
+	// display name of the persona
+	DSNM string
+	// time the persona last logged in
+	LAST int64
+	// persona id
+	PID int64
+	// persona status
+	STAS int64
+	// external reference id
+	XREF int64
+	// external reference type
+	XTYP int64
+}
+
+// Values encodes the LoginPersonaResponsePDTL as a list of values
+func (m *LoginPersonaResponsePDTL) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewString("DSNM", m.DSNM))
+	values = append(values, blaze.NewInt64("LAST", m.LAST))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	values = append(values, blaze.NewInt64("STAS", m.STAS))
+	values = append(values, blaze.NewInt64("XREF", m.XREF))
+	values = append(values, blaze.NewInt64("XTYP", m.XTYP))
+	return values
+}
+
+// Decode sets the LoginPersonaResponsePDTL from decoded values
+func (m *LoginPersonaResponsePDTL) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DSNM", false); err != nil {
+		return err
+	} else if ok {
+		m.DSNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LAST", false); err != nil {
+		return err
+	} else if ok {
+		m.LAST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAS", false); err != nil {
+		return err
+	} else if ok {
+		m.STAS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XREF", false); err != nil {
+		return err
+	} else if ok {
+		m.XREF = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XTYP", false); err != nil {
+		return err
+	} else if ok {
+		m.XTYP = v.Value
+	}
+	return nil
+}
+
+// LogoutPersonaRequest is the request of Authentication logoutPersona which logs out of the persona
+type LogoutPersonaRequest struct {
+}
+
+// Values encodes the LogoutPersonaRequest as a list of values
+func (m *LogoutPersonaRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the LogoutPersonaRequest from decoded values
+func (m *LogoutPersonaRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// LogoutPersonaResponse is the response of Authentication logoutPersona which logs out of the persona
+type LogoutPersonaResponse struct {
+}
+
+// Values encodes the LogoutPersonaResponse as a list of values
+func (m *LogoutPersonaResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the LogoutPersonaResponse from decoded values
+func (m *LogoutPersonaResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// AuthenticationHandler handles the requests of the Authentication component
+type AuthenticationHandler interface {
+	// GetAuthToken gets the token for the HTTP services
+	GetAuthToken(ctx context.Context, request *GetAuthTokenRequest) (*GetAuthTokenResponse, error)
+	// Login logs in with an email and password
+	Login(ctx context.Context, request *LoginRequest) (*LoginResponse, error)
+	// SilentLogin logs in again with a token from an earlier login
+	SilentLogin(ctx context.Context, request *SilentLoginRequest) (*SilentLoginResponse, error)
+	// Logout logs out
+	Logout(ctx context.Context, request *LogoutRequest) (*LogoutResponse, error)
+	// LoginPersona logs into a persona of the player
+	LoginPersona(ctx context.Context, request *LoginPersonaRequest) (*LoginPersonaResponse, error)
+	// LogoutPersona logs out of the persona
+	LogoutPersona(ctx context.Context, request *LogoutPersonaRequest) (*LogoutPersonaResponse, error)
+}
+
+// UnimplementedAuthenticationHandler can be embedded in a AuthenticationHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedAuthenticationHandler struct{}
+
+func (UnimplementedAuthenticationHandler) GetAuthToken(context.Context, *GetAuthTokenRequest) (*GetAuthTokenResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAuthenticationHandler) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAuthenticationHandler) SilentLogin(context.Context, *SilentLoginRequest) (*SilentLoginResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAuthenticationHandler) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAuthenticationHandler) LoginPersona(context.Context, *LoginPersonaRequest) (*LoginPersonaResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAuthenticationHandler) LogoutPersona(context.Context, *LogoutPersonaRequest) (*LogoutPersonaResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchAuthentication decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchAuthentication(ctx context.Context, handler AuthenticationHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != AuthenticationComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case AuthenticationGetAuthToken:
+		request := &GetAuthTokenRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetAuthToken(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AuthenticationLogin:
+		request := &LoginRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.Login(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AuthenticationSilentLogin:
+		request := &SilentLoginRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SilentLogin(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AuthenticationLogout:
+		request := &LogoutRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.Logout(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AuthenticationLoginPersona:
+		request := &LoginPersonaRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.LoginPersona(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AuthenticationLogoutPersona:
+		request := &LogoutPersonaRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.LogoutPersona(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// GameManager component and the commands and notifications of it
+const (
+	GameManagerComponent                               uint16 = 0x4
+	GameManagerCreateGame                              uint16 = 0x1
+	GameManagerDestroyGame                             uint16 = 0x2
+	GameManagerAdvanceGameState                        uint16 = 0x3
+	GameManagerSetGameSettings                         uint16 = 0x4
+	GameManagerSetPlayerCapacity                       uint16 = 0x5
+	GameManagerSetPresenceMode                         uint16 = 0x6
+	GameManagerSetGameAttributes                       uint16 = 0x7
+	GameManagerSetPlayerAttributes                     uint16 = 0x8
+	GameManagerJoinGame                                uint16 = 0x9
+	GameManagerNotifyMatchmakingFailed                 uint16 = 0xA
+	GameManagerRemovePlayer                            uint16 = 0xB
+	GameManagerNotifyMatchmakingAsyncStatus            uint16 = 0xC
+	GameManagerStartMatchmaking                        uint16 = 0xD
+	GameManagerCancelMatchmaking                       uint16 = 0xE
+	GameManagerFinalizeGameCreation                    uint16 = 0xF
+	GameManagerNotifyGameCreated                       uint16 = 0xF
+	GameManagerNotifyGameRemoved                       uint16 = 0x10
+	GameManagerListGames                               uint16 = 0x11
+	GameManagerSetPlayerCustomData                     uint16 = 0x12
+	GameManagerReplayGame                              uint16 = 0x13
+	GameManagerReturnDedicatedServerToPool             uint16 = 0x14
+	GameManagerNotifyGameSetup                         uint16 = 0x14
+	GameManagerJoinGameByGroup                         uint16 = 0x15
+	GameManagerNotifyPlayerJoining                     uint16 = 0x15
+	GameManagerLeaveGameByGroup                        uint16 = 0x16
+	GameManagerNotifyJoiningPlayerInitiateConnections  uint16 = 0x16
+	GameManagerMigrateGame                             uint16 = 0x17
+	GameManagerNotifyPlayerJoiningQueue                uint16 = 0x17
+	GameManagerUpdateGameHostMigrationStatus           uint16 = 0x18
+	GameManagerNotifyPlayerPromotedFromQueue           uint16 = 0x18
+	GameManagerResetDedicatedServer                    uint16 = 0x19
+	GameManagerNotifyPlayerClaimingReservation         uint16 = 0x19
+	GameManagerUpdateGameSession                       uint16 = 0x1A
+	GameManagerBanPlayer                               uint16 = 0x1B
+	GameManagerUpdateMeshConnection                    uint16 = 0x1D
+	GameManagerNotifyPlayerJoinCompleted               uint16 = 0x1E
+	GameManagerRemovePlayerFromBannedList              uint16 = 0x1F
+	GameManagerClearBannedList                         uint16 = 0x20
+	GameManagerGetBannedList                           uint16 = 0x21
+	GameManagerAddQueuedPlayerToGame                   uint16 = 0x26
+	GameManagerUpdateGameName                          uint16 = 0x27
+	GameManagerEjectHost                               uint16 = 0x28
+	GameManagerNotifyPlayerRemoved                     uint16 = 0x28
+	GameManagerNotifyHostMigrationFinished             uint16 = 0x3C
+	GameManagerNotifyHostMigrationStart                uint16 = 0x46
+	GameManagerNotifyPlatformHostInitialized           uint16 = 0x47
+	GameManagerNotifyGameUpdated                       uint16 = 0x50
+	GameManagerNotifyGameAttribChange                  uint16 = 0x50
+	GameManagerNotifyPlayerAttribChange                uint16 = 0x5A
+	GameManagerNotifyPlayerCustomDataChange            uint16 = 0x5F
+	GameManagerGetGameListSnapshot                     uint16 = 0x64
+	GameManagerNotifyGameStateChange                   uint16 = 0x64
+	GameManagerGetGameListSubscription                 uint16 = 0x65
+	GameManagerDestroyGameList                         uint16 = 0x66
+	GameManagerGetFullGameData                         uint16 = 0x67
+	GameManagerGetMatchmakingConfig                    uint16 = 0x68
+	GameManagerGetGameDataFromId                       uint16 = 0x69
+	GameManagerAddAdminPlayer                          uint16 = 0x6A
+	GameManagerRemoveAdminPlayer                       uint16 = 0x6B
+	GameManagerSetPlayerTeam                           uint16 = 0x6C
+	GameManagerChangeGameTeamId                        uint16 = 0x6D
+	GameManagerMigrateAdminPlayer                      uint16 = 0x6E
+	GameManagerNotifyGameSettingsChange                uint16 = 0x6E
+	GameManagerGetUserSetGameListSubscription          uint16 = 0x6F
+	GameManagerNotifyGameCapacityChange                uint16 = 0x6F
+	GameManagerSwapPlayersTeam                         uint16 = 0x70
+	GameManagerNotifyGameReset                         uint16 = 0x70
+	GameManagerNotifyGameReportingIdChange             uint16 = 0x71
+	GameManagerNotifyGameSessionUpdated                uint16 = 0x73
+	GameManagerNotifyGamePlayerStateChange             uint16 = 0x74
+	GameManagerNotifyGamePlayerTeamChange              uint16 = 0x75
+	GameManagerNotifyGameTeamIdChange                  uint16 = 0x76
+	GameManagerNotifyProcessQueue                      uint16 = 0x77
+	GameManagerNotifyPresenceModeChanged               uint16 = 0x78
+	GameManagerNotifyGamePlayerQueuePositionChange     uint16 = 0x79
+	GameManagerRegisterDynamicDedicatedServerCreator   uint16 = 0x96
+	GameManagerUnregisterDynamicDedicatedServerCreator uint16 = 0x97
+	GameManagerNotifyGameListUpdate                    uint16 = 0xC9
+	GameManagerNotifyAdminListChange                   uint16 = 0xCA
+	GameManagerNotifyCreateDynamicDedicatedServerGame  uint16 = 0xDC
+	GameManagerNotifyGameNameChange                    uint16 = 0xE6
+)
+
+// AdvanceGameStateRequest is the request of GameManager advanceGameState which changes the state of a game
+type AdvanceGameStateRequest struct {
+	// game id
+	GID int64
+	// new game state
+	GSTA int64
+}
+
+// Values encodes the AdvanceGameStateRequest as a list of values
+func (m *AdvanceGameStateRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("GSTA", m.GSTA))
+	return values
+}
+
+// Decode sets the AdvanceGameStateRequest from decoded values
+func (m *AdvanceGameStateRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GSTA", false); err != nil {
+		return err
+	} else if ok {
+		m.GSTA = v.Value
+	}
+	return nil
+}
+
+// AdvanceGameStateResponse is the response of GameManager advanceGameState which changes the state of a game
+type AdvanceGameStateResponse struct {
+}
+
+// Values encodes the AdvanceGameStateResponse as a list of values
+func (m *AdvanceGameStateResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the AdvanceGameStateResponse from decoded values
+func (m *AdvanceGameStateResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// SetGameSettingsRequest is the request of GameManager setGameSettings which changes the settings of a game
+type SetGameSettingsRequest struct {
+	// game id
+	GID int64
+	// game setting flags
+	GSET int64
+}
+
+// Values encodes the SetGameSettingsRequest as a list of values
+func (m *SetGameSettingsRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("GSET", m.GSET))
+	return values
+}
+
+// Decode sets the SetGameSettingsRequest from decoded values
+func (m *SetGameSettingsRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GSET", false); err != nil {
+		return err
+	} else if ok {
+		m.GSET = v.Value
+	}
+	return nil
+}
+
+// SetGameSettingsResponse is the response of GameManager setGameSettings which changes the settings of a game
+type SetGameSettingsResponse struct {
+}
+
+// Values encodes the SetGameSettingsResponse as a list of values
+func (m *SetGameSettingsResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the SetGameSettingsResponse from decoded values
+func (m *SetGameSettingsResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// SetGameAttributesRequest is the request of GameManager setGameAttributes which changes attributes of a game
+type SetGameAttributesRequest struct {
+	// attributes to change
+	ATTR map[string]string
+	// game id
+	GID int64
+}
+
+// Values encodes the SetGameAttributesRequest as a list of values
+func (m *SetGameAttributesRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	return values
+}
+
+// Decode sets the SetGameAttributesRequest from decoded values
+func (m *SetGameAttributesRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	return nil
+}
+
+// SetGameAttributesResponse is the response of GameManager setGameAttributes which changes attributes of a game
+type SetGameAttributesResponse struct {
+}
+
+// Values encodes the SetGameAttributesResponse as a list of values
+func (m *SetGameAttributesResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the SetGameAttributesResponse from decoded values
+func (m *SetGameAttributesResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// SetPlayerAttributesRequest is the request of GameManager setPlayerAttributes which changes attributes of a player in a game
+type SetPlayerAttributesRequest struct {
+	// attributes to change
+	ATTR map[string]string
+	// game id
+	GID int64
+	// player id
+	PID int64
+}
+
+// Values encodes the SetPlayerAttributesRequest as a list of values
+func (m *SetPlayerAttributesRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	return values
+}
+
+// Decode sets the SetPlayerAttributesRequest from decoded values
+func (m *SetPlayerAttributesRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	return nil
+}
+
+// SetPlayerAttributesResponse is the response of GameManager setPlayerAttributes which changes attributes of a player in a game
+type SetPlayerAttributesResponse struct {
+}
+
+// Values encodes the SetPlayerAttributesResponse as a list of values
+func (m *SetPlayerAttributesResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the SetPlayerAttributesResponse from decoded values
+func (m *SetPlayerAttributesResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// NotifyMatchmakingFailed is the notification of GameManager NotifyMatchmakingFailed which matchmaking found no game
+type NotifyMatchmakingFailed struct {
+	// fit score of the best game
+	MAXF int64
+	// matchmaking session id
+	MSID int64
+	// matchmaking result
+	RSLT int64
+	// player id
+	USID int64
+}
+
+// Values encodes the NotifyMatchmakingFailed as a list of values
+func (m *NotifyMatchmakingFailed) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewInt64("MAXF", m.MAXF))
+	values = append(values, blaze.NewInt64("MSID", m.MSID))
+	values = append(values, blaze.NewInt64("RSLT", m.RSLT))
+	values = append(values, blaze.NewInt64("USID", m.USID))
+	return values
+}
+
+// Decode sets the NotifyMatchmakingFailed from decoded values
+func (m *NotifyMatchmakingFailed) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MAXF", false); err != nil {
+		return err
+	} else if ok {
+		m.MAXF = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MSID", false); err != nil {
+		return err
+	} else if ok {
+		m.MSID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RSLT", false); err != nil {
+		return err
+	} else if ok {
+		m.RSLT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "USID", false); err != nil {
+		return err
+	} else if ok {
+		m.USID = v.Value
+	}
+	return nil
+}
+
+// RemovePlayerRequest is the request of GameManager removePlayer which removes a player from a game
+type RemovePlayerRequest struct {
+	// context of the removal
+	CNTX *int64
+	// game id
+	GID int64
+	// id of the removed player
+	PID int64
+	// reason the player was removed
+	REAS int64
+}
+
+// Values encodes the RemovePlayerRequest as a list of values
+func (m *RemovePlayerRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	if m.CNTX != nil {
+		values = append(values, blaze.NewInt64("CNTX", *m.CNTX))
+	}
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	values = append(values, blaze.NewInt64("REAS", m.REAS))
+	return values
+}
+
+// Decode sets the RemovePlayerRequest from decoded values
+func (m *RemovePlayerRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "CNTX", true); err != nil {
+		return err
+	} else if ok {
+		m.CNTX = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "REAS", false); err != nil {
+		return err
+	} else if ok {
+		m.REAS = v.Value
+	}
+	return nil
+}
+
+// RemovePlayerResponse is the response of GameManager removePlayer which removes a player from a game
+type RemovePlayerResponse struct {
+}
+
+// Values encodes the RemovePlayerResponse as a list of values
+func (m *RemovePlayerResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the RemovePlayerResponse from decoded values
+func (m *RemovePlayerResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// StartMatchmakingResponse is the response of GameManager startMatchmaking which starts matchmaking for a game to join
+type StartMatchmakingResponse struct {
+	// matchmaking session id
+	MSID int64
+}
+
+// Values encodes the StartMatchmakingResponse as a list of values
+func (m *StartMatchmakingResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("MSID", m.MSID))
+	return values
+}
+
+// Decode sets the StartMatchmakingResponse from decoded values
+func (m *StartMatchmakingResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MSID", false); err != nil {
+		return err
+	} else if ok {
+		m.MSID = v.Value
+	}
+	return nil
+}
+
+// CancelMatchmakingRequest is the request of GameManager cancelMatchmaking which stops matchmaking
+type CancelMatchmakingRequest struct {
+	// matchmaking session id
+	MSID int64
+}
+
+// Values encodes the CancelMatchmakingRequest as a list of values
+func (m *CancelMatchmakingRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("MSID", m.MSID))
+	return values
+}
+
+// Decode sets the CancelMatchmakingRequest from decoded values
+func (m *CancelMatchmakingRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MSID", false); err != nil {
+		return err
+	} else if ok {
+		m.MSID = v.Value
+	}
+	return nil
+}
+
+// CancelMatchmakingResponse is the response of GameManager cancelMatchmaking which stops matchmaking
+type CancelMatchmakingResponse struct {
+}
+
+// Values encodes the CancelMatchmakingResponse as a list of values
+func (m *CancelMatchmakingResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the CancelMatchmakingResponse from decoded values
+func (m *CancelMatchmakingResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// NotifyGameRemoved is the notification of GameManager NotifyGameRemoved which a game was destroyed
+type NotifyGameRemoved struct {
+	// reason the game was destroyed
+	DRSN int64
+	// game id
+	GID int64
+}
+
+// Values encodes the NotifyGameRemoved as a list of values
+func (m *NotifyGameRemoved) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("DRSN", m.DRSN))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	return values
+}
+
+// Decode sets the NotifyGameRemoved from decoded values
+func (m *NotifyGameRemoved) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "DRSN", false); err != nil {
+		return err
+	} else if ok {
+		m.DRSN = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	return nil
+}
+
+// UpdateMeshConnectionRequest is the request of GameManager updateMeshConnection which reports the connections between the players of a game
+type UpdateMeshConnectionRequest struct {
+	// game id
+	GID int64
+	// connections to other players
+	TARG []UpdateMeshConnectionRequestTARG
+}
+
+// Values encodes the UpdateMeshConnectionRequest as a list of values
+func (m *UpdateMeshConnectionRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewStructList("TARG", m.TARG, (*UpdateMeshConnectionRequestTARG).Values))
+	return values
+}
+
+// Decode sets the UpdateMeshConnectionRequest from decoded values
+func (m *UpdateMeshConnectionRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "TARG", false); err != nil {
+		return err
+	} else if ok {
+		m.TARG = make([]UpdateMeshConnectionRequestTARG, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.TARG[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("TARG", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateMeshConnectionRequestTARG is an item of connections to other players
+type UpdateMeshConnectionRequestTARG struct {
+	// connection flags
+	FLGS int64
+	// id of the other player
+	PID int64
+	// connection state
+	STAT int64
+}
+
+// Values encodes the UpdateMeshConnectionRequestTARG as a list of values
+func (m *UpdateMeshConnectionRequestTARG) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewInt64("FLGS", m.FLGS))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	values = append(values, blaze.NewInt64("STAT", m.STAT))
+	return values
+}
+
+// Decode sets the UpdateMeshConnectionRequestTARG from decoded values
+func (m *UpdateMeshConnectionRequestTARG) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLGS", false); err != nil {
+		return err
+	} else if ok {
+		m.FLGS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Value
+	}
+	return nil
+}
+
+// UpdateMeshConnectionResponse is the response of GameManager updateMeshConnection which reports the connections between the players of a game
+type UpdateMeshConnectionResponse struct {
+}
+
+// Values encodes the UpdateMeshConnectionResponse as a list of values
+func (m *UpdateMeshConnectionResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the UpdateMeshConnectionResponse from decoded values
+func (m *UpdateMeshConnectionResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// NotifyPlayerJoinCompleted is the notification of GameManager NotifyPlayerJoinCompleted which a player finished joining a game
+type NotifyPlayerJoinCompleted struct {
+	// game id
+	GID int64
+	// player id
+	PID int64
+}
+
+// Values encodes the NotifyPlayerJoinCompleted as a list of values
+func (m *NotifyPlayerJoinCompleted) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	return values
+}
+
+// Decode sets the NotifyPlayerJoinCompleted from decoded values
+func (m *NotifyPlayerJoinCompleted) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	return nil
+}
+
+// NotifyPlayerRemoved is the notification of GameManager NotifyPlayerRemoved which a player left a game
+type NotifyPlayerRemoved struct {
+	// context of the removal
+	CNTX *int64
+	// game id
+	GID int64
+	// id of the removed player
+	PID int64
+	// reason the player was removed
+	REAS int64
+}
+
+// Values encodes the NotifyPlayerRemoved as a list of values
+func (m *NotifyPlayerRemoved) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	if m.CNTX != nil {
+		values = append(values, blaze.NewInt64("CNTX", *m.CNTX))
+	}
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	values = append(values, blaze.NewInt64("REAS", m.REAS))
+	return values
+}
+
+// Decode sets the NotifyPlayerRemoved from decoded values
+func (m *NotifyPlayerRemoved) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "CNTX", true); err != nil {
+		return err
+	} else if ok {
+		m.CNTX = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "REAS", false); err != nil {
+		return err
+	} else if ok {
+		m.REAS = v.Value
+	}
+	return nil
+}
+
+// NotifyGameAttribChange is the notification of GameManager NotifyGameAttribChange which attributes of a game changed
+type NotifyGameAttribChange struct {
+	// the changed attributes
+	ATTR map[string]string
+	// game id
+	GID int64
+}
+
+// Values encodes the NotifyGameAttribChange as a list of values
+func (m *NotifyGameAttribChange) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	return values
+}
+
+// Decode sets the NotifyGameAttribChange from decoded values
+func (m *NotifyGameAttribChange) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	return nil
+}
+
+// NotifyPlayerAttribChange is the notification of GameManager NotifyPlayerAttribChange which attributes of a player in a game changed
+type NotifyPlayerAttribChange struct {
+	// the changed attributes
+	ATTR map[string]string
+	// game id
+	GID int64
+	// player id
+	PID int64
+}
+
+// Values encodes the NotifyPlayerAttribChange as a list of values
+func (m *NotifyPlayerAttribChange) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("PID", m.PID))
+	return values
+}
+
+// Decode sets the NotifyPlayerAttribChange from decoded values
+func (m *NotifyPlayerAttribChange) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PID", false); err != nil {
+		return err
+	} else if ok {
+		m.PID = v.Value
+	}
+	return nil
+}
+
+// NotifyGameStateChange is the notification of GameManager NotifyGameStateChange which the state of a game changed
+type NotifyGameStateChange struct {
+	// game id
+	GID int64
+	// new game state
+	GSTA int64
+}
+
+// Values encodes the NotifyGameStateChange as a list of values
+func (m *NotifyGameStateChange) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("GSTA", m.GSTA))
+	return values
+}
+
+// Decode sets the NotifyGameStateChange from decoded values
+func (m *NotifyGameStateChange) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GSTA", false); err != nil {
+		return err
+	} else if ok {
+		m.GSTA = v.Value
+	}
+	return nil
+}
+
+// NotifyGameSettingsChange is the notification of GameManager NotifyGameSettingsChange which the settings of a game changed
+type NotifyGameSettingsChange struct {
+	// game setting flags
+	ATTR int64
+	// game id
+	GID int64
+}
+
+// Values encodes the NotifyGameSettingsChange as a list of values
+func (m *NotifyGameSettingsChange) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	return values
+}
+
+// Decode sets the NotifyGameSettingsChange from decoded values
+func (m *NotifyGameSettingsChange) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	return nil
+}
+
+// NotifyAdminListChange is the notification of GameManager NotifyAdminListChange which a player was made or stopped being an admin of a game
+type NotifyAdminListChange struct {
+	// id of the player that changed
+	ALST int64
+	// game id
+	GID int64
+	// 0 when added and 1 when removed
+	OPER int64
+	// id of the player making the change
+	UID int64
+}
+
+// Values encodes the NotifyAdminListChange as a list of values
+func (m *NotifyAdminListChange) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewInt64("ALST", m.ALST))
+	values = append(values, blaze.NewInt64("GID", m.GID))
+	values = append(values, blaze.NewInt64("OPER", m.OPER))
+	values = append(values, blaze.NewInt64("UID", m.UID))
+	return values
+}
+
+// Decode sets the NotifyAdminListChange from decoded values
+func (m *NotifyAdminListChange) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ALST", false); err != nil {
+		return err
+	} else if ok {
+		m.ALST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "GID", false); err != nil {
+		return err
+	} else if ok {
+		m.GID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "OPER", false); err != nil {
+		return err
+	} else if ok {
+		m.OPER = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", false); err != nil {
+		return err
+	} else if ok {
+		m.UID = v.Value
+	}
+	return nil
+}
+
+// GameManagerHandler handles the requests of the GameManager component
+type GameManagerHandler interface {
+	// AdvanceGameState changes the state of a game
+	AdvanceGameState(ctx context.Context, request *AdvanceGameStateRequest) (*AdvanceGameStateResponse, error)
+	// SetGameSettings changes the settings of a game
+	SetGameSettings(ctx context.Context, request *SetGameSettingsRequest) (*SetGameSettingsResponse, error)
+	// SetGameAttributes changes attributes of a game
+	SetGameAttributes(ctx context.Context, request *SetGameAttributesRequest) (*SetGameAttributesResponse, error)
+	// SetPlayerAttributes changes attributes of a player in a game
+	SetPlayerAttributes(ctx context.Context, request *SetPlayerAttributesRequest) (*SetPlayerAttributesResponse, error)
+	// RemovePlayer removes a player from a game
+	RemovePlayer(ctx context.Context, request *RemovePlayerRequest) (*RemovePlayerResponse, error)
+	// CancelMatchmaking stops matchmaking
+	CancelMatchmaking(ctx context.Context, request *CancelMatchmakingRequest) (*CancelMatchmakingResponse, error)
+	// UpdateMeshConnection reports the connections between the players of a game
+	UpdateMeshConnection(ctx context.Context, request *UpdateMeshConnectionRequest) (*UpdateMeshConnectionResponse, error)
+}
+
+// UnimplementedGameManagerHandler can be embedded in a GameManagerHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedGameManagerHandler struct{}
+
+func (UnimplementedGameManagerHandler) AdvanceGameState(context.Context, *AdvanceGameStateRequest) (*AdvanceGameStateResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedGameManagerHandler) SetGameSettings(context.Context, *SetGameSettingsRequest) (*SetGameSettingsResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedGameManagerHandler) SetGameAttributes(context.Context, *SetGameAttributesRequest) (*SetGameAttributesResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedGameManagerHandler) SetPlayerAttributes(context.Context, *SetPlayerAttributesRequest) (*SetPlayerAttributesResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedGameManagerHandler) RemovePlayer(context.Context, *RemovePlayerRequest) (*RemovePlayerResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedGameManagerHandler) CancelMatchmaking(context.Context, *CancelMatchmakingRequest) (*CancelMatchmakingResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedGameManagerHandler) UpdateMeshConnection(context.Context, *UpdateMeshConnectionRequest) (*UpdateMeshConnectionResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchGameManager decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchGameManager(ctx context.Context, handler GameManagerHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != GameManagerComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case GameManagerAdvanceGameState:
+		request := &AdvanceGameStateRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.AdvanceGameState(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case GameManagerSetGameSettings:
+		request := &SetGameSettingsRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SetGameSettings(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case GameManagerSetGameAttributes:
+		request := &SetGameAttributesRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SetGameAttributes(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case GameManagerSetPlayerAttributes:
+		request := &SetPlayerAttributesRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SetPlayerAttributes(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case GameManagerRemovePlayer:
+		request := &RemovePlayerRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.RemovePlayer(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case GameManagerCancelMatchmaking:
+		request := &CancelMatchmakingRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.CancelMatchmaking(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case GameManagerUpdateMeshConnection:
+		request := &UpdateMeshConnectionRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UpdateMeshConnection(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// Redirect component and the commands and notifications of it
+const (
+	RedirectComponent         uint16 = 0x5
+	RedirectGetServerInstance uint16 = 0x1
+)
+
+// GetServerInstanceRequest is the request of Redirect getServerInstance which finds the main server the client connects to
+type GetServerInstanceRequest struct {
+	// version of the Blaze SDK
+	BSDK string
+	// build time of the Blaze SDK
+	BTIM string
+	// client name
+	CLNT string
+	// client platform type
+	CPFT *int64
+	// client SKU
+	CSKU string
+	// client version
+	CVER string
+	// version of the DirtySDK
+	DSDK string
+	// environment such as prod
+	ENV string
+	// first party id
+	FPID blaze.Tdf
+	// locale such as 0x656e5553 for enUS
+	LOC int64
+	// service name such as masseffect-3-pc
+	NAME string
+	// platform such as Windows
+	PLAT string
+	// profile
+	PROF string
+}
+
+// Values encodes the GetServerInstanceRequest as a list of values
+func (m *GetServerInstanceRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 13)
+	values = append(values, blaze.NewString("BSDK", m.BSDK))
+	values = append(values, blaze.NewString("BTIM", m.BTIM))
+	values = append(values, blaze.NewString("CLNT", m.CLNT))
+	if m.CPFT != nil {
+		values = append(values, blaze.NewInt64("CPFT", *m.CPFT))
+	}
+	values = append(values, blaze.NewString("CSKU", m.CSKU))
+	values = append(values, blaze.NewString("CVER", m.CVER))
+	values = append(values, blaze.NewString("DSDK", m.DSDK))
+	values = append(values, blaze.NewString("ENV", m.ENV))
+	if m.FPID != nil {
+		values = append(values, m.FPID)
+	}
+	values = append(values, blaze.NewInt64("LOC", m.LOC))
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	values = append(values, blaze.NewString("PLAT", m.PLAT))
+	values = append(values, blaze.NewString("PROF", m.PROF))
+	return values
+}
+
+// Decode sets the GetServerInstanceRequest from decoded values
+func (m *GetServerInstanceRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "BSDK", false); err != nil {
+		return err
+	} else if ok {
+		m.BSDK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "BTIM", false); err != nil {
+		return err
+	} else if ok {
+		m.BTIM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CLNT", false); err != nil {
+		return err
+	} else if ok {
+		m.CLNT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "CPFT", true); err != nil {
+		return err
+	} else if ok {
+		m.CPFT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CSKU", false); err != nil {
+		return err
+	} else if ok {
+		m.CSKU = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CVER", false); err != nil {
+		return err
+	} else if ok {
+		m.CVER = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DSDK", false); err != nil {
+		return err
+	} else if ok {
+		m.DSDK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ENV", false); err != nil {
+		return err
+	} else if ok {
+		m.ENV = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Tdf](values, "FPID", true); err != nil {
+		return err
+	} else if ok {
+		m.FPID = v
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LOC", false); err != nil {
+		return err
+	} else if ok {
+		m.LOC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PLAT", false); err != nil {
+		return err
+	} else if ok {
+		m.PLAT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PROF", false); err != nil {
+		return err
+	} else if ok {
+		m.PROF = v.Value
+	}
+	return nil
+}
+
+// GetServerInstanceResponse is the response of Redirect getServerInstance which finds the main server the client connects to
+type GetServerInstanceResponse struct {
+	// address of the main server
+	ADDR GetServerInstanceResponseADDR
+	// message shown to the player
+	AMSG *string
+	// 1 when the main server uses SSL
+	SECU int64
+	// whether the client resolves the host itself
+	XDNS int64
+}
+
+// Values encodes the GetServerInstanceResponse as a list of values
+func (m *GetServerInstanceResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.UnionOf("ADDR", m.ADDR.Type, m.ADDR.Values()))
+	if m.AMSG != nil {
+		values = append(values, blaze.NewString("AMSG", *m.AMSG))
+	}
+	values = append(values, blaze.NewInt64("SECU", m.SECU))
+	values = append(values, blaze.NewInt64("XDNS", m.XDNS))
+	return values
+}
+
+// Decode sets the GetServerInstanceResponse from decoded values
+func (m *GetServerInstanceResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.UnionTdf](values, "ADDR", false); err != nil {
+		return err
+	} else if ok {
+		m.ADDR.Type = v.Type
+		if v.Content != nil {
+			if err := m.ADDR.Decode(blaze.Values{v.Content}); err != nil {
+				return blaze.NestedError("ADDR", err)
+			}
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "AMSG", true); err != nil {
+		return err
+	} else if ok {
+		m.AMSG = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SECU", false); err != nil {
+		return err
+	} else if ok {
+		m.SECU = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "XDNS", false); err != nil {
+		return err
+	} else if ok {
+		m.XDNS = v.Value
+	}
+	return nil
+}
+
+// GetServerInstanceResponseADDR is address of the main server
+type GetServerInstanceResponseADDR struct {
+	// Type is the union type written before the value
+	Type blaze.TdfType
+	// ip address
+	VALU *GetServerInstanceResponseADDRVALU
+}
+
+// Values encodes the set values of the union. Only the first is written
+func (m *GetServerInstanceResponseADDR) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	if m.VALU != nil {
+		values = append(values, blaze.NewStruct("VALU", m.VALU.Values()...))
+	}
+	return values
+}
+
+// Decode sets the GetServerInstanceResponseADDR from decoded values
+func (m *GetServerInstanceResponseADDR) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "VALU", true); err != nil {
+		return err
+	} else if ok {
+		m.VALU = &GetServerInstanceResponseADDRVALU{}
+		if err := m.VALU.Decode(v.Values); err != nil {
+			return blaze.NestedError("VALU", err)
+		}
+	}
+	return nil
+}
+
+// GetServerInstanceResponseADDRVALU is ip address
+type GetServerInstanceResponseADDRVALU struct {
+	// host name
+	HOST string
+	// ip address as a number
+	IP int64
+	// port
+	PORT int64
+}
+
+// Values encodes the GetServerInstanceResponseADDRVALU as a list of values
+func (m *GetServerInstanceResponseADDRVALU) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("HOST", m.HOST))
+	values = append(values, blaze.NewInt64("IP", m.IP))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	return values
+}
+
+// Decode sets the GetServerInstanceResponseADDRVALU from decoded values
+func (m *GetServerInstanceResponseADDRVALU) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "HOST", false); err != nil {
+		return err
+	} else if ok {
+		m.HOST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "IP", false); err != nil {
+		return err
+	} else if ok {
+		m.IP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	return nil
+}
+
+// RedirectHandler handles the requests of the Redirect component
+type RedirectHandler interface {
+	// GetServerInstance finds the main server the client connects to
+	GetServerInstance(ctx context.Context, request *GetServerInstanceRequest) (*GetServerInstanceResponse, error)
+}
+
+// UnimplementedRedirectHandler can be embedded in a RedirectHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedRedirectHandler struct{}
+
+func (UnimplementedRedirectHandler) GetServerInstance(context.Context, *GetServerInstanceRequest) (*GetServerInstanceResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchRedirect decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchRedirect(ctx context.Context, handler RedirectHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != RedirectComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case RedirectGetServerInstance:
+		request := &GetServerInstanceRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetServerInstance(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// Stats component and the commands and notifications of it
+const (
+	StatsComponent                 uint16 = 0x7
+	StatsGetStatDescs              uint16 = 0x1
+	StatsGetStats                  uint16 = 0x2
+	StatsGetStatGroupList          uint16 = 0x3
+	StatsGetStatGroup              uint16 = 0x4
+	StatsGetStatsByGroup           uint16 = 0x5
+	StatsGetDateRange              uint16 = 0x6
+	StatsGetEntityCount            uint16 = 0x7
+	StatsGetLeaderboardGroup       uint16 = 0xA
+	StatsGetLeaderboardFolderGroup uint16 = 0xB
+	StatsGetLeaderboard            uint16 = 0xC
+	StatsGetCenteredLeaderboard    uint16 = 0xD
+	StatsGetFilteredLeaderboard    uint16 = 0xE
+	StatsGetKeyScopesMap           uint16 = 0xF
+	StatsGetStatsByGroupAsync      uint16 = 0x10
+	StatsGetLeaderboardTreeAsync   uint16 = 0x11
+	StatsGetLeaderboardEntityCount uint16 = 0x12
+	StatsGetStatCategoryList       uint16 = 0x13
+	StatsGetPeriodIds              uint16 = 0x14
+	StatsGetLeaderboardRaw         uint16 = 0x15
+	StatsGetCenteredLeaderboardRaw uint16 = 0x16
+	StatsGetFilteredLeaderboardRaw uint16 = 0x17
+	StatsChangeKeyscopeValue       uint16 = 0x18
+)
+
+// GetLeaderboardRequest is the request of Stats getLeaderboard which gets rows of a leaderboard by rank
+type GetLeaderboardRequest struct {
+	// number of rows
+	COUN int64
+	// key scope values
+	KSUM map[string]int64
+	// leaderboard name such as N7RatingGlobal
+	NAME string
+	// period offset
+	POFF *int64
+	// rank of the first row
+	STRT int64
+	// period time
+	TIME *int64
+	// set of users to rank
+	USET blaze.Values
+}
+
+// Values encodes the GetLeaderboardRequest as a list of values
+func (m *GetLeaderboardRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 7)
+	values = append(values, blaze.NewInt64("COUN", m.COUN))
+	if m.KSUM != nil {
+		values = append(values, blaze.NewSortedMap("KSUM", m.KSUM))
+	}
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	if m.POFF != nil {
+		values = append(values, blaze.NewInt64("POFF", *m.POFF))
+	}
+	values = append(values, blaze.NewInt64("STRT", m.STRT))
+	if m.TIME != nil {
+		values = append(values, blaze.NewInt64("TIME", *m.TIME))
+	}
+	if m.USET != nil {
+		values = append(values, blaze.NewStruct("USET", m.USET...))
+	}
+	return values
+}
+
+// Decode sets the GetLeaderboardRequest from decoded values
+func (m *GetLeaderboardRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "COUN", false); err != nil {
+		return err
+	} else if ok {
+		m.COUN = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, int64]](values, "KSUM", true); err != nil {
+		return err
+	} else if ok {
+		m.KSUM = make(map[string]int64, len(v.Keys))
+		for i, key := range v.Keys {
+			m.KSUM[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "POFF", true); err != nil {
+		return err
+	} else if ok {
+		m.POFF = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STRT", false); err != nil {
+		return err
+	} else if ok {
+		m.STRT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", true); err != nil {
+		return err
+	} else if ok {
+		m.TIME = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USET", true); err != nil {
+		return err
+	} else if ok {
+		m.USET = v.Values
+	}
+	return nil
+}
+
+// GetLeaderboardResponse is the response of Stats getLeaderboard which gets rows of a leaderboard by rank
+type GetLeaderboardResponse struct {
+	// leaderboard rows
+	LDLS []GetLeaderboardResponseLDLS
+}
+
+// Values encodes the GetLeaderboardResponse as a list of values
+func (m *GetLeaderboardResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LDLS", m.LDLS, (*GetLeaderboardResponseLDLS).Values))
+	return values
+}
+
+// Decode sets the GetLeaderboardResponse from decoded values
+func (m *GetLeaderboardResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LDLS", false); err != nil {
+		return err
+	} else if ok {
+		m.LDLS = make([]GetLeaderboardResponseLDLS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LDLS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LDLS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetLeaderboardResponseLDLS is an item of leaderboard rows
+type GetLeaderboardResponseLDLS struct {
+	// name of the player
+	ENAM string
+	// player id
+	ENID int64
+	// rank of the player
+	RANK int64
+	// value of the ranked stat
+	RSTA string
+	// raw stat flags
+	RWFG int64
+	// raw stats
+	RWST blaze.Tdf
+	// stat values of the row
+	STAT []string
+	// user attributes
+	UATT int64
+}
+
+// Values encodes the GetLeaderboardResponseLDLS as a list of values
+func (m *GetLeaderboardResponseLDLS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 8)
+	values = append(values, blaze.NewString("ENAM", m.ENAM))
+	values = append(values, blaze.NewInt64("ENID", m.ENID))
+	values = append(values, blaze.NewInt64("RANK", m.RANK))
+	values = append(values, blaze.NewString("RSTA", m.RSTA))
+	values = append(values, blaze.NewInt64("RWFG", m.RWFG))
+	if m.RWST != nil {
+		values = append(values, m.RWST)
+	}
+	values = append(values, blaze.NewList("STAT", m.STAT))
+	values = append(values, blaze.NewInt64("UATT", m.UATT))
+	return values
+}
+
+// Decode sets the GetLeaderboardResponseLDLS from decoded values
+func (m *GetLeaderboardResponseLDLS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ENAM", false); err != nil {
+		return err
+	} else if ok {
+		m.ENAM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ENID", false); err != nil {
+		return err
+	} else if ok {
+		m.ENID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RANK", false); err != nil {
+		return err
+	} else if ok {
+		m.RANK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "RSTA", false); err != nil {
+		return err
+	} else if ok {
+		m.RSTA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RWFG", false); err != nil {
+		return err
+	} else if ok {
+		m.RWFG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Tdf](values, "RWST", true); err != nil {
+		return err
+	} else if ok {
+		m.RWST = v
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[string]](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Values
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UATT", false); err != nil {
+		return err
+	} else if ok {
+		m.UATT = v.Value
+	}
+	return nil
+}
+
+// GetCenteredLeaderboardRequest is the request of Stats getCenteredLeaderboard which gets rows of a leaderboard around a player
+type GetCenteredLeaderboardRequest struct {
+	// id of the player in the middle
+	CENT int64
+	// number of rows
+	COUN int64
+	// key scope values
+	KSUM map[string]int64
+	// leaderboard name such as N7RatingGlobal
+	NAME string
+	// period offset
+	POFF *int64
+	// period time
+	TIME *int64
+	// set of users to rank
+	USET blaze.Values
+}
+
+// Values encodes the GetCenteredLeaderboardRequest as a list of values
+func (m *GetCenteredLeaderboardRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 7)
+	values = append(values, blaze.NewInt64("CENT", m.CENT))
+	values = append(values, blaze.NewInt64("COUN", m.COUN))
+	if m.KSUM != nil {
+		values = append(values, blaze.NewSortedMap("KSUM", m.KSUM))
+	}
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	if m.POFF != nil {
+		values = append(values, blaze.NewInt64("POFF", *m.POFF))
+	}
+	if m.TIME != nil {
+		values = append(values, blaze.NewInt64("TIME", *m.TIME))
+	}
+	if m.USET != nil {
+		values = append(values, blaze.NewStruct("USET", m.USET...))
+	}
+	return values
+}
+
+// Decode sets the GetCenteredLeaderboardRequest from decoded values
+func (m *GetCenteredLeaderboardRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "CENT", false); err != nil {
+		return err
+	} else if ok {
+		m.CENT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "COUN", false); err != nil {
+		return err
+	} else if ok {
+		m.COUN = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, int64]](values, "KSUM", true); err != nil {
+		return err
+	} else if ok {
+		m.KSUM = make(map[string]int64, len(v.Keys))
+		for i, key := range v.Keys {
+			m.KSUM[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "POFF", true); err != nil {
+		return err
+	} else if ok {
+		m.POFF = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", true); err != nil {
+		return err
+	} else if ok {
+		m.TIME = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USET", true); err != nil {
+		return err
+	} else if ok {
+		m.USET = v.Values
+	}
+	return nil
+}
+
+// GetCenteredLeaderboardResponse is the response of Stats getCenteredLeaderboard which gets rows of a leaderboard around a player
+type GetCenteredLeaderboardResponse struct {
+	// leaderboard rows
+	LDLS []GetCenteredLeaderboardResponseLDLS
+}
+
+// Values encodes the GetCenteredLeaderboardResponse as a list of values
+func (m *GetCenteredLeaderboardResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LDLS", m.LDLS, (*GetCenteredLeaderboardResponseLDLS).Values))
+	return values
+}
+
+// Decode sets the GetCenteredLeaderboardResponse from decoded values
+func (m *GetCenteredLeaderboardResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LDLS", false); err != nil {
+		return err
+	} else if ok {
+		m.LDLS = make([]GetCenteredLeaderboardResponseLDLS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LDLS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LDLS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetCenteredLeaderboardResponseLDLS is an item of leaderboard rows
+type GetCenteredLeaderboardResponseLDLS struct {
+	// name of the player
+	ENAM string
+	// player id
+	ENID int64
+	// rank of the player
+	RANK int64
+	// value of the ranked stat
+	RSTA string
+	// raw stat flags
+	RWFG int64
+	// raw stats
+	RWST blaze.Tdf
+	// stat values of the row
+	STAT []string
+	// user attributes
+	UATT int64
+}
+
+// Values encodes the GetCenteredLeaderboardResponseLDLS as a list of values
+func (m *GetCenteredLeaderboardResponseLDLS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 8)
+	values = append(values, blaze.NewString("ENAM", m.ENAM))
+	values = append(values, blaze.NewInt64("ENID", m.ENID))
+	values = append(values, blaze.NewInt64("RANK", m.RANK))
+	values = append(values, blaze.NewString("RSTA", m.RSTA))
+	values = append(values, blaze.NewInt64("RWFG", m.RWFG))
+	if m.RWST != nil {
+		values = append(values, m.RWST)
+	}
+	values = append(values, blaze.NewList("STAT", m.STAT))
+	values = append(values, blaze.NewInt64("UATT", m.UATT))
+	return values
+}
+
+// Decode sets the GetCenteredLeaderboardResponseLDLS from decoded values
+func (m *GetCenteredLeaderboardResponseLDLS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ENAM", false); err != nil {
+		return err
+	} else if ok {
+		m.ENAM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ENID", false); err != nil {
+		return err
+	} else if ok {
+		m.ENID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RANK", false); err != nil {
+		return err
+	} else if ok {
+		m.RANK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "RSTA", false); err != nil {
+		return err
+	} else if ok {
+		m.RSTA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RWFG", false); err != nil {
+		return err
+	} else if ok {
+		m.RWFG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Tdf](values, "RWST", true); err != nil {
+		return err
+	} else if ok {
+		m.RWST = v
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[string]](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Values
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UATT", false); err != nil {
+		return err
+	} else if ok {
+		m.UATT = v.Value
+	}
+	return nil
+}
+
+// GetFilteredLeaderboardRequest is the request of Stats getFilteredLeaderboard which gets the rows of a leaderboard for some players
+type GetFilteredLeaderboardRequest struct {
+	// ids of the players
+	IDLS []int64
+	// key scope values
+	KSUM map[string]int64
+	// leaderboard name such as N7RatingGlobal
+	NAME string
+	// period offset
+	POFF *int64
+	// period time
+	TIME *int64
+	// set of users to rank
+	USET blaze.Values
+}
+
+// Values encodes the GetFilteredLeaderboardRequest as a list of values
+func (m *GetFilteredLeaderboardRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewList("IDLS", m.IDLS))
+	if m.KSUM != nil {
+		values = append(values, blaze.NewSortedMap("KSUM", m.KSUM))
+	}
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	if m.POFF != nil {
+		values = append(values, blaze.NewInt64("POFF", *m.POFF))
+	}
+	if m.TIME != nil {
+		values = append(values, blaze.NewInt64("TIME", *m.TIME))
+	}
+	if m.USET != nil {
+		values = append(values, blaze.NewStruct("USET", m.USET...))
+	}
+	return values
+}
+
+// Decode sets the GetFilteredLeaderboardRequest from decoded values
+func (m *GetFilteredLeaderboardRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[int64]](values, "IDLS", false); err != nil {
+		return err
+	} else if ok {
+		m.IDLS = v.Values
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, int64]](values, "KSUM", true); err != nil {
+		return err
+	} else if ok {
+		m.KSUM = make(map[string]int64, len(v.Keys))
+		for i, key := range v.Keys {
+			m.KSUM[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "POFF", true); err != nil {
+		return err
+	} else if ok {
+		m.POFF = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", true); err != nil {
+		return err
+	} else if ok {
+		m.TIME = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USET", true); err != nil {
+		return err
+	} else if ok {
+		m.USET = v.Values
+	}
+	return nil
+}
+
+// GetFilteredLeaderboardResponse is the response of Stats getFilteredLeaderboard which gets the rows of a leaderboard for some players
+type GetFilteredLeaderboardResponse struct {
+	// leaderboard rows
+	LDLS []GetFilteredLeaderboardResponseLDLS
+}
+
+// Values encodes the GetFilteredLeaderboardResponse as a list of values
+func (m *GetFilteredLeaderboardResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LDLS", m.LDLS, (*GetFilteredLeaderboardResponseLDLS).Values))
+	return values
+}
+
+// Decode sets the GetFilteredLeaderboardResponse from decoded values
+func (m *GetFilteredLeaderboardResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LDLS", false); err != nil {
+		return err
+	} else if ok {
+		m.LDLS = make([]GetFilteredLeaderboardResponseLDLS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LDLS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LDLS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetFilteredLeaderboardResponseLDLS is an item of leaderboard rows
+type GetFilteredLeaderboardResponseLDLS struct {
+	// name of the player
+	ENAM string
+	// player id
+	ENID int64
+	// rank of the player
+	RANK int64
+	// value of the ranked stat
+	RSTA string
+	// raw stat flags
+	RWFG int64
+	// raw stats
+	RWST blaze.Tdf
+	// stat values of the row
+	STAT []string
+	// user attributes
+	UATT int64
+}
+
+// Values encodes the GetFilteredLeaderboardResponseLDLS as a list of values
+func (m *GetFilteredLeaderboardResponseLDLS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 8)
+	values = append(values, blaze.NewString("ENAM", m.ENAM))
+	values = append(values, blaze.NewInt64("ENID", m.ENID))
+	values = append(values, blaze.NewInt64("RANK", m.RANK))
+	values = append(values, blaze.NewString("RSTA", m.RSTA))
+	values = append(values, blaze.NewInt64("RWFG", m.RWFG))
+	if m.RWST != nil {
+		values = append(values, m.RWST)
+	}
+	values = append(values, blaze.NewList("STAT", m.STAT))
+	values = append(values, blaze.NewInt64("UATT", m.UATT))
+	return values
+}
+
+// Decode sets the GetFilteredLeaderboardResponseLDLS from decoded values
+func (m *GetFilteredLeaderboardResponseLDLS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ENAM", false); err != nil {
+		return err
+	} else if ok {
+		m.ENAM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ENID", false); err != nil {
+		return err
+	} else if ok {
+		m.ENID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RANK", false); err != nil {
+		return err
+	} else if ok {
+		m.RANK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "RSTA", false); err != nil {
+		return err
+	} else if ok {
+		m.RSTA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "RWFG", false); err != nil {
+		return err
+	} else if ok {
+		m.RWFG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Tdf](values, "RWST", true); err != nil {
+		return err
+	} else if ok {
+		m.RWST = v
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[string]](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Values
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UATT", false); err != nil {
+		return err
+	} else if ok {
+		m.UATT = v.Value
+	}
+	return nil
+}
+
+// GetLeaderboardEntityCountRequest is the request of Stats getLeaderboardEntityCount which counts the rows of a leaderboard
+type GetLeaderboardEntityCountRequest struct {
+	// key scope values
+	KSUM map[string]int64
+	// leaderboard name such as N7RatingGlobal
+	NAME string
+	// period offset
+	POFF *int64
+	// period time
+	TIME *int64
+	// set of users to rank
+	USET blaze.Values
+}
+
+// Values encodes the GetLeaderboardEntityCountRequest as a list of values
+func (m *GetLeaderboardEntityCountRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 5)
+	if m.KSUM != nil {
+		values = append(values, blaze.NewSortedMap("KSUM", m.KSUM))
+	}
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	if m.POFF != nil {
+		values = append(values, blaze.NewInt64("POFF", *m.POFF))
+	}
+	if m.TIME != nil {
+		values = append(values, blaze.NewInt64("TIME", *m.TIME))
+	}
+	if m.USET != nil {
+		values = append(values, blaze.NewStruct("USET", m.USET...))
+	}
+	return values
+}
+
+// Decode sets the GetLeaderboardEntityCountRequest from decoded values
+func (m *GetLeaderboardEntityCountRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, int64]](values, "KSUM", true); err != nil {
+		return err
+	} else if ok {
+		m.KSUM = make(map[string]int64, len(v.Keys))
+		for i, key := range v.Keys {
+			m.KSUM[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "POFF", true); err != nil {
+		return err
+	} else if ok {
+		m.POFF = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", true); err != nil {
+		return err
+	} else if ok {
+		m.TIME = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USET", true); err != nil {
+		return err
+	} else if ok {
+		m.USET = v.Values
+	}
+	return nil
+}
+
+// GetLeaderboardEntityCountResponse is the response of Stats getLeaderboardEntityCount which counts the rows of a leaderboard
+type GetLeaderboardEntityCountResponse struct {
+	// number of rows
+	CNT int64
+}
+
+// Values encodes the GetLeaderboardEntityCountResponse as a list of values
+func (m *GetLeaderboardEntityCountResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("CNT", m.CNT))
+	return values
+}
+
+// Decode sets the GetLeaderboardEntityCountResponse from decoded values
+func (m *GetLeaderboardEntityCountResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "CNT", false); err != nil {
+		return err
+	} else if ok {
+		m.CNT = v.Value
+	}
+	return nil
+}
+
+// StatsHandler handles the requests of the Stats component
+type StatsHandler interface {
+	// GetLeaderboard gets rows of a leaderboard by rank
+	GetLeaderboard(ctx context.Context, request *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	// GetCenteredLeaderboard gets rows of a leaderboard around a player
+	GetCenteredLeaderboard(ctx context.Context, request *GetCenteredLeaderboardRequest) (*GetCenteredLeaderboardResponse, error)
+	// GetFilteredLeaderboard gets the rows of a leaderboard for some players
+	GetFilteredLeaderboard(ctx context.Context, request *GetFilteredLeaderboardRequest) (*GetFilteredLeaderboardResponse, error)
+	// GetLeaderboardEntityCount counts the rows of a leaderboard
+	GetLeaderboardEntityCount(ctx context.Context, request *GetLeaderboardEntityCountRequest) (*GetLeaderboardEntityCountResponse, error)
+}
+
+// UnimplementedStatsHandler can be embedded in a StatsHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedStatsHandler struct{}
+
+func (UnimplementedStatsHandler) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedStatsHandler) GetCenteredLeaderboard(context.Context, *GetCenteredLeaderboardRequest) (*GetCenteredLeaderboardResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedStatsHandler) GetFilteredLeaderboard(context.Context, *GetFilteredLeaderboardRequest) (*GetFilteredLeaderboardResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedStatsHandler) GetLeaderboardEntityCount(context.Context, *GetLeaderboardEntityCountRequest) (*GetLeaderboardEntityCountResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchStats decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchStats(ctx context.Context, handler StatsHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != StatsComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case StatsGetLeaderboard:
+		request := &GetLeaderboardRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetLeaderboard(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case StatsGetCenteredLeaderboard:
+		request := &GetCenteredLeaderboardRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetCenteredLeaderboard(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case StatsGetFilteredLeaderboard:
+		request := &GetFilteredLeaderboardRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetFilteredLeaderboard(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case StatsGetLeaderboardEntityCount:
+		request := &GetLeaderboardEntityCountRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetLeaderboardEntityCount(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// Util component and the commands and notifications of it
+const (
+	UtilComponent           uint16 = 0x9
+	UtilFetchClientConfig   uint16 = 0x1
+	UtilPing                uint16 = 0x2
+	UtilSetClientData       uint16 = 0x3
+	UtilLocalizeStrings     uint16 = 0x4
+	UtilGetTelemetryServer  uint16 = 0x5
+	UtilGetTickerServer     uint16 = 0x6
+	UtilPreAuth             uint16 = 0x7
+	UtilPostAuth            uint16 = 0x8
+	UtilUserSettingsLoad    uint16 = 0xA
+	UtilUserSettingsSave    uint16 = 0xB
+	UtilUserSettingsLoadAll uint16 = 0xC
+	UtilDeleteUserSettings  uint16 = 0xE
+	UtilFilterForProfanity  uint16 = 0x14
+	UtilFetchQosConfig      uint16 = 0x15
+	UtilSetClientMetrics    uint16 = 0x16
+	UtilSetConnectionState  uint16 = 0x17
+	UtilGetPssConfig        uint16 = 0x18
+	UtilGetUserOptions      uint16 = 0x19
+	UtilSetUserOptions      uint16 = 0x1A
+	UtilSuspendUserPing     uint16 = 0x1B
+)
+
+// FetchClientConfigRequest is the request of Util fetchClientConfig which fetches a named client config
+type FetchClientConfigRequest struct {
+	// config id such as ME3_DATA
+	CFID string
+}
+
+// Values encodes the FetchClientConfigRequest as a list of values
+func (m *FetchClientConfigRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewString("CFID", m.CFID))
+	return values
+}
+
+// Decode sets the FetchClientConfigRequest from decoded values
+func (m *FetchClientConfigRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CFID", false); err != nil {
+		return err
+	} else if ok {
+		m.CFID = v.Value
+	}
+	return nil
+}
+
+// FetchClientConfigResponse is the response of Util fetchClientConfig which fetches a named client config
+type FetchClientConfigResponse struct {
+	// config values by key
+	CONF map[string]string
+}
+
+// Values encodes the FetchClientConfigResponse as a list of values
+func (m *FetchClientConfigResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewSortedMap("CONF", m.CONF))
+	return values
+}
+
+// Decode sets the FetchClientConfigResponse from decoded values
+func (m *FetchClientConfigResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "CONF", false); err != nil {
+		return err
+	} else if ok {
+		m.CONF = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.CONF[key] = v.Values[i]
+		}
+	}
+	return nil
+}
+
+// PingRequest is the request of Util ping which keeps the connection alive
+type PingRequest struct {
+}
+
+// Values encodes the PingRequest as a list of values
+func (m *PingRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the PingRequest from decoded values
+func (m *PingRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// PingResponse is the response of Util ping which keeps the connection alive
+type PingResponse struct {
+	// server time in seconds
+	STIM *int64
+}
+
+// Values encodes the PingResponse as a list of values
+func (m *PingResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	if m.STIM != nil {
+		values = append(values, blaze.NewInt64("STIM", *m.STIM))
+	}
+	return values
+}
+
+// Decode sets the PingResponse from decoded values
+func (m *PingResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STIM", true); err != nil {
+		return err
+	} else if ok {
+		m.STIM = &v.Value
+	}
+	return nil
+}
+
+// GetTelemetryServerRequest is the request of Util getTelemetryServer which finds the telemetry server
+type GetTelemetryServerRequest struct {
+}
+
+// Values encodes the GetTelemetryServerRequest as a list of values
+func (m *GetTelemetryServerRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the GetTelemetryServerRequest from decoded values
+func (m *GetTelemetryServerRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// GetTelemetryServerResponse is the response of Util getTelemetryServer which finds the telemetry server
+type GetTelemetryServerResponse struct {
+	// address of the telemetry server
+	ADRS string
+	// whether telemetry is anonymous
+	ANON int64
+	// countries telemetry is disabled in
+	DISA string
+	// filter of the telemetry sent
+	FILT string
+	// locale
+	LOC int64
+	// countries telemetry is allowed in
+	NOOK string
+	// port of the telemetry server
+	PORT int64
+	// delay in milliseconds between sends
+	SDLY int64
+	// telemetry session
+	SESS string
+	// telemetry key
+	SKEY string
+	// percentage of data sent
+	SPCT int64
+	// telemetry time
+	STIM string
+}
+
+// Values encodes the GetTelemetryServerResponse as a list of values
+func (m *GetTelemetryServerResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 12)
+	values = append(values, blaze.NewString("ADRS", m.ADRS))
+	values = append(values, blaze.NewInt64("ANON", m.ANON))
+	values = append(values, blaze.NewString("DISA", m.DISA))
+	values = append(values, blaze.NewString("FILT", m.FILT))
+	values = append(values, blaze.NewInt64("LOC", m.LOC))
+	values = append(values, blaze.NewString("NOOK", m.NOOK))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	values = append(values, blaze.NewInt64("SDLY", m.SDLY))
+	values = append(values, blaze.NewString("SESS", m.SESS))
+	values = append(values, blaze.NewString("SKEY", m.SKEY))
+	values = append(values, blaze.NewInt64("SPCT", m.SPCT))
+	values = append(values, blaze.NewString("STIM", m.STIM))
+	return values
+}
+
+// Decode sets the GetTelemetryServerResponse from decoded values
+func (m *GetTelemetryServerResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ADRS", false); err != nil {
+		return err
+	} else if ok {
+		m.ADRS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ANON", false); err != nil {
+		return err
+	} else if ok {
+		m.ANON = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DISA", false); err != nil {
+		return err
+	} else if ok {
+		m.DISA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "FILT", false); err != nil {
+		return err
+	} else if ok {
+		m.FILT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LOC", false); err != nil {
+		return err
+	} else if ok {
+		m.LOC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NOOK", false); err != nil {
+		return err
+	} else if ok {
+		m.NOOK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SDLY", false); err != nil {
+		return err
+	} else if ok {
+		m.SDLY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SESS", false); err != nil {
+		return err
+	} else if ok {
+		m.SESS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SKEY", false); err != nil {
+		return err
+	} else if ok {
+		m.SKEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SPCT", false); err != nil {
+		return err
+	} else if ok {
+		m.SPCT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "STIM", false); err != nil {
+		return err
+	} else if ok {
+		m.STIM = v.Value
+	}
+	return nil
+}
+
+// GetTickerServerRequest is the request of Util getTickerServer which finds the ticker server
+type GetTickerServerRequest struct {
+}
+
+// Values encodes the GetTickerServerRequest as a list of values
+func (m *GetTickerServerRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the GetTickerServerRequest from decoded values
+func (m *GetTickerServerRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// GetTickerServerResponse is the response of Util getTickerServer which finds the ticker server
+type GetTickerServerResponse struct {
+	// address of the ticker server
+	ADRS string
+	// port of the ticker server
+	PORT int64
+	// ticker key holding the player id and server
+	SKEY string
+}
+
+// Values encodes the GetTickerServerResponse as a list of values
+func (m *GetTickerServerResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("ADRS", m.ADRS))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	values = append(values, blaze.NewString("SKEY", m.SKEY))
+	return values
+}
+
+// Decode sets the GetTickerServerResponse from decoded values
+func (m *GetTickerServerResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ADRS", false); err != nil {
+		return err
+	} else if ok {
+		m.ADRS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SKEY", false); err != nil {
+		return err
+	} else if ok {
+		m.SKEY = v.Value
+	}
+	return nil
+}
+
+// PreAuthRequest is the request of Util preAuth which exchanges client and server details before logging in
+type PreAuthRequest struct {
+	// client data
+	CDAT PreAuthRequestCDAT
+	// client information
+	CINF PreAuthRequestCINF
+	// config fetch request
+	FCCR PreAuthRequestFCCR
+}
+
+// Values encodes the PreAuthRequest as a list of values
+func (m *PreAuthRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewStruct("CDAT", m.CDAT.Values()...))
+	values = append(values, blaze.NewStruct("CINF", m.CINF.Values()...))
+	values = append(values, blaze.NewStruct("FCCR", m.FCCR.Values()...))
+	return values
+}
+
+// Decode sets the PreAuthRequest from decoded values
+func (m *PreAuthRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "CDAT", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.CDAT.Decode(v.Values); err != nil {
+			return blaze.NestedError("CDAT", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "CINF", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.CINF.Decode(v.Values); err != nil {
+			return blaze.NestedError("CINF", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "FCCR", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.FCCR.Decode(v.Values); err != nil {
+			return blaze.NestedError("FCCR", err)
+		}
+	}
+	return nil
+}
+
+// PreAuthRequestCDAT is client data
+type PreAuthRequestCDAT struct {
+	// whether the client is in the opening
+	IITO int64
+	// language such as 0x656e for en
+	LANG int64
+	// service name
+	SVCN string
+	// client type
+	TYPE int64
+}
+
+// Values encodes the PreAuthRequestCDAT as a list of values
+func (m *PreAuthRequestCDAT) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewInt64("IITO", m.IITO))
+	values = append(values, blaze.NewInt64("LANG", m.LANG))
+	values = append(values, blaze.NewString("SVCN", m.SVCN))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the PreAuthRequestCDAT from decoded values
+func (m *PreAuthRequestCDAT) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "IITO", false); err != nil {
+		return err
+	} else if ok {
+		m.IITO = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LANG", false); err != nil {
+		return err
+	} else if ok {
+		m.LANG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SVCN", false); err != nil {
+		return err
+	} else if ok {
+		m.SVCN = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// PreAuthRequestCINF is client information
+type PreAuthRequestCINF struct {
+	// version of the Blaze SDK
+	BSDK string
+	// build time of the Blaze SDK
+	BTIM string
+	// client name
+	CLNT string
+	// client platform type
+	CPFT *int64
+	// client SKU
+	CSKU string
+	// client version
+	CVER string
+	// version of the DirtySDK
+	DSDK string
+	// environment such as prod
+	ENV string
+	// first party id
+	FPID blaze.Tdf
+	// locale such as 0x656e5553 for enUS
+	LOC int64
+	// mac address
+	MAC string
+	// platform such as Windows
+	PLAT string
+}
+
+// Values encodes the PreAuthRequestCINF as a list of values
+func (m *PreAuthRequestCINF) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 12)
+	values = append(values, blaze.NewString("BSDK", m.BSDK))
+	values = append(values, blaze.NewString("BTIM", m.BTIM))
+	values = append(values, blaze.NewString("CLNT", m.CLNT))
+	if m.CPFT != nil {
+		values = append(values, blaze.NewInt64("CPFT", *m.CPFT))
+	}
+	values = append(values, blaze.NewString("CSKU", m.CSKU))
+	values = append(values, blaze.NewString("CVER", m.CVER))
+	values = append(values, blaze.NewString("DSDK", m.DSDK))
+	values = append(values, blaze.NewString("ENV", m.ENV))
+	if m.FPID != nil {
+		values = append(values, m.FPID)
+	}
+	values = append(values, blaze.NewInt64("LOC", m.LOC))
+	values = append(values, blaze.NewString("MAC", m.MAC))
+	values = append(values, blaze.NewString("PLAT", m.PLAT))
+	return values
+}
+
+// Decode sets the PreAuthRequestCINF from decoded values
+func (m *PreAuthRequestCINF) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "BSDK", false); err != nil {
+		return err
+	} else if ok {
+		m.BSDK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "BTIM", false); err != nil {
+		return err
+	} else if ok {
+		m.BTIM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CLNT", false); err != nil {
+		return err
+	} else if ok {
+		m.CLNT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "CPFT", true); err != nil {
+		return err
+	} else if ok {
+		m.CPFT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CSKU", false); err != nil {
+		return err
+	} else if ok {
+		m.CSKU = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CVER", false); err != nil {
+		return err
+	} else if ok {
+		m.CVER = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DSDK", false); err != nil {
+		return err
+	} else if ok {
+		m.DSDK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ENV", false); err != nil {
+		return err
+	} else if ok {
+		m.ENV = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Tdf](values, "FPID", true); err != nil {
+		return err
+	} else if ok {
+		m.FPID = v
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LOC", false); err != nil {
+		return err
+	} else if ok {
+		m.LOC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "MAC", false); err != nil {
+		return err
+	} else if ok {
+		m.MAC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PLAT", false); err != nil {
+		return err
+	} else if ok {
+		m.PLAT = v.Value
+	}
+	return nil
+}
+
+// PreAuthRequestFCCR is config fetch request
+type PreAuthRequestFCCR struct {
+	// config id such as BlazeSDK
+	CFID string
+}
+
+// Values encodes the PreAuthRequestFCCR as a list of values
+func (m *PreAuthRequestFCCR) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewString("CFID", m.CFID))
+	return values
+}
+
+// Decode sets the PreAuthRequestFCCR from decoded values
+func (m *PreAuthRequestFCCR) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CFID", false); err != nil {
+		return err
+	} else if ok {
+		m.CFID = v.Value
+	}
+	return nil
+}
+
+// PreAuthResponse is the response of Util preAuth which exchanges client and server details before logging in
+type PreAuthResponse struct {
+	// whether anonymous users are allowed
+	ANON int64
+	// authentication source
+	ASRC string
+	// ids of the components the server has
+	CIDS []int64
+	// connection group name
+	CNGN string
+	// client config
+	CONF PreAuthResponseCONF
+	// server instance name
+	INST string
+	// minimum retry delay
+	MINR int64
+	// persona namespace
+	NASP string
+	// legal documents platform
+	PILD string
+	// server platform
+	PLAT string
+	// platform tag
+	PTAG string
+	// QoS config
+	QOSS PreAuthResponseQOSS
+	// registration source
+	RSRC string
+	// server version
+	SVER string
+}
+
+// Values encodes the PreAuthResponse as a list of values
+func (m *PreAuthResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 14)
+	values = append(values, blaze.NewInt64("ANON", m.ANON))
+	values = append(values, blaze.NewString("ASRC", m.ASRC))
+	values = append(values, blaze.NewVarIntList("CIDS", m.CIDS))
+	values = append(values, blaze.NewString("CNGN", m.CNGN))
+	values = append(values, blaze.NewStruct("CONF", m.CONF.Values()...))
+	values = append(values, blaze.NewString("INST", m.INST))
+	values = append(values, blaze.NewInt64("MINR", m.MINR))
+	values = append(values, blaze.NewString("NASP", m.NASP))
+	values = append(values, blaze.NewString("PILD", m.PILD))
+	values = append(values, blaze.NewString("PLAT", m.PLAT))
+	values = append(values, blaze.NewString("PTAG", m.PTAG))
+	values = append(values, blaze.NewStruct("QOSS", m.QOSS.Values()...))
+	values = append(values, blaze.NewString("RSRC", m.RSRC))
+	values = append(values, blaze.NewString("SVER", m.SVER))
+	return values
+}
+
+// Decode sets the PreAuthResponse from decoded values
+func (m *PreAuthResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ANON", false); err != nil {
+		return err
+	} else if ok {
+		m.ANON = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ASRC", false); err != nil {
+		return err
+	} else if ok {
+		m.ASRC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.VarIntListTdf](values, "CIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.CIDS = v.Values
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "CNGN", false); err != nil {
+		return err
+	} else if ok {
+		m.CNGN = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "CONF", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.CONF.Decode(v.Values); err != nil {
+			return blaze.NestedError("CONF", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "INST", false); err != nil {
+		return err
+	} else if ok {
+		m.INST = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MINR", false); err != nil {
+		return err
+	} else if ok {
+		m.MINR = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NASP", false); err != nil {
+		return err
+	} else if ok {
+		m.NASP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PILD", false); err != nil {
+		return err
+	} else if ok {
+		m.PILD = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PLAT", false); err != nil {
+		return err
+	} else if ok {
+		m.PLAT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PTAG", false); err != nil {
+		return err
+	} else if ok {
+		m.PTAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "QOSS", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.QOSS.Decode(v.Values); err != nil {
+			return blaze.NestedError("QOSS", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "RSRC", false); err != nil {
+		return err
+	} else if ok {
+		m.RSRC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SVER", false); err != nil {
+		return err
+	} else if ok {
+		m.SVER = v.Value
+	}
+	return nil
+}
+
+// PreAuthResponseCONF is client config
+type PreAuthResponseCONF struct {
+	// config values such as pingPeriod
+	CONF map[string]string
+}
+
+// Values encodes the PreAuthResponseCONF as a list of values
+func (m *PreAuthResponseCONF) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewSortedMap("CONF", m.CONF))
+	return values
+}
+
+// Decode sets the PreAuthResponseCONF from decoded values
+func (m *PreAuthResponseCONF) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "CONF", false); err != nil {
+		return err
+	} else if ok {
+		m.CONF = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.CONF[key] = v.Values[i]
+		}
+	}
+	return nil
+}
+
+// PreAuthResponseQOSS is QoS config
+type PreAuthResponseQOSS struct {
+	// bandwidth test server
+	BWPS PreAuthResponseQOSSBWPS
+	// number of latency probes
+	LNP int64
+	// latency test servers by name
+	LTPS map[string]PreAuthResponseQOSSLTPS
+	// id of the QoS service
+	SVID int64
+}
+
+// Values encodes the PreAuthResponseQOSS as a list of values
+func (m *PreAuthResponseQOSS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewStruct("BWPS", m.BWPS.Values()...))
+	values = append(values, blaze.NewInt64("LNP", m.LNP))
+	values = append(values, blaze.NewStructMap("LTPS", m.LTPS, (*PreAuthResponseQOSSLTPS).Values))
+	values = append(values, blaze.NewInt64("SVID", m.SVID))
+	return values
+}
+
+// Decode sets the PreAuthResponseQOSS from decoded values
+func (m *PreAuthResponseQOSS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "BWPS", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.BWPS.Decode(v.Values); err != nil {
+			return blaze.NestedError("BWPS", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LNP", false); err != nil {
+		return err
+	} else if ok {
+		m.LNP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, blaze.StructTdf]](values, "LTPS", false); err != nil {
+		return err
+	} else if ok {
+		m.LTPS = make(map[string]PreAuthResponseQOSSLTPS, len(v.Keys))
+		for i, key := range v.Keys {
+			var item PreAuthResponseQOSSLTPS
+			if err := item.Decode(v.Values[i].Values); err != nil {
+				return blaze.NestedItemError("LTPS", key, err)
+			}
+			m.LTPS[key] = item
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SVID", false); err != nil {
+		return err
+	} else if ok {
+		m.SVID = v.Value
+	}
+	return nil
+}
+
+// PreAuthResponseQOSSBWPS is bandwidth test server
+type PreAuthResponseQOSSBWPS struct {
+	// address of the QoS server
+	PSA string
+	// port of the QoS server
+	PSP int64
+	// name of the QoS server
+	SNA string
+}
+
+// Values encodes the PreAuthResponseQOSSBWPS as a list of values
+func (m *PreAuthResponseQOSSBWPS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("PSA", m.PSA))
+	values = append(values, blaze.NewInt64("PSP", m.PSP))
+	values = append(values, blaze.NewString("SNA", m.SNA))
+	return values
+}
+
+// Decode sets the PreAuthResponseQOSSBWPS from decoded values
+func (m *PreAuthResponseQOSSBWPS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PSA", false); err != nil {
+		return err
+	} else if ok {
+		m.PSA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSP", false); err != nil {
+		return err
+	} else if ok {
+		m.PSP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SNA", false); err != nil {
+		return err
+	} else if ok {
+		m.SNA = v.Value
+	}
+	return nil
+}
+
+// PreAuthResponseQOSSLTPS is a value of latency test servers by name
+type PreAuthResponseQOSSLTPS struct {
+	// address of the QoS server
+	PSA string
+	// port of the QoS server
+	PSP int64
+	// name of the QoS server
+	SNA string
+}
+
+// Values encodes the PreAuthResponseQOSSLTPS as a list of values
+func (m *PreAuthResponseQOSSLTPS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("PSA", m.PSA))
+	values = append(values, blaze.NewInt64("PSP", m.PSP))
+	values = append(values, blaze.NewString("SNA", m.SNA))
+	return values
+}
+
+// Decode sets the PreAuthResponseQOSSLTPS from decoded values
+func (m *PreAuthResponseQOSSLTPS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PSA", false); err != nil {
+		return err
+	} else if ok {
+		m.PSA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSP", false); err != nil {
+		return err
+	} else if ok {
+		m.PSP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SNA", false); err != nil {
+		return err
+	} else if ok {
+		m.SNA = v.Value
+	}
+	return nil
+}
+
+// PostAuthRequest is the request of Util postAuth which gives the telemetry and ticker servers after logging in
+type PostAuthRequest struct {
+}
+
+// Values encodes the PostAuthRequest as a list of values
+func (m *PostAuthRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the PostAuthRequest from decoded values
+func (m *PostAuthRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// PostAuthResponse is the response of Util postAuth which gives the telemetry and ticker servers after logging in
+type PostAuthResponse struct {
+	// telemetry server
+	TELE PostAuthResponseTELE
+	// ticker server
+	TICK PostAuthResponseTICK
+	// user options
+	UROP PostAuthResponseUROP
+}
+
+// Values encodes the PostAuthResponse as a list of values
+func (m *PostAuthResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewStruct("TELE", m.TELE.Values()...))
+	values = append(values, blaze.NewStruct("TICK", m.TICK.Values()...))
+	values = append(values, blaze.NewStruct("UROP", m.UROP.Values()...))
+	return values
+}
+
+// Decode sets the PostAuthResponse from decoded values
+func (m *PostAuthResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "TELE", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.TELE.Decode(v.Values); err != nil {
+			return blaze.NestedError("TELE", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "TICK", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.TICK.Decode(v.Values); err != nil {
+			return blaze.NestedError("TICK", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "UROP", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.UROP.Decode(v.Values); err != nil {
+			return blaze.NestedError("UROP", err)
+		}
+	}
+	return nil
+}
+
+// PostAuthResponseTELE is telemetry server
+type PostAuthResponseTELE struct {
+	// address of the telemetry server
+	ADRS string
+	// whether telemetry is anonymous
+	ANON int64
+	// countries telemetry is disabled in
+	DISA string
+	// filter of the telemetry sent
+	FILT string
+	// locale
+	LOC int64
+	// countries telemetry is allowed in
+	NOOK string
+	// port of the telemetry server
+	PORT int64
+	// delay in milliseconds between sends
+	SDLY int64
+	// telemetry session
+	SESS string
+	// telemetry key
+	SKEY string
+	// percentage of data sent
+	SPCT int64
+	// telemetry time
+	STIM string
+}
+
+// Values encodes the PostAuthResponseTELE as a list of values
+func (m *PostAuthResponseTELE) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 12)
+	values = append(values, blaze.NewString("ADRS", m.ADRS))
+	values = append(values, blaze.NewInt64("ANON", m.ANON))
+	values = append(values, blaze.NewString("DISA", m.DISA))
+	values = append(values, blaze.NewString("FILT", m.FILT))
+	values = append(values, blaze.NewInt64("LOC", m.LOC))
+	values = append(values, blaze.NewString("NOOK", m.NOOK))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	values = append(values, blaze.NewInt64("SDLY", m.SDLY))
+	values = append(values, blaze.NewString("SESS", m.SESS))
+	values = append(values, blaze.NewString("SKEY", m.SKEY))
+	values = append(values, blaze.NewInt64("SPCT", m.SPCT))
+	values = append(values, blaze.NewString("STIM", m.STIM))
+	return values
+}
+
+// Decode sets the PostAuthResponseTELE from decoded values
+func (m *PostAuthResponseTELE) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ADRS", false); err != nil {
+		return err
+	} else if ok {
+		m.ADRS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ANON", false); err != nil {
+		return err
+	} else if ok {
+		m.ANON = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DISA", false); err != nil {
+		return err
+	} else if ok {
+		m.DISA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "FILT", false); err != nil {
+		return err
+	} else if ok {
+		m.FILT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LOC", false); err != nil {
+		return err
+	} else if ok {
+		m.LOC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NOOK", false); err != nil {
+		return err
+	} else if ok {
+		m.NOOK = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SDLY", false); err != nil {
+		return err
+	} else if ok {
+		m.SDLY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SESS", false); err != nil {
+		return err
+	} else if ok {
+		m.SESS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SKEY", false); err != nil {
+		return err
+	} else if ok {
+		m.SKEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SPCT", false); err != nil {
+		return err
+	} else if ok {
+		m.SPCT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "STIM", false); err != nil {
+		return err
+	} else if ok {
+		m.STIM = v.Value
+	}
+	return nil
+}
+
+// PostAuthResponseTICK is ticker server
+type PostAuthResponseTICK struct {
+	// address of the ticker server
+	ADRS string
+	// port of the ticker server
+	PORT int64
+	// ticker key holding the player id and server
+	SKEY string
+}
+
+// Values encodes the PostAuthResponseTICK as a list of values
+func (m *PostAuthResponseTICK) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("ADRS", m.ADRS))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	values = append(values, blaze.NewString("SKEY", m.SKEY))
+	return values
+}
+
+// Decode sets the PostAuthResponseTICK from decoded values
+func (m *PostAuthResponseTICK) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "ADRS", false); err != nil {
+		return err
+	} else if ok {
+		m.ADRS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SKEY", false); err != nil {
+		return err
+	} else if ok {
+		m.SKEY = v.Value
+	}
+	return nil
+}
+
+// PostAuthResponseUROP is user options
+type PostAuthResponseUROP struct {
+	// telemetry opt in
+	TMOP int64
+	// player id
+	UID int64
+}
+
+// Values encodes the PostAuthResponseUROP as a list of values
+func (m *PostAuthResponseUROP) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("TMOP", m.TMOP))
+	values = append(values, blaze.NewInt64("UID", m.UID))
+	return values
+}
+
+// Decode sets the PostAuthResponseUROP from decoded values
+func (m *PostAuthResponseUROP) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TMOP", false); err != nil {
+		return err
+	} else if ok {
+		m.TMOP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", false); err != nil {
+		return err
+	} else if ok {
+		m.UID = v.Value
+	}
+	return nil
+}
+
+// UserSettingsLoadRequest is the request of Util userSettingsLoad which loads a user setting
+type UserSettingsLoadRequest struct {
+	// setting key
+	KEY string
+	// player id
+	UID *int64
+}
+
+// Values encodes the UserSettingsLoadRequest as a list of values
+func (m *UserSettingsLoadRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("KEY", m.KEY))
+	if m.UID != nil {
+		values = append(values, blaze.NewInt64("UID", *m.UID))
+	}
+	return values
+}
+
+// Decode sets the UserSettingsLoadRequest from decoded values
+func (m *UserSettingsLoadRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "KEY", false); err != nil {
+		return err
+	} else if ok {
+		m.KEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", true); err != nil {
+		return err
+	} else if ok {
+		m.UID = &v.Value
+	}
+	return nil
+}
+
+// UserSettingsLoadResponse is the response of Util userSettingsLoad which loads a user setting
+type UserSettingsLoadResponse struct {
+	// setting value
+	DATA string
+}
+
+// Values encodes the UserSettingsLoadResponse as a list of values
+func (m *UserSettingsLoadResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewString("DATA", m.DATA))
+	return values
+}
+
+// Decode sets the UserSettingsLoadResponse from decoded values
+func (m *UserSettingsLoadResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DATA", false); err != nil {
+		return err
+	} else if ok {
+		m.DATA = v.Value
+	}
+	return nil
+}
+
+// UserSettingsSaveRequest is the request of Util userSettingsSave which saves a user setting
+type UserSettingsSaveRequest struct {
+	// setting value
+	DATA string
+	// setting key
+	KEY string
+	// player id
+	UID *int64
+}
+
+// Values encodes the UserSettingsSaveRequest as a list of values
+func (m *UserSettingsSaveRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("DATA", m.DATA))
+	values = append(values, blaze.NewString("KEY", m.KEY))
+	if m.UID != nil {
+		values = append(values, blaze.NewInt64("UID", *m.UID))
+	}
+	return values
+}
+
+// Decode sets the UserSettingsSaveRequest from decoded values
+func (m *UserSettingsSaveRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "DATA", false); err != nil {
+		return err
+	} else if ok {
+		m.DATA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "KEY", false); err != nil {
+		return err
+	} else if ok {
+		m.KEY = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", true); err != nil {
+		return err
+	} else if ok {
+		m.UID = &v.Value
+	}
+	return nil
+}
+
+// UserSettingsSaveResponse is the response of Util userSettingsSave which saves a user setting
+type UserSettingsSaveResponse struct {
+}
+
+// Values encodes the UserSettingsSaveResponse as a list of values
+func (m *UserSettingsSaveResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the UserSettingsSaveResponse from decoded values
+func (m *UserSettingsSaveResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// UserSettingsLoadAllRequest is the request of Util userSettingsLoadAll which loads every user setting
+type UserSettingsLoadAllRequest struct {
+	// player id
+	UID *int64
+}
+
+// Values encodes the UserSettingsLoadAllRequest as a list of values
+func (m *UserSettingsLoadAllRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	if m.UID != nil {
+		values = append(values, blaze.NewInt64("UID", *m.UID))
+	}
+	return values
+}
+
+// Decode sets the UserSettingsLoadAllRequest from decoded values
+func (m *UserSettingsLoadAllRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UID", true); err != nil {
+		return err
+	} else if ok {
+		m.UID = &v.Value
+	}
+	return nil
+}
+
+// UserSettingsLoadAllResponse is the response of Util userSettingsLoadAll which loads every user setting
+type UserSettingsLoadAllResponse struct {
+	// setting values by key
+	SMAP map[string]string
+}
+
+// Values encodes the UserSettingsLoadAllResponse as a list of values
+func (m *UserSettingsLoadAllResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewSortedMap("SMAP", m.SMAP))
+	return values
+}
+
+// Decode sets the UserSettingsLoadAllResponse from decoded values
+func (m *UserSettingsLoadAllResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, string]](values, "SMAP", false); err != nil {
+		return err
+	} else if ok {
+		m.SMAP = make(map[string]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.SMAP[key] = v.Values[i]
+		}
+	}
+	return nil
+}
+
+// FetchQosConfigRequest is the request of Util fetchQosConfig which fetches the QoS config
+type FetchQosConfigRequest struct {
+}
+
+// Values encodes the FetchQosConfigRequest as a list of values
+func (m *FetchQosConfigRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the FetchQosConfigRequest from decoded values
+func (m *FetchQosConfigRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// FetchQosConfigResponse is the response of Util fetchQosConfig which fetches the QoS config
+type FetchQosConfigResponse struct {
+	// bandwidth test server
+	BWPS FetchQosConfigResponseBWPS
+	// number of latency probes
+	LNP int64
+	// latency test servers by name
+	LTPS map[string]FetchQosConfigResponseLTPS
+	// id of the QoS service
+	SVID int64
+}
+
+// Values encodes the FetchQosConfigResponse as a list of values
+func (m *FetchQosConfigResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewStruct("BWPS", m.BWPS.Values()...))
+	values = append(values, blaze.NewInt64("LNP", m.LNP))
+	values = append(values, blaze.NewStructMap("LTPS", m.LTPS, (*FetchQosConfigResponseLTPS).Values))
+	values = append(values, blaze.NewInt64("SVID", m.SVID))
+	return values
+}
+
+// Decode sets the FetchQosConfigResponse from decoded values
+func (m *FetchQosConfigResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "BWPS", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.BWPS.Decode(v.Values); err != nil {
+			return blaze.NestedError("BWPS", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LNP", false); err != nil {
+		return err
+	} else if ok {
+		m.LNP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, blaze.StructTdf]](values, "LTPS", false); err != nil {
+		return err
+	} else if ok {
+		m.LTPS = make(map[string]FetchQosConfigResponseLTPS, len(v.Keys))
+		for i, key := range v.Keys {
+			var item FetchQosConfigResponseLTPS
+			if err := item.Decode(v.Values[i].Values); err != nil {
+				return blaze.NestedItemError("LTPS", key, err)
+			}
+			m.LTPS[key] = item
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SVID", false); err != nil {
+		return err
+	} else if ok {
+		m.SVID = v.Value
+	}
+	return nil
+}
+
+// FetchQosConfigResponseBWPS is bandwidth test server
+type FetchQosConfigResponseBWPS struct {
+	// address of the QoS server
+	PSA string
+	// port of the QoS server
+	PSP int64
+	// name of the QoS server
+	SNA string
+}
+
+// Values encodes the FetchQosConfigResponseBWPS as a list of values
+func (m *FetchQosConfigResponseBWPS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("PSA", m.PSA))
+	values = append(values, blaze.NewInt64("PSP", m.PSP))
+	values = append(values, blaze.NewString("SNA", m.SNA))
+	return values
+}
+
+// Decode sets the FetchQosConfigResponseBWPS from decoded values
+func (m *FetchQosConfigResponseBWPS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PSA", false); err != nil {
+		return err
+	} else if ok {
+		m.PSA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSP", false); err != nil {
+		return err
+	} else if ok {
+		m.PSP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SNA", false); err != nil {
+		return err
+	} else if ok {
+		m.SNA = v.Value
+	}
+	return nil
+}
+
+// FetchQosConfigResponseLTPS is a value of latency test servers by name
+type FetchQosConfigResponseLTPS struct {
+	// address of the QoS server
+	PSA string
+	// port of the QoS server
+	PSP int64
+	// name of the QoS server
+	SNA string
+}
+
+// Values encodes the FetchQosConfigResponseLTPS as a list of values
+func (m *FetchQosConfigResponseLTPS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewString("PSA", m.PSA))
+	values = append(values, blaze.NewInt64("PSP", m.PSP))
+	values = append(values, blaze.NewString("SNA", m.SNA))
+	return values
+}
+
+// Decode sets the FetchQosConfigResponseLTPS from decoded values
+func (m *FetchQosConfigResponseLTPS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "PSA", false); err != nil {
+		return err
+	} else if ok {
+		m.PSA = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSP", false); err != nil {
+		return err
+	} else if ok {
+		m.PSP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "SNA", false); err != nil {
+		return err
+	} else if ok {
+		m.SNA = v.Value
+	}
+	return nil
+}
+
+// UtilHandler handles the requests of the Util component
+type UtilHandler interface {
+	// FetchClientConfig fetches a named client config
+	FetchClientConfig(ctx context.Context, request *FetchClientConfigRequest) (*FetchClientConfigResponse, error)
+	// Ping keeps the connection alive
+	Ping(ctx context.Context, request *PingRequest) (*PingResponse, error)
+	// GetTelemetryServer finds the telemetry server
+	GetTelemetryServer(ctx context.Context, request *GetTelemetryServerRequest) (*GetTelemetryServerResponse, error)
+	// GetTickerServer finds the ticker server
+	GetTickerServer(ctx context.Context, request *GetTickerServerRequest) (*GetTickerServerResponse, error)
+	// PreAuth exchanges client and server details before logging in
+	PreAuth(ctx context.Context, request *PreAuthRequest) (*PreAuthResponse, error)
+	// PostAuth gives the telemetry and ticker servers after logging in
+	PostAuth(ctx context.Context, request *PostAuthRequest) (*PostAuthResponse, error)
+	// UserSettingsLoad loads a user setting
+	UserSettingsLoad(ctx context.Context, request *UserSettingsLoadRequest) (*UserSettingsLoadResponse, error)
+	// UserSettingsSave saves a user setting
+	UserSettingsSave(ctx context.Context, request *UserSettingsSaveRequest) (*UserSettingsSaveResponse, error)
+	// UserSettingsLoadAll loads every user setting
+	UserSettingsLoadAll(ctx context.Context, request *UserSettingsLoadAllRequest) (*UserSettingsLoadAllResponse, error)
+	// FetchQosConfig fetches the QoS config
+	FetchQosConfig(ctx context.Context, request *FetchQosConfigRequest) (*FetchQosConfigResponse, error)
+}
+
+// UnimplementedUtilHandler can be embedded in a UtilHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedUtilHandler struct{}
+
+func (UnimplementedUtilHandler) FetchClientConfig(context.Context, *FetchClientConfigRequest) (*FetchClientConfigResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) GetTelemetryServer(context.Context, *GetTelemetryServerRequest) (*GetTelemetryServerResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) GetTickerServer(context.Context, *GetTickerServerRequest) (*GetTickerServerResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) PreAuth(context.Context, *PreAuthRequest) (*PreAuthResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) PostAuth(context.Context, *PostAuthRequest) (*PostAuthResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) UserSettingsLoad(context.Context, *UserSettingsLoadRequest) (*UserSettingsLoadResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) UserSettingsSave(context.Context, *UserSettingsSaveRequest) (*UserSettingsSaveResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) UserSettingsLoadAll(context.Context, *UserSettingsLoadAllRequest) (*UserSettingsLoadAllResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUtilHandler) FetchQosConfig(context.Context, *FetchQosConfigRequest) (*FetchQosConfigResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchUtil decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchUtil(ctx context.Context, handler UtilHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != UtilComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case UtilFetchClientConfig:
+		request := &FetchClientConfigRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.FetchClientConfig(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilPing:
+		request := &PingRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.Ping(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilGetTelemetryServer:
+		request := &GetTelemetryServerRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetTelemetryServer(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilGetTickerServer:
+		request := &GetTickerServerRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetTickerServer(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilPreAuth:
+		request := &PreAuthRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.PreAuth(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilPostAuth:
+		request := &PostAuthRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.PostAuth(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilUserSettingsLoad:
+		request := &UserSettingsLoadRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UserSettingsLoad(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilUserSettingsSave:
+		request := &UserSettingsSaveRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UserSettingsSave(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilUserSettingsLoadAll:
+		request := &UserSettingsLoadAllRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UserSettingsLoadAll(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UtilFetchQosConfig:
+		request := &FetchQosConfigRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.FetchQosConfig(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// Messaging component and the commands and notifications of it
+const (
+	MessagingComponent     uint16 = 0xF
+	MessagingSendMessage   uint16 = 0x1
+	MessagingNotifyMessage uint16 = 0x1
+	MessagingFetchMessages uint16 = 0x2
+	MessagingPurgeMessages uint16 = 0x3
+	MessagingTouchMessages uint16 = 0x4
+	MessagingGetMessages   uint16 = 0x5
+)
+
+// SendMessageRequest is the request of Messaging sendMessage which sends a message to a player
+type SendMessageRequest struct {
+	// message attributes such as the body
+	ATTR map[int64]string
+	// message flags
+	FLAG int64
+	// message status
+	STAT int64
+	// message tag
+	TAG int64
+	// object id of the recipient
+	TARG types.Triple
+	// message type
+	TYPE int64
+}
+
+// Values encodes the SendMessageRequest as a list of values
+func (m *SendMessageRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("FLAG", m.FLAG))
+	values = append(values, blaze.NewInt64("STAT", m.STAT))
+	values = append(values, blaze.NewInt64("TAG", m.TAG))
+	values = append(values, blaze.NewTriple("TARG", m.TARG))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the SendMessageRequest from decoded values
+func (m *SendMessageRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[int64, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[int64]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", false); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TAG", false); err != nil {
+		return err
+	} else if ok {
+		m.TAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "TARG", false); err != nil {
+		return err
+	} else if ok {
+		m.TARG = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// SendMessageResponse is the response of Messaging sendMessage which sends a message to a player
+type SendMessageResponse struct {
+	// id of the first message
+	MGID int64
+	// ids of the messages
+	MIDS []int64
+}
+
+// Values encodes the SendMessageResponse as a list of values
+func (m *SendMessageResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("MGID", m.MGID))
+	values = append(values, blaze.NewList("MIDS", m.MIDS))
+	return values
+}
+
+// Decode sets the SendMessageResponse from decoded values
+func (m *SendMessageResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MGID", false); err != nil {
+		return err
+	} else if ok {
+		m.MGID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[int64]](values, "MIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.MIDS = v.Values
+	}
+	return nil
+}
+
+// NotifyMessage is the notification of Messaging NotifyMessage which a message for the player
+type NotifyMessage struct {
+	// 0x1 when the message has been read
+	FLAG int64
+	// id of the message
+	MGID int64
+	// name of the sender
+	NAME string
+	// message payload
+	PYLD NotifyMessagePYLD
+	// object id of the sender
+	SRCE types.Triple
+	// time the message was sent
+	TIME int64
+}
+
+// Values encodes the NotifyMessage as a list of values
+func (m *NotifyMessage) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewInt64("FLAG", m.FLAG))
+	values = append(values, blaze.NewInt64("MGID", m.MGID))
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	values = append(values, blaze.NewStruct("PYLD", m.PYLD.Values()...))
+	values = append(values, blaze.NewTriple("SRCE", m.SRCE))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the NotifyMessage from decoded values
+func (m *NotifyMessage) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", false); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MGID", false); err != nil {
+		return err
+	} else if ok {
+		m.MGID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "PYLD", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.PYLD.Decode(v.Values); err != nil {
+			return blaze.NestedError("PYLD", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "SRCE", false); err != nil {
+		return err
+	} else if ok {
+		m.SRCE = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// NotifyMessagePYLD is message payload
+type NotifyMessagePYLD struct {
+	// message attributes such as the body
+	ATTR map[int64]string
+	// message flags
+	FLAG int64
+	// message status
+	STAT int64
+	// message tag
+	TAG int64
+	// object id of the recipient
+	TARG types.Triple
+	// message type
+	TYPE int64
+}
+
+// Values encodes the NotifyMessagePYLD as a list of values
+func (m *NotifyMessagePYLD) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("FLAG", m.FLAG))
+	values = append(values, blaze.NewInt64("STAT", m.STAT))
+	values = append(values, blaze.NewInt64("TAG", m.TAG))
+	values = append(values, blaze.NewTriple("TARG", m.TARG))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the NotifyMessagePYLD from decoded values
+func (m *NotifyMessagePYLD) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[int64, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[int64]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", false); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TAG", false); err != nil {
+		return err
+	} else if ok {
+		m.TAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "TARG", false); err != nil {
+		return err
+	} else if ok {
+		m.TARG = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// FetchMessagesRequest is the request of Messaging fetchMessages which delivers the matching messages as notifications
+type FetchMessagesRequest struct {
+	// message flags
+	FLAG *int64
+	// id of a single message
+	MGID *int64
+	// page index
+	PIDX *int64
+	// page size
+	PSIZ *int64
+	// status mask
+	SMSK *int64
+	// sort order
+	SORT *int64
+	// object id of the sender
+	SRCE *types.Triple
+	// message status
+	STAT *int64
+	// object id of the recipient
+	TARG *types.Triple
+	// message type
+	TYPE *int64
+}
+
+// Values encodes the FetchMessagesRequest as a list of values
+func (m *FetchMessagesRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 10)
+	if m.FLAG != nil {
+		values = append(values, blaze.NewInt64("FLAG", *m.FLAG))
+	}
+	if m.MGID != nil {
+		values = append(values, blaze.NewInt64("MGID", *m.MGID))
+	}
+	if m.PIDX != nil {
+		values = append(values, blaze.NewInt64("PIDX", *m.PIDX))
+	}
+	if m.PSIZ != nil {
+		values = append(values, blaze.NewInt64("PSIZ", *m.PSIZ))
+	}
+	if m.SMSK != nil {
+		values = append(values, blaze.NewInt64("SMSK", *m.SMSK))
+	}
+	if m.SORT != nil {
+		values = append(values, blaze.NewInt64("SORT", *m.SORT))
+	}
+	if m.SRCE != nil {
+		values = append(values, blaze.NewTriple("SRCE", *m.SRCE))
+	}
+	if m.STAT != nil {
+		values = append(values, blaze.NewInt64("STAT", *m.STAT))
+	}
+	if m.TARG != nil {
+		values = append(values, blaze.NewTriple("TARG", *m.TARG))
+	}
+	if m.TYPE != nil {
+		values = append(values, blaze.NewInt64("TYPE", *m.TYPE))
+	}
+	return values
+}
+
+// Decode sets the FetchMessagesRequest from decoded values
+func (m *FetchMessagesRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", true); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MGID", true); err != nil {
+		return err
+	} else if ok {
+		m.MGID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PIDX", true); err != nil {
+		return err
+	} else if ok {
+		m.PIDX = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSIZ", true); err != nil {
+		return err
+	} else if ok {
+		m.PSIZ = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SMSK", true); err != nil {
+		return err
+	} else if ok {
+		m.SMSK = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SORT", true); err != nil {
+		return err
+	} else if ok {
+		m.SORT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "SRCE", true); err != nil {
+		return err
+	} else if ok {
+		m.SRCE = &v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", true); err != nil {
+		return err
+	} else if ok {
+		m.STAT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "TARG", true); err != nil {
+		return err
+	} else if ok {
+		m.TARG = &v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", true); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = &v.Value
+	}
+	return nil
+}
+
+// FetchMessagesResponse is the response of Messaging fetchMessages which delivers the matching messages as notifications
+type FetchMessagesResponse struct {
+	// number of messages
+	MCNT int64
+}
+
+// Values encodes the FetchMessagesResponse as a list of values
+func (m *FetchMessagesResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("MCNT", m.MCNT))
+	return values
+}
+
+// Decode sets the FetchMessagesResponse from decoded values
+func (m *FetchMessagesResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MCNT", false); err != nil {
+		return err
+	} else if ok {
+		m.MCNT = v.Value
+	}
+	return nil
+}
+
+// PurgeMessagesRequest is the request of Messaging purgeMessages which deletes the matching messages
+type PurgeMessagesRequest struct {
+	// message flags
+	FLAG *int64
+	// id of a single message
+	MGID *int64
+	// page index
+	PIDX *int64
+	// page size
+	PSIZ *int64
+	// status mask
+	SMSK *int64
+	// sort order
+	SORT *int64
+	// object id of the sender
+	SRCE *types.Triple
+	// message status
+	STAT *int64
+	// object id of the recipient
+	TARG *types.Triple
+	// message type
+	TYPE *int64
+}
+
+// Values encodes the PurgeMessagesRequest as a list of values
+func (m *PurgeMessagesRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 10)
+	if m.FLAG != nil {
+		values = append(values, blaze.NewInt64("FLAG", *m.FLAG))
+	}
+	if m.MGID != nil {
+		values = append(values, blaze.NewInt64("MGID", *m.MGID))
+	}
+	if m.PIDX != nil {
+		values = append(values, blaze.NewInt64("PIDX", *m.PIDX))
+	}
+	if m.PSIZ != nil {
+		values = append(values, blaze.NewInt64("PSIZ", *m.PSIZ))
+	}
+	if m.SMSK != nil {
+		values = append(values, blaze.NewInt64("SMSK", *m.SMSK))
+	}
+	if m.SORT != nil {
+		values = append(values, blaze.NewInt64("SORT", *m.SORT))
+	}
+	if m.SRCE != nil {
+		values = append(values, blaze.NewTriple("SRCE", *m.SRCE))
+	}
+	if m.STAT != nil {
+		values = append(values, blaze.NewInt64("STAT", *m.STAT))
+	}
+	if m.TARG != nil {
+		values = append(values, blaze.NewTriple("TARG", *m.TARG))
+	}
+	if m.TYPE != nil {
+		values = append(values, blaze.NewInt64("TYPE", *m.TYPE))
+	}
+	return values
+}
+
+// Decode sets the PurgeMessagesRequest from decoded values
+func (m *PurgeMessagesRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", true); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MGID", true); err != nil {
+		return err
+	} else if ok {
+		m.MGID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PIDX", true); err != nil {
+		return err
+	} else if ok {
+		m.PIDX = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSIZ", true); err != nil {
+		return err
+	} else if ok {
+		m.PSIZ = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SMSK", true); err != nil {
+		return err
+	} else if ok {
+		m.SMSK = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SORT", true); err != nil {
+		return err
+	} else if ok {
+		m.SORT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "SRCE", true); err != nil {
+		return err
+	} else if ok {
+		m.SRCE = &v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", true); err != nil {
+		return err
+	} else if ok {
+		m.STAT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "TARG", true); err != nil {
+		return err
+	} else if ok {
+		m.TARG = &v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", true); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = &v.Value
+	}
+	return nil
+}
+
+// PurgeMessagesResponse is the response of Messaging purgeMessages which deletes the matching messages
+type PurgeMessagesResponse struct {
+	// number of messages
+	MCNT int64
+}
+
+// Values encodes the PurgeMessagesResponse as a list of values
+func (m *PurgeMessagesResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("MCNT", m.MCNT))
+	return values
+}
+
+// Decode sets the PurgeMessagesResponse from decoded values
+func (m *PurgeMessagesResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MCNT", false); err != nil {
+		return err
+	} else if ok {
+		m.MCNT = v.Value
+	}
+	return nil
+}
+
+// TouchMessagesRequest is the request of Messaging touchMessages which marks the matching messages as read
+type TouchMessagesRequest struct {
+	// message flags
+	FLAG *int64
+	// id of a single message
+	MGID *int64
+	// page index
+	PIDX *int64
+	// page size
+	PSIZ *int64
+	// status mask
+	SMSK *int64
+	// sort order
+	SORT *int64
+	// object id of the sender
+	SRCE *types.Triple
+	// message status
+	STAT *int64
+	// object id of the recipient
+	TARG *types.Triple
+	// message type
+	TYPE *int64
+}
+
+// Values encodes the TouchMessagesRequest as a list of values
+func (m *TouchMessagesRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 10)
+	if m.FLAG != nil {
+		values = append(values, blaze.NewInt64("FLAG", *m.FLAG))
+	}
+	if m.MGID != nil {
+		values = append(values, blaze.NewInt64("MGID", *m.MGID))
+	}
+	if m.PIDX != nil {
+		values = append(values, blaze.NewInt64("PIDX", *m.PIDX))
+	}
+	if m.PSIZ != nil {
+		values = append(values, blaze.NewInt64("PSIZ", *m.PSIZ))
+	}
+	if m.SMSK != nil {
+		values = append(values, blaze.NewInt64("SMSK", *m.SMSK))
+	}
+	if m.SORT != nil {
+		values = append(values, blaze.NewInt64("SORT", *m.SORT))
+	}
+	if m.SRCE != nil {
+		values = append(values, blaze.NewTriple("SRCE", *m.SRCE))
+	}
+	if m.STAT != nil {
+		values = append(values, blaze.NewInt64("STAT", *m.STAT))
+	}
+	if m.TARG != nil {
+		values = append(values, blaze.NewTriple("TARG", *m.TARG))
+	}
+	if m.TYPE != nil {
+		values = append(values, blaze.NewInt64("TYPE", *m.TYPE))
+	}
+	return values
+}
+
+// Decode sets the TouchMessagesRequest from decoded values
+func (m *TouchMessagesRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", true); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MGID", true); err != nil {
+		return err
+	} else if ok {
+		m.MGID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PIDX", true); err != nil {
+		return err
+	} else if ok {
+		m.PIDX = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PSIZ", true); err != nil {
+		return err
+	} else if ok {
+		m.PSIZ = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SMSK", true); err != nil {
+		return err
+	} else if ok {
+		m.SMSK = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "SORT", true); err != nil {
+		return err
+	} else if ok {
+		m.SORT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "SRCE", true); err != nil {
+		return err
+	} else if ok {
+		m.SRCE = &v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", true); err != nil {
+		return err
+	} else if ok {
+		m.STAT = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "TARG", true); err != nil {
+		return err
+	} else if ok {
+		m.TARG = &v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", true); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = &v.Value
+	}
+	return nil
+}
+
+// TouchMessagesResponse is the response of Messaging touchMessages which marks the matching messages as read
+type TouchMessagesResponse struct {
+	// number of messages
+	MCNT int64
+}
+
+// Values encodes the TouchMessagesResponse as a list of values
+func (m *TouchMessagesResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("MCNT", m.MCNT))
+	return values
+}
+
+// Decode sets the TouchMessagesResponse from decoded values
+func (m *TouchMessagesResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MCNT", false); err != nil {
+		return err
+	} else if ok {
+		m.MCNT = v.Value
+	}
+	return nil
+}
+
+// GetMessagesRequest is the request of Messaging getMessages which gets messages by id
+type GetMessagesRequest struct {
+	// ids of the messages
+	MIDS []int64
+}
+
+// Values encodes the GetMessagesRequest as a list of values
+func (m *GetMessagesRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewList("MIDS", m.MIDS))
+	return values
+}
+
+// Decode sets the GetMessagesRequest from decoded values
+func (m *GetMessagesRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[int64]](values, "MIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.MIDS = v.Values
+	}
+	return nil
+}
+
+// GetMessagesResponse is the response of Messaging getMessages which gets messages by id
+type GetMessagesResponse struct {
+	// the messages
+	MSGS []GetMessagesResponseMSGS
+}
+
+// Values encodes the GetMessagesResponse as a list of values
+func (m *GetMessagesResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("MSGS", m.MSGS, (*GetMessagesResponseMSGS).Values))
+	return values
+}
+
+// Decode sets the GetMessagesResponse from decoded values
+func (m *GetMessagesResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "MSGS", false); err != nil {
+		return err
+	} else if ok {
+		m.MSGS = make([]GetMessagesResponseMSGS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.MSGS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("MSGS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetMessagesResponseMSGS is an item of the messages
+type GetMessagesResponseMSGS struct {
+	// 0x1 when the message has been read
+	FLAG int64
+	// id of the message
+	MGID int64
+	// name of the sender
+	NAME string
+	// message payload
+	PYLD GetMessagesResponseMSGSPYLD
+	// object id of the sender
+	SRCE types.Triple
+	// time the message was sent
+	TIME int64
+}
+
+// Values encodes the GetMessagesResponseMSGS as a list of values
+func (m *GetMessagesResponseMSGS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewInt64("FLAG", m.FLAG))
+	values = append(values, blaze.NewInt64("MGID", m.MGID))
+	values = append(values, blaze.NewString("NAME", m.NAME))
+	values = append(values, blaze.NewStruct("PYLD", m.PYLD.Values()...))
+	values = append(values, blaze.NewTriple("SRCE", m.SRCE))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the GetMessagesResponseMSGS from decoded values
+func (m *GetMessagesResponseMSGS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", false); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MGID", false); err != nil {
+		return err
+	} else if ok {
+		m.MGID = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", false); err != nil {
+		return err
+	} else if ok {
+		m.NAME = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "PYLD", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.PYLD.Decode(v.Values); err != nil {
+			return blaze.NestedError("PYLD", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "SRCE", false); err != nil {
+		return err
+	} else if ok {
+		m.SRCE = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// GetMessagesResponseMSGSPYLD is message payload
+type GetMessagesResponseMSGSPYLD struct {
+	// message attributes such as the body
+	ATTR map[int64]string
+	// message flags
+	FLAG int64
+	// message status
+	STAT int64
+	// message tag
+	TAG int64
+	// object id of the recipient
+	TARG types.Triple
+	// message type
+	TYPE int64
+}
+
+// Values encodes the GetMessagesResponseMSGSPYLD as a list of values
+func (m *GetMessagesResponseMSGSPYLD) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 6)
+	values = append(values, blaze.NewSortedMap("ATTR", m.ATTR))
+	values = append(values, blaze.NewInt64("FLAG", m.FLAG))
+	values = append(values, blaze.NewInt64("STAT", m.STAT))
+	values = append(values, blaze.NewInt64("TAG", m.TAG))
+	values = append(values, blaze.NewTriple("TARG", m.TARG))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the GetMessagesResponseMSGSPYLD from decoded values
+func (m *GetMessagesResponseMSGSPYLD) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Map[int64, string]](values, "ATTR", false); err != nil {
+		return err
+	} else if ok {
+		m.ATTR = make(map[int64]string, len(v.Keys))
+		for i, key := range v.Keys {
+			m.ATTR[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLAG", false); err != nil {
+		return err
+	} else if ok {
+		m.FLAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "STAT", false); err != nil {
+		return err
+	} else if ok {
+		m.STAT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TAG", false); err != nil {
+		return err
+	} else if ok {
+		m.TAG = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "TARG", false); err != nil {
+		return err
+	} else if ok {
+		m.TARG = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// MessagingHandler handles the requests of the Messaging component
+type MessagingHandler interface {
+	// SendMessage sends a message to a player
+	SendMessage(ctx context.Context, request *SendMessageRequest) (*SendMessageResponse, error)
+	// FetchMessages delivers the matching messages as notifications
+	FetchMessages(ctx context.Context, request *FetchMessagesRequest) (*FetchMessagesResponse, error)
+	// PurgeMessages deletes the matching messages
+	PurgeMessages(ctx context.Context, request *PurgeMessagesRequest) (*PurgeMessagesResponse, error)
+	// TouchMessages marks the matching messages as read
+	TouchMessages(ctx context.Context, request *TouchMessagesRequest) (*TouchMessagesResponse, error)
+	// GetMessages gets messages by id
+	GetMessages(ctx context.Context, request *GetMessagesRequest) (*GetMessagesResponse, error)
+}
+
+// UnimplementedMessagingHandler can be embedded in a MessagingHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedMessagingHandler struct{}
+
+func (UnimplementedMessagingHandler) SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedMessagingHandler) FetchMessages(context.Context, *FetchMessagesRequest) (*FetchMessagesResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedMessagingHandler) PurgeMessages(context.Context, *PurgeMessagesRequest) (*PurgeMessagesResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedMessagingHandler) TouchMessages(context.Context, *TouchMessagesRequest) (*TouchMessagesResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedMessagingHandler) GetMessages(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchMessaging decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchMessaging(ctx context.Context, handler MessagingHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != MessagingComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case MessagingSendMessage:
+		request := &SendMessageRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SendMessage(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case MessagingFetchMessages:
+		request := &FetchMessagesRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.FetchMessages(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case MessagingPurgeMessages:
+		request := &PurgeMessagesRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.PurgeMessages(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case MessagingTouchMessages:
+		request := &TouchMessagesRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.TouchMessages(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case MessagingGetMessages:
+		request := &GetMessagesRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetMessages(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// AssociationLists component and the commands and notifications of it
+const (
+	AssociationListsComponent                  uint16 = 0x19
+	AssociationListsAddUsersToList             uint16 = 0x1
+	AssociationListsNotifyUpdateListMembership uint16 = 0x1
+	AssociationListsRemoveUsersFromList        uint16 = 0x2
+	AssociationListsClearLists                 uint16 = 0x3
+	AssociationListsSetUsersToList             uint16 = 0x4
+	AssociationListsGetListForUser             uint16 = 0x5
+	AssociationListsGetLists                   uint16 = 0x6
+	AssociationListsSubscribeToLists           uint16 = 0x7
+	AssociationListsUnsubscribeFromLists       uint16 = 0x8
+	AssociationListsGetConfigListsInfo         uint16 = 0x9
+)
+
+// AddUsersToListRequest is the request of AssociationLists addUsersToList which adds users to a list
+type AddUsersToListRequest struct {
+	// list identification
+	LID AddUsersToListRequestLID
+	// users to change
+	ULST []AddUsersToListRequestULST
+}
+
+// Values encodes the AddUsersToListRequest as a list of values
+func (m *AddUsersToListRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewStructList("ULST", m.ULST, (*AddUsersToListRequestULST).Values))
+	return values
+}
+
+// Decode sets the AddUsersToListRequest from decoded values
+func (m *AddUsersToListRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "ULST", false); err != nil {
+		return err
+	} else if ok {
+		m.ULST = make([]AddUsersToListRequestULST, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.ULST[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("ULST", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AddUsersToListRequestLID is list identification
+type AddUsersToListRequestLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the AddUsersToListRequestLID as a list of values
+func (m *AddUsersToListRequestLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the AddUsersToListRequestLID from decoded values
+func (m *AddUsersToListRequestLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// AddUsersToListRequestULST is an item of users to change
+type AddUsersToListRequestULST struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the AddUsersToListRequestULST as a list of values
+func (m *AddUsersToListRequestULST) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the AddUsersToListRequestULST from decoded values
+func (m *AddUsersToListRequestULST) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// AddUsersToListResponse is the response of AssociationLists addUsersToList which adds users to a list
+type AddUsersToListResponse struct {
+	// the members changed
+	LMID []AddUsersToListResponseLMID
+}
+
+// Values encodes the AddUsersToListResponse as a list of values
+func (m *AddUsersToListResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LMID", m.LMID, (*AddUsersToListResponseLMID).Values))
+	return values
+}
+
+// Decode sets the AddUsersToListResponse from decoded values
+func (m *AddUsersToListResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		m.LMID = make([]AddUsersToListResponseLMID, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LMID[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LMID", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AddUsersToListResponseLMID is an item of the members changed
+type AddUsersToListResponseLMID struct {
+	// list member
+	LMID AddUsersToListResponseLMIDLMID
+	// time the member was added
+	TIME int64
+}
+
+// Values encodes the AddUsersToListResponseLMID as a list of values
+func (m *AddUsersToListResponseLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LMID", m.LMID.Values()...))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the AddUsersToListResponseLMID from decoded values
+func (m *AddUsersToListResponseLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// AddUsersToListResponseLMIDLMID is list member
+type AddUsersToListResponseLMIDLMID struct {
+	// the member
+	USER AddUsersToListResponseLMIDLMIDUSER
+}
+
+// Values encodes the AddUsersToListResponseLMIDLMID as a list of values
+func (m *AddUsersToListResponseLMIDLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the AddUsersToListResponseLMIDLMID from decoded values
+func (m *AddUsersToListResponseLMIDLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// AddUsersToListResponseLMIDLMIDUSER is the member
+type AddUsersToListResponseLMIDLMIDUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the AddUsersToListResponseLMIDLMIDUSER as a list of values
+func (m *AddUsersToListResponseLMIDLMIDUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the AddUsersToListResponseLMIDLMIDUSER from decoded values
+func (m *AddUsersToListResponseLMIDLMIDUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// NotifyUpdateListMembership is the notification of AssociationLists NotifyUpdateListMembership which the members of a subscribed list changed
+type NotifyUpdateListMembership struct {
+	// list identification
+	LID NotifyUpdateListMembershipLID
+	// the member
+	MEMB NotifyUpdateListMembershipMEMB
+	// 0 when added and 1 when removed
+	OPER int64
+}
+
+// Values encodes the NotifyUpdateListMembership as a list of values
+func (m *NotifyUpdateListMembership) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewStruct("MEMB", m.MEMB.Values()...))
+	values = append(values, blaze.NewInt64("OPER", m.OPER))
+	return values
+}
+
+// Decode sets the NotifyUpdateListMembership from decoded values
+func (m *NotifyUpdateListMembership) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "MEMB", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.MEMB.Decode(v.Values); err != nil {
+			return blaze.NestedError("MEMB", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "OPER", false); err != nil {
+		return err
+	} else if ok {
+		m.OPER = v.Value
+	}
+	return nil
+}
+
+// NotifyUpdateListMembershipLID is list identification
+type NotifyUpdateListMembershipLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the NotifyUpdateListMembershipLID as a list of values
+func (m *NotifyUpdateListMembershipLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the NotifyUpdateListMembershipLID from decoded values
+func (m *NotifyUpdateListMembershipLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// NotifyUpdateListMembershipMEMB is the member
+type NotifyUpdateListMembershipMEMB struct {
+	// list member
+	LMID NotifyUpdateListMembershipMEMBLMID
+	// time the member was added
+	TIME int64
+}
+
+// Values encodes the NotifyUpdateListMembershipMEMB as a list of values
+func (m *NotifyUpdateListMembershipMEMB) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LMID", m.LMID.Values()...))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the NotifyUpdateListMembershipMEMB from decoded values
+func (m *NotifyUpdateListMembershipMEMB) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// NotifyUpdateListMembershipMEMBLMID is list member
+type NotifyUpdateListMembershipMEMBLMID struct {
+	// the member
+	USER NotifyUpdateListMembershipMEMBLMIDUSER
+}
+
+// Values encodes the NotifyUpdateListMembershipMEMBLMID as a list of values
+func (m *NotifyUpdateListMembershipMEMBLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the NotifyUpdateListMembershipMEMBLMID from decoded values
+func (m *NotifyUpdateListMembershipMEMBLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// NotifyUpdateListMembershipMEMBLMIDUSER is the member
+type NotifyUpdateListMembershipMEMBLMIDUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the NotifyUpdateListMembershipMEMBLMIDUSER as a list of values
+func (m *NotifyUpdateListMembershipMEMBLMIDUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the NotifyUpdateListMembershipMEMBLMIDUSER from decoded values
+func (m *NotifyUpdateListMembershipMEMBLMIDUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// RemoveUsersFromListRequest is the request of AssociationLists removeUsersFromList which removes users from a list
+type RemoveUsersFromListRequest struct {
+	// list identification
+	LID RemoveUsersFromListRequestLID
+	// users to change
+	ULST []RemoveUsersFromListRequestULST
+}
+
+// Values encodes the RemoveUsersFromListRequest as a list of values
+func (m *RemoveUsersFromListRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewStructList("ULST", m.ULST, (*RemoveUsersFromListRequestULST).Values))
+	return values
+}
+
+// Decode sets the RemoveUsersFromListRequest from decoded values
+func (m *RemoveUsersFromListRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "ULST", false); err != nil {
+		return err
+	} else if ok {
+		m.ULST = make([]RemoveUsersFromListRequestULST, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.ULST[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("ULST", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveUsersFromListRequestLID is list identification
+type RemoveUsersFromListRequestLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the RemoveUsersFromListRequestLID as a list of values
+func (m *RemoveUsersFromListRequestLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the RemoveUsersFromListRequestLID from decoded values
+func (m *RemoveUsersFromListRequestLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// RemoveUsersFromListRequestULST is an item of users to change
+type RemoveUsersFromListRequestULST struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the RemoveUsersFromListRequestULST as a list of values
+func (m *RemoveUsersFromListRequestULST) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the RemoveUsersFromListRequestULST from decoded values
+func (m *RemoveUsersFromListRequestULST) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// RemoveUsersFromListResponse is the response of AssociationLists removeUsersFromList which removes users from a list
+type RemoveUsersFromListResponse struct {
+	// the members changed
+	LMID []RemoveUsersFromListResponseLMID
+}
+
+// Values encodes the RemoveUsersFromListResponse as a list of values
+func (m *RemoveUsersFromListResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LMID", m.LMID, (*RemoveUsersFromListResponseLMID).Values))
+	return values
+}
+
+// Decode sets the RemoveUsersFromListResponse from decoded values
+func (m *RemoveUsersFromListResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		m.LMID = make([]RemoveUsersFromListResponseLMID, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LMID[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LMID", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveUsersFromListResponseLMID is an item of the members changed
+type RemoveUsersFromListResponseLMID struct {
+	// list member
+	LMID RemoveUsersFromListResponseLMIDLMID
+	// time the member was added
+	TIME int64
+}
+
+// Values encodes the RemoveUsersFromListResponseLMID as a list of values
+func (m *RemoveUsersFromListResponseLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LMID", m.LMID.Values()...))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the RemoveUsersFromListResponseLMID from decoded values
+func (m *RemoveUsersFromListResponseLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// RemoveUsersFromListResponseLMIDLMID is list member
+type RemoveUsersFromListResponseLMIDLMID struct {
+	// the member
+	USER RemoveUsersFromListResponseLMIDLMIDUSER
+}
+
+// Values encodes the RemoveUsersFromListResponseLMIDLMID as a list of values
+func (m *RemoveUsersFromListResponseLMIDLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the RemoveUsersFromListResponseLMIDLMID from decoded values
+func (m *RemoveUsersFromListResponseLMIDLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// RemoveUsersFromListResponseLMIDLMIDUSER is the member
+type RemoveUsersFromListResponseLMIDLMIDUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the RemoveUsersFromListResponseLMIDLMIDUSER as a list of values
+func (m *RemoveUsersFromListResponseLMIDLMIDUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the RemoveUsersFromListResponseLMIDLMIDUSER from decoded values
+func (m *RemoveUsersFromListResponseLMIDLMIDUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// ClearListsRequest is the request of AssociationLists clearLists which removes every user from the lists
+type ClearListsRequest struct {
+	// list identifications
+	LIDS []ClearListsRequestLIDS
+}
+
+// Values encodes the ClearListsRequest as a list of values
+func (m *ClearListsRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LIDS", m.LIDS, (*ClearListsRequestLIDS).Values))
+	return values
+}
+
+// Decode sets the ClearListsRequest from decoded values
+func (m *ClearListsRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.LIDS = make([]ClearListsRequestLIDS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LIDS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LIDS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ClearListsRequestLIDS is an item of list identifications
+type ClearListsRequestLIDS struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the ClearListsRequestLIDS as a list of values
+func (m *ClearListsRequestLIDS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the ClearListsRequestLIDS from decoded values
+func (m *ClearListsRequestLIDS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// ClearListsResponse is the response of AssociationLists clearLists which removes every user from the lists
+type ClearListsResponse struct {
+}
+
+// Values encodes the ClearListsResponse as a list of values
+func (m *ClearListsResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the ClearListsResponse from decoded values
+func (m *ClearListsResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// SetUsersToListRequest is the request of AssociationLists setUsersToList which replaces the users of a list
+type SetUsersToListRequest struct {
+	// list identification
+	LID SetUsersToListRequestLID
+	// users to change
+	ULST []SetUsersToListRequestULST
+}
+
+// Values encodes the SetUsersToListRequest as a list of values
+func (m *SetUsersToListRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewStructList("ULST", m.ULST, (*SetUsersToListRequestULST).Values))
+	return values
+}
+
+// Decode sets the SetUsersToListRequest from decoded values
+func (m *SetUsersToListRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "ULST", false); err != nil {
+		return err
+	} else if ok {
+		m.ULST = make([]SetUsersToListRequestULST, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.ULST[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("ULST", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SetUsersToListRequestLID is list identification
+type SetUsersToListRequestLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the SetUsersToListRequestLID as a list of values
+func (m *SetUsersToListRequestLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the SetUsersToListRequestLID from decoded values
+func (m *SetUsersToListRequestLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// SetUsersToListRequestULST is an item of users to change
+type SetUsersToListRequestULST struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the SetUsersToListRequestULST as a list of values
+func (m *SetUsersToListRequestULST) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the SetUsersToListRequestULST from decoded values
+func (m *SetUsersToListRequestULST) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// SetUsersToListResponse is the response of AssociationLists setUsersToList which replaces the users of a list
+type SetUsersToListResponse struct {
+	// the members changed
+	LMID []SetUsersToListResponseLMID
+}
+
+// Values encodes the SetUsersToListResponse as a list of values
+func (m *SetUsersToListResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LMID", m.LMID, (*SetUsersToListResponseLMID).Values))
+	return values
+}
+
+// Decode sets the SetUsersToListResponse from decoded values
+func (m *SetUsersToListResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		m.LMID = make([]SetUsersToListResponseLMID, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LMID[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LMID", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SetUsersToListResponseLMID is an item of the members changed
+type SetUsersToListResponseLMID struct {
+	// list member
+	LMID SetUsersToListResponseLMIDLMID
+	// time the member was added
+	TIME int64
+}
+
+// Values encodes the SetUsersToListResponseLMID as a list of values
+func (m *SetUsersToListResponseLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LMID", m.LMID.Values()...))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the SetUsersToListResponseLMID from decoded values
+func (m *SetUsersToListResponseLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// SetUsersToListResponseLMIDLMID is list member
+type SetUsersToListResponseLMIDLMID struct {
+	// the member
+	USER SetUsersToListResponseLMIDLMIDUSER
+}
+
+// Values encodes the SetUsersToListResponseLMIDLMID as a list of values
+func (m *SetUsersToListResponseLMIDLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the SetUsersToListResponseLMIDLMID from decoded values
+func (m *SetUsersToListResponseLMIDLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// SetUsersToListResponseLMIDLMIDUSER is the member
+type SetUsersToListResponseLMIDLMIDUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the SetUsersToListResponseLMIDLMIDUSER as a list of values
+func (m *SetUsersToListResponseLMIDLMIDUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the SetUsersToListResponseLMIDLMIDUSER from decoded values
+func (m *SetUsersToListResponseLMIDLMIDUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// GetListForUserRequest is the request of AssociationLists getListForUser which gets a list of a player
+type GetListForUserRequest struct {
+	// id of the list owner, zero for the player
+	BID *int64
+	// list identification
+	LID GetListForUserRequestLID
+}
+
+// Values encodes the GetListForUserRequest as a list of values
+func (m *GetListForUserRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.BID != nil {
+		values = append(values, blaze.NewInt64("BID", *m.BID))
+	}
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	return values
+}
+
+// Decode sets the GetListForUserRequest from decoded values
+func (m *GetListForUserRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "BID", true); err != nil {
+		return err
+	} else if ok {
+		m.BID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	return nil
+}
+
+// GetListForUserRequestLID is list identification
+type GetListForUserRequestLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the GetListForUserRequestLID as a list of values
+func (m *GetListForUserRequestLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the GetListForUserRequestLID from decoded values
+func (m *GetListForUserRequestLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// GetListForUserResponse is the response of AssociationLists getListForUser which gets a list of a player
+type GetListForUserResponse struct {
+	// the list and its members
+	LMEM GetListForUserResponseLMEM
+}
+
+// Values encodes the GetListForUserResponse as a list of values
+func (m *GetListForUserResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("LMEM", m.LMEM.Values()...))
+	return values
+}
+
+// Decode sets the GetListForUserResponse from decoded values
+func (m *GetListForUserResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMEM", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMEM.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMEM", err)
+		}
+	}
+	return nil
+}
+
+// GetListForUserResponseLMEM is the list and its members
+type GetListForUserResponseLMEM struct {
+	// list information
+	INFO GetListForUserResponseLMEMINFO
+	// list members
+	MEML []GetListForUserResponseLMEMMEML
+	// offset of the first member
+	OFRC int64
+	// total number of members
+	TOCT int64
+}
+
+// Values encodes the GetListForUserResponseLMEM as a list of values
+func (m *GetListForUserResponseLMEM) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewStruct("INFO", m.INFO.Values()...))
+	values = append(values, blaze.NewStructList("MEML", m.MEML, (*GetListForUserResponseLMEMMEML).Values))
+	values = append(values, blaze.NewInt64("OFRC", m.OFRC))
+	values = append(values, blaze.NewInt64("TOCT", m.TOCT))
+	return values
+}
+
+// Decode sets the GetListForUserResponseLMEM from decoded values
+func (m *GetListForUserResponseLMEM) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "INFO", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.INFO.Decode(v.Values); err != nil {
+			return blaze.NestedError("INFO", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "MEML", false); err != nil {
+		return err
+	} else if ok {
+		m.MEML = make([]GetListForUserResponseLMEMMEML, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.MEML[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("MEML", i, err)
+			}
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "OFRC", false); err != nil {
+		return err
+	} else if ok {
+		m.OFRC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TOCT", false); err != nil {
+		return err
+	} else if ok {
+		m.TOCT = v.Value
+	}
+	return nil
+}
+
+// GetListForUserResponseLMEMINFO is list information
+type GetListForUserResponseLMEMINFO struct {
+	// object id of the list owner
+	BOID types.Triple
+	// list flags
+	FLGS int64
+	// list identification
+	LID GetListForUserResponseLMEMINFOLID
+	// maximum list size
+	LMS int64
+	// id of the paired list
+	PRID int64
+}
+
+// Values encodes the GetListForUserResponseLMEMINFO as a list of values
+func (m *GetListForUserResponseLMEMINFO) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 5)
+	values = append(values, blaze.NewTriple("BOID", m.BOID))
+	values = append(values, blaze.NewInt64("FLGS", m.FLGS))
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewInt64("LMS", m.LMS))
+	values = append(values, blaze.NewInt64("PRID", m.PRID))
+	return values
+}
+
+// Decode sets the GetListForUserResponseLMEMINFO from decoded values
+func (m *GetListForUserResponseLMEMINFO) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "BOID", false); err != nil {
+		return err
+	} else if ok {
+		m.BOID = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLGS", false); err != nil {
+		return err
+	} else if ok {
+		m.FLGS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LMS", false); err != nil {
+		return err
+	} else if ok {
+		m.LMS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PRID", false); err != nil {
+		return err
+	} else if ok {
+		m.PRID = v.Value
+	}
+	return nil
+}
+
+// GetListForUserResponseLMEMMEML is an item of list members
+type GetListForUserResponseLMEMMEML struct {
+	// list member
+	LMID GetListForUserResponseLMEMMEMLLMID
+	// time the member was added
+	TIME int64
+}
+
+// Values encodes the GetListForUserResponseLMEMMEML as a list of values
+func (m *GetListForUserResponseLMEMMEML) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LMID", m.LMID.Values()...))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the GetListForUserResponseLMEMMEML from decoded values
+func (m *GetListForUserResponseLMEMMEML) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// GetListForUserResponseLMEMINFOLID is list identification
+type GetListForUserResponseLMEMINFOLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the GetListForUserResponseLMEMINFOLID as a list of values
+func (m *GetListForUserResponseLMEMINFOLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the GetListForUserResponseLMEMINFOLID from decoded values
+func (m *GetListForUserResponseLMEMINFOLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// GetListForUserResponseLMEMMEMLLMID is list member
+type GetListForUserResponseLMEMMEMLLMID struct {
+	// the member
+	USER GetListForUserResponseLMEMMEMLLMIDUSER
+}
+
+// Values encodes the GetListForUserResponseLMEMMEMLLMID as a list of values
+func (m *GetListForUserResponseLMEMMEMLLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the GetListForUserResponseLMEMMEMLLMID from decoded values
+func (m *GetListForUserResponseLMEMMEMLLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// GetListForUserResponseLMEMMEMLLMIDUSER is the member
+type GetListForUserResponseLMEMMEMLLMIDUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the GetListForUserResponseLMEMMEMLLMIDUSER as a list of values
+func (m *GetListForUserResponseLMEMMEMLLMIDUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the GetListForUserResponseLMEMMEMLLMIDUSER from decoded values
+func (m *GetListForUserResponseLMEMMEMLLMIDUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// GetListsRequest is the request of AssociationLists getLists which gets the lists of the player
+type GetListsRequest struct {
+	// list identifications
+	LIDS []GetListsRequestLIDS
+	// maximum number of members
+	MXRC *int64
+	// offset of the first member
+	OFRC *int64
+}
+
+// Values encodes the GetListsRequest as a list of values
+func (m *GetListsRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewStructList("LIDS", m.LIDS, (*GetListsRequestLIDS).Values))
+	if m.MXRC != nil {
+		values = append(values, blaze.NewInt64("MXRC", *m.MXRC))
+	}
+	if m.OFRC != nil {
+		values = append(values, blaze.NewInt64("OFRC", *m.OFRC))
+	}
+	return values
+}
+
+// Decode sets the GetListsRequest from decoded values
+func (m *GetListsRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.LIDS = make([]GetListsRequestLIDS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LIDS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LIDS", i, err)
+			}
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "MXRC", true); err != nil {
+		return err
+	} else if ok {
+		m.MXRC = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "OFRC", true); err != nil {
+		return err
+	} else if ok {
+		m.OFRC = &v.Value
+	}
+	return nil
+}
+
+// GetListsRequestLIDS is an item of list identifications
+type GetListsRequestLIDS struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the GetListsRequestLIDS as a list of values
+func (m *GetListsRequestLIDS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the GetListsRequestLIDS from decoded values
+func (m *GetListsRequestLIDS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// GetListsResponse is the response of AssociationLists getLists which gets the lists of the player
+type GetListsResponse struct {
+	// the lists and their members
+	LMAP []GetListsResponseLMAP
+}
+
+// Values encodes the GetListsResponse as a list of values
+func (m *GetListsResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LMAP", m.LMAP, (*GetListsResponseLMAP).Values))
+	return values
+}
+
+// Decode sets the GetListsResponse from decoded values
+func (m *GetListsResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LMAP", false); err != nil {
+		return err
+	} else if ok {
+		m.LMAP = make([]GetListsResponseLMAP, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LMAP[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LMAP", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetListsResponseLMAP is an item of the lists and their members
+type GetListsResponseLMAP struct {
+	// list information
+	INFO GetListsResponseLMAPINFO
+	// list members
+	MEML []GetListsResponseLMAPMEML
+	// offset of the first member
+	OFRC int64
+	// total number of members
+	TOCT int64
+}
+
+// Values encodes the GetListsResponseLMAP as a list of values
+func (m *GetListsResponseLMAP) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 4)
+	values = append(values, blaze.NewStruct("INFO", m.INFO.Values()...))
+	values = append(values, blaze.NewStructList("MEML", m.MEML, (*GetListsResponseLMAPMEML).Values))
+	values = append(values, blaze.NewInt64("OFRC", m.OFRC))
+	values = append(values, blaze.NewInt64("TOCT", m.TOCT))
+	return values
+}
+
+// Decode sets the GetListsResponseLMAP from decoded values
+func (m *GetListsResponseLMAP) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "INFO", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.INFO.Decode(v.Values); err != nil {
+			return blaze.NestedError("INFO", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "MEML", false); err != nil {
+		return err
+	} else if ok {
+		m.MEML = make([]GetListsResponseLMAPMEML, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.MEML[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("MEML", i, err)
+			}
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "OFRC", false); err != nil {
+		return err
+	} else if ok {
+		m.OFRC = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TOCT", false); err != nil {
+		return err
+	} else if ok {
+		m.TOCT = v.Value
+	}
+	return nil
+}
+
+// GetListsResponseLMAPINFO is list information
+type GetListsResponseLMAPINFO struct {
+	// object id of the list owner
+	BOID types.Triple
+	// list flags
+	FLGS int64
+	// list identification
+	LID GetListsResponseLMAPINFOLID
+	// maximum list size
+	LMS int64
+	// id of the paired list
+	PRID int64
+}
+
+// Values encodes the GetListsResponseLMAPINFO as a list of values
+func (m *GetListsResponseLMAPINFO) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 5)
+	values = append(values, blaze.NewTriple("BOID", m.BOID))
+	values = append(values, blaze.NewInt64("FLGS", m.FLGS))
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewInt64("LMS", m.LMS))
+	values = append(values, blaze.NewInt64("PRID", m.PRID))
+	return values
+}
+
+// Decode sets the GetListsResponseLMAPINFO from decoded values
+func (m *GetListsResponseLMAPINFO) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "BOID", false); err != nil {
+		return err
+	} else if ok {
+		m.BOID = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLGS", false); err != nil {
+		return err
+	} else if ok {
+		m.FLGS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LMS", false); err != nil {
+		return err
+	} else if ok {
+		m.LMS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PRID", false); err != nil {
+		return err
+	} else if ok {
+		m.PRID = v.Value
+	}
+	return nil
+}
+
+// GetListsResponseLMAPMEML is an item of list members
+type GetListsResponseLMAPMEML struct {
+	// list member
+	LMID GetListsResponseLMAPMEMLLMID
+	// time the member was added
+	TIME int64
+}
+
+// Values encodes the GetListsResponseLMAPMEML as a list of values
+func (m *GetListsResponseLMAPMEML) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("LMID", m.LMID.Values()...))
+	values = append(values, blaze.NewInt64("TIME", m.TIME))
+	return values
+}
+
+// Decode sets the GetListsResponseLMAPMEML from decoded values
+func (m *GetListsResponseLMAPMEML) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LMID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LMID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LMID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TIME", false); err != nil {
+		return err
+	} else if ok {
+		m.TIME = v.Value
+	}
+	return nil
+}
+
+// GetListsResponseLMAPINFOLID is list identification
+type GetListsResponseLMAPINFOLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the GetListsResponseLMAPINFOLID as a list of values
+func (m *GetListsResponseLMAPINFOLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the GetListsResponseLMAPINFOLID from decoded values
+func (m *GetListsResponseLMAPINFOLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// GetListsResponseLMAPMEMLLMID is list member
+type GetListsResponseLMAPMEMLLMID struct {
+	// the member
+	USER GetListsResponseLMAPMEMLLMIDUSER
+}
+
+// Values encodes the GetListsResponseLMAPMEMLLMID as a list of values
+func (m *GetListsResponseLMAPMEMLLMID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the GetListsResponseLMAPMEMLLMID from decoded values
+func (m *GetListsResponseLMAPMEMLLMID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// GetListsResponseLMAPMEMLLMIDUSER is the member
+type GetListsResponseLMAPMEMLLMIDUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the GetListsResponseLMAPMEMLLMIDUSER as a list of values
+func (m *GetListsResponseLMAPMEMLLMIDUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the GetListsResponseLMAPMEMLLMIDUSER from decoded values
+func (m *GetListsResponseLMAPMEMLLMIDUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// SubscribeToListsRequest is the request of AssociationLists subscribeToLists which subscribes to changes of the lists
+type SubscribeToListsRequest struct {
+	// list identifications
+	LIDS []SubscribeToListsRequestLIDS
+}
+
+// Values encodes the SubscribeToListsRequest as a list of values
+func (m *SubscribeToListsRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LIDS", m.LIDS, (*SubscribeToListsRequestLIDS).Values))
+	return values
+}
+
+// Decode sets the SubscribeToListsRequest from decoded values
+func (m *SubscribeToListsRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.LIDS = make([]SubscribeToListsRequestLIDS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LIDS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LIDS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SubscribeToListsRequestLIDS is an item of list identifications
+type SubscribeToListsRequestLIDS struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the SubscribeToListsRequestLIDS as a list of values
+func (m *SubscribeToListsRequestLIDS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the SubscribeToListsRequestLIDS from decoded values
+func (m *SubscribeToListsRequestLIDS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// SubscribeToListsResponse is the response of AssociationLists subscribeToLists which subscribes to changes of the lists
+type SubscribeToListsResponse struct {
+}
+
+// Values encodes the SubscribeToListsResponse as a list of values
+func (m *SubscribeToListsResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the SubscribeToListsResponse from decoded values
+func (m *SubscribeToListsResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// UnsubscribeFromListsRequest is the request of AssociationLists unsubscribeFromLists which unsubscribes from changes of the lists
+type UnsubscribeFromListsRequest struct {
+	// list identifications
+	LIDS []UnsubscribeFromListsRequestLIDS
+}
+
+// Values encodes the UnsubscribeFromListsRequest as a list of values
+func (m *UnsubscribeFromListsRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("LIDS", m.LIDS, (*UnsubscribeFromListsRequestLIDS).Values))
+	return values
+}
+
+// Decode sets the UnsubscribeFromListsRequest from decoded values
+func (m *UnsubscribeFromListsRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "LIDS", false); err != nil {
+		return err
+	} else if ok {
+		m.LIDS = make([]UnsubscribeFromListsRequestLIDS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.LIDS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("LIDS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UnsubscribeFromListsRequestLIDS is an item of list identifications
+type UnsubscribeFromListsRequestLIDS struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the UnsubscribeFromListsRequestLIDS as a list of values
+func (m *UnsubscribeFromListsRequestLIDS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the UnsubscribeFromListsRequestLIDS from decoded values
+func (m *UnsubscribeFromListsRequestLIDS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// UnsubscribeFromListsResponse is the response of AssociationLists unsubscribeFromLists which unsubscribes from changes of the lists
+type UnsubscribeFromListsResponse struct {
+}
+
+// Values encodes the UnsubscribeFromListsResponse as a list of values
+func (m *UnsubscribeFromListsResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the UnsubscribeFromListsResponse from decoded values
+func (m *UnsubscribeFromListsResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// GetConfigListsInfoRequest is the request of AssociationLists getConfigListsInfo which gets the configuration of every list
+type GetConfigListsInfoRequest struct {
+}
+
+// Values encodes the GetConfigListsInfoRequest as a list of values
+func (m *GetConfigListsInfoRequest) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the GetConfigListsInfoRequest from decoded values
+func (m *GetConfigListsInfoRequest) Decode(values blaze.Values) error {
+	return nil
+}
+
+// GetConfigListsInfoResponse is the response of AssociationLists getConfigListsInfo which gets the configuration of every list
+type GetConfigListsInfoResponse struct {
+	// the list configurations
+	CFGS []GetConfigListsInfoResponseCFGS
+}
+
+// Values encodes the GetConfigListsInfoResponse as a list of values
+func (m *GetConfigListsInfoResponse) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStructList("CFGS", m.CFGS, (*GetConfigListsInfoResponseCFGS).Values))
+	return values
+}
+
+// Decode sets the GetConfigListsInfoResponse from decoded values
+func (m *GetConfigListsInfoResponse) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.List[blaze.StructTdf]](values, "CFGS", false); err != nil {
+		return err
+	} else if ok {
+		m.CFGS = make([]GetConfigListsInfoResponseCFGS, len(v.Values))
+		for i, item := range v.Values {
+			if err := m.CFGS[i].Decode(item.Values); err != nil {
+				return blaze.NestedItemError("CFGS", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetConfigListsInfoResponseCFGS is an item of the list configurations
+type GetConfigListsInfoResponseCFGS struct {
+	// object id of the list owner
+	BOID types.Triple
+	// list flags
+	FLGS int64
+	// list identification
+	LID GetConfigListsInfoResponseCFGSLID
+	// maximum list size
+	LMS int64
+	// id of the paired list
+	PRID int64
+}
+
+// Values encodes the GetConfigListsInfoResponseCFGS as a list of values
+func (m *GetConfigListsInfoResponseCFGS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 5)
+	values = append(values, blaze.NewTriple("BOID", m.BOID))
+	values = append(values, blaze.NewInt64("FLGS", m.FLGS))
+	values = append(values, blaze.NewStruct("LID", m.LID.Values()...))
+	values = append(values, blaze.NewInt64("LMS", m.LMS))
+	values = append(values, blaze.NewInt64("PRID", m.PRID))
+	return values
+}
+
+// Decode sets the GetConfigListsInfoResponseCFGS from decoded values
+func (m *GetConfigListsInfoResponseCFGS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.TripleTdf](values, "BOID", false); err != nil {
+		return err
+	} else if ok {
+		m.BOID = v.Triple
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "FLGS", false); err != nil {
+		return err
+	} else if ok {
+		m.FLGS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "LID", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.LID.Decode(v.Values); err != nil {
+			return blaze.NestedError("LID", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "LMS", false); err != nil {
+		return err
+	} else if ok {
+		m.LMS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PRID", false); err != nil {
+		return err
+	} else if ok {
+		m.PRID = v.Value
+	}
+	return nil
+}
+
+// GetConfigListsInfoResponseCFGSLID is list identification
+type GetConfigListsInfoResponseCFGSLID struct {
+	// list name such as friendList
+	LNM string
+	// list type
+	TYPE int64
+}
+
+// Values encodes the GetConfigListsInfoResponseCFGSLID as a list of values
+func (m *GetConfigListsInfoResponseCFGSLID) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewString("LNM", m.LNM))
+	values = append(values, blaze.NewInt64("TYPE", m.TYPE))
+	return values
+}
+
+// Decode sets the GetConfigListsInfoResponseCFGSLID from decoded values
+func (m *GetConfigListsInfoResponseCFGSLID) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "LNM", false); err != nil {
+		return err
+	} else if ok {
+		m.LNM = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "TYPE", false); err != nil {
+		return err
+	} else if ok {
+		m.TYPE = v.Value
+	}
+	return nil
+}
+
+// AssociationListsHandler handles the requests of the AssociationLists component
+type AssociationListsHandler interface {
+	// AddUsersToList adds users to a list
+	AddUsersToList(ctx context.Context, request *AddUsersToListRequest) (*AddUsersToListResponse, error)
+	// RemoveUsersFromList removes users from a list
+	RemoveUsersFromList(ctx context.Context, request *RemoveUsersFromListRequest) (*RemoveUsersFromListResponse, error)
+	// ClearLists removes every user from the lists
+	ClearLists(ctx context.Context, request *ClearListsRequest) (*ClearListsResponse, error)
+	// SetUsersToList replaces the users of a list
+	SetUsersToList(ctx context.Context, request *SetUsersToListRequest) (*SetUsersToListResponse, error)
+	// GetListForUser gets a list of a player
+	GetListForUser(ctx context.Context, request *GetListForUserRequest) (*GetListForUserResponse, error)
+	// GetLists gets the lists of the player
+	GetLists(ctx context.Context, request *GetListsRequest) (*GetListsResponse, error)
+	// SubscribeToLists subscribes to changes of the lists
+	SubscribeToLists(ctx context.Context, request *SubscribeToListsRequest) (*SubscribeToListsResponse, error)
+	// UnsubscribeFromLists unsubscribes from changes of the lists
+	UnsubscribeFromLists(ctx context.Context, request *UnsubscribeFromListsRequest) (*UnsubscribeFromListsResponse, error)
+	// GetConfigListsInfo gets the configuration of every list
+	GetConfigListsInfo(ctx context.Context, request *GetConfigListsInfoRequest) (*GetConfigListsInfoResponse, error)
+}
+
+// UnimplementedAssociationListsHandler can be embedded in a AssociationListsHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedAssociationListsHandler struct{}
+
+func (UnimplementedAssociationListsHandler) AddUsersToList(context.Context, *AddUsersToListRequest) (*AddUsersToListResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) RemoveUsersFromList(context.Context, *RemoveUsersFromListRequest) (*RemoveUsersFromListResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) ClearLists(context.Context, *ClearListsRequest) (*ClearListsResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) SetUsersToList(context.Context, *SetUsersToListRequest) (*SetUsersToListResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) GetListForUser(context.Context, *GetListForUserRequest) (*GetListForUserResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) GetLists(context.Context, *GetListsRequest) (*GetListsResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) SubscribeToLists(context.Context, *SubscribeToListsRequest) (*SubscribeToListsResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) UnsubscribeFromLists(context.Context, *UnsubscribeFromListsRequest) (*UnsubscribeFromListsResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedAssociationListsHandler) GetConfigListsInfo(context.Context, *GetConfigListsInfoRequest) (*GetConfigListsInfoResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchAssociationLists decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchAssociationLists(ctx context.Context, handler AssociationListsHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != AssociationListsComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case AssociationListsAddUsersToList:
+		request := &AddUsersToListRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.AddUsersToList(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsRemoveUsersFromList:
+		request := &RemoveUsersFromListRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.RemoveUsersFromList(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsClearLists:
+		request := &ClearListsRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.ClearLists(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsSetUsersToList:
+		request := &SetUsersToListRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SetUsersToList(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsGetListForUser:
+		request := &GetListForUserRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetListForUser(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsGetLists:
+		request := &GetListsRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetLists(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsSubscribeToLists:
+		request := &SubscribeToListsRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.SubscribeToLists(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsUnsubscribeFromLists:
+		request := &UnsubscribeFromListsRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UnsubscribeFromLists(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case AssociationListsGetConfigListsInfo:
+		request := &GetConfigListsInfoRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.GetConfigListsInfo(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
+
+// GameReporting component and the commands and notifications of it
+const (
+	GameReportingComponent                  uint16 = 0x1C
+	GameReportingSubmitGameReport           uint16 = 0x1
+	GameReportingSubmitOfflineGameReport    uint16 = 0x2
+	GameReportingSubmitGameEvents           uint16 = 0x3
+	GameReportingGetGameReportQuery         uint16 = 0x4
+	GameReportingGetGameReportQueriesList   uint16 = 0x5
+	GameReportingGetGameReports             uint16 = 0x6
+	GameReportingGetGameReportView          uint16 = 0x7
+	GameReportingGetGameReportViewInfo      uint16 = 0x8
+	GameReportingGetGameReportViewInfoList  uint16 = 0x9
+	GameReportingGetGameReportTypes         uint16 = 0xA
+	GameReportingUpdateMetric               uint16 = 0xB
+	GameReportingGetGameReportColumnInfo    uint16 = 0xC
+	GameReportingGetGameReportColumnValues  uint16 = 0xD
+	GameReportingSubmitTrustedMidGameReport uint16 = 0x64
+	GameReportingSubmitTrustedEndGameReport uint16 = 0x65
+)
+
+// UserSessions component and the commands and notifications of it
+const (
+	UserSessionsComponent                       uint16 = 0x7802
+	UserSessionsNotifyUserAdded                 uint16 = 0x2
+	UserSessionsFetchExtendedData               uint16 = 0x3
+	UserSessionsNotifyUserRemoved               uint16 = 0x3
+	UserSessionsUpdateExtendedDataAttribute     uint16 = 0x5
+	UserSessionsUpdateHardwareFlags             uint16 = 0x8
+	UserSessionsLookupUser                      uint16 = 0xC
+	UserSessionsLookupUsers                     uint16 = 0xD
+	UserSessionsLookupUsersByPrefix             uint16 = 0xE
+	UserSessionsUpdateNetworkInfo               uint16 = 0x14
+	UserSessionsLookupUserGeoIPData             uint16 = 0x17
+	UserSessionsOverrideUserGeoIPData           uint16 = 0x18
+	UserSessionsUpdateUserSessionClientData     uint16 = 0x19
+	UserSessionsSetUserInfoAttribute            uint16 = 0x1A
+	UserSessionsResetUserGeoIPData              uint16 = 0x1B
+	UserSessionsLookupUserSessionId             uint16 = 0x20
+	UserSessionsFetchLastLocaleUsedAndAuthError uint16 = 0x21
+	UserSessionsFetchUserFirstLastAuthTime      uint16 = 0x22
+	UserSessionsResumeSession                   uint16 = 0x23
+)
+
+// NotifyUserAdded is the notification of UserSessions NotifyUserAdded which a friend came online
+type NotifyUserAdded struct {
+	// the friend
+	USER NotifyUserAddedUSER
+}
+
+// Values encodes the NotifyUserAdded as a list of values
+func (m *NotifyUserAdded) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewStruct("USER", m.USER.Values()...))
+	return values
+}
+
+// Decode sets the NotifyUserAdded from decoded values
+func (m *NotifyUserAdded) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "USER", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.USER.Decode(v.Values); err != nil {
+			return blaze.NestedError("USER", err)
+		}
+	}
+	return nil
+}
+
+// NotifyUserAddedUSER is the friend
+type NotifyUserAddedUSER struct {
+	// player id
+	ID *int64
+	// player name
+	NAME *string
+}
+
+// Values encodes the NotifyUserAddedUSER as a list of values
+func (m *NotifyUserAddedUSER) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	if m.ID != nil {
+		values = append(values, blaze.NewInt64("ID", *m.ID))
+	}
+	if m.NAME != nil {
+		values = append(values, blaze.NewString("NAME", *m.NAME))
+	}
+	return values
+}
+
+// Decode sets the NotifyUserAddedUSER from decoded values
+func (m *NotifyUserAddedUSER) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "ID", true); err != nil {
+		return err
+	} else if ok {
+		m.ID = &v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StringTdf](values, "NAME", true); err != nil {
+		return err
+	} else if ok {
+		m.NAME = &v.Value
+	}
+	return nil
+}
+
+// NotifyUserRemoved is the notification of UserSessions NotifyUserRemoved which a friend went offline
+type NotifyUserRemoved struct {
+	// player id of the friend
+	BUID int64
+}
+
+// Values encodes the NotifyUserRemoved as a list of values
+func (m *NotifyUserRemoved) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("BUID", m.BUID))
+	return values
+}
+
+// Decode sets the NotifyUserRemoved from decoded values
+func (m *NotifyUserRemoved) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "BUID", false); err != nil {
+		return err
+	} else if ok {
+		m.BUID = v.Value
+	}
+	return nil
+}
+
+// UpdateHardwareFlagsRequest is the request of UserSessions updateHardwareFlags which sets the hardware flags of the session
+type UpdateHardwareFlagsRequest struct {
+	// hardware flags
+	HWFG int64
+}
+
+// Values encodes the UpdateHardwareFlagsRequest as a list of values
+func (m *UpdateHardwareFlagsRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	values = append(values, blaze.NewInt64("HWFG", m.HWFG))
+	return values
+}
+
+// Decode sets the UpdateHardwareFlagsRequest from decoded values
+func (m *UpdateHardwareFlagsRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "HWFG", false); err != nil {
+		return err
+	} else if ok {
+		m.HWFG = v.Value
+	}
+	return nil
+}
+
+// UpdateHardwareFlagsResponse is the response of UserSessions updateHardwareFlags which sets the hardware flags of the session
+type UpdateHardwareFlagsResponse struct {
+}
+
+// Values encodes the UpdateHardwareFlagsResponse as a list of values
+func (m *UpdateHardwareFlagsResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the UpdateHardwareFlagsResponse from decoded values
+func (m *UpdateHardwareFlagsResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// UpdateNetworkInfoRequest is the request of UserSessions updateNetworkInfo which sets the addresses and connection quality of the client
+type UpdateNetworkInfoRequest struct {
+	// addresses of the client
+	ADDR UpdateNetworkInfoRequestADDR
+	// latency in milliseconds to each QoS server
+	NLMP map[string]int64
+	// network quality
+	NQOS UpdateNetworkInfoRequestNQOS
+}
+
+// Values encodes the UpdateNetworkInfoRequest as a list of values
+func (m *UpdateNetworkInfoRequest) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.UnionOf("ADDR", m.ADDR.Type, m.ADDR.Values()))
+	values = append(values, blaze.NewSortedMap("NLMP", m.NLMP))
+	values = append(values, blaze.NewStruct("NQOS", m.NQOS.Values()...))
+	return values
+}
+
+// Decode sets the UpdateNetworkInfoRequest from decoded values
+func (m *UpdateNetworkInfoRequest) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.UnionTdf](values, "ADDR", false); err != nil {
+		return err
+	} else if ok {
+		m.ADDR.Type = v.Type
+		if v.Content != nil {
+			if err := m.ADDR.Decode(blaze.Values{v.Content}); err != nil {
+				return blaze.NestedError("ADDR", err)
+			}
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Map[string, int64]](values, "NLMP", false); err != nil {
+		return err
+	} else if ok {
+		m.NLMP = make(map[string]int64, len(v.Keys))
+		for i, key := range v.Keys {
+			m.NLMP[key] = v.Values[i]
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "NQOS", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.NQOS.Decode(v.Values); err != nil {
+			return blaze.NestedError("NQOS", err)
+		}
+	}
+	return nil
+}
+
+// UpdateNetworkInfoRequestADDR is addresses of the client
+type UpdateNetworkInfoRequestADDR struct {
+	// Type is the union type written before the value
+	Type blaze.TdfType
+	// internal and external address
+	VALU *UpdateNetworkInfoRequestADDRVALU
+}
+
+// Values encodes the set values of the union. Only the first is written
+func (m *UpdateNetworkInfoRequestADDR) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 1)
+	if m.VALU != nil {
+		values = append(values, blaze.NewStruct("VALU", m.VALU.Values()...))
+	}
+	return values
+}
+
+// Decode sets the UpdateNetworkInfoRequestADDR from decoded values
+func (m *UpdateNetworkInfoRequestADDR) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "VALU", true); err != nil {
+		return err
+	} else if ok {
+		m.VALU = &UpdateNetworkInfoRequestADDRVALU{}
+		if err := m.VALU.Decode(v.Values); err != nil {
+			return blaze.NestedError("VALU", err)
+		}
+	}
+	return nil
+}
+
+// UpdateNetworkInfoRequestNQOS is network quality
+type UpdateNetworkInfoRequestNQOS struct {
+	// download bits per second
+	DBPS int64
+	// nat type
+	NATT int64
+	// upload bits per second
+	UBPS int64
+}
+
+// Values encodes the UpdateNetworkInfoRequestNQOS as a list of values
+func (m *UpdateNetworkInfoRequestNQOS) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 3)
+	values = append(values, blaze.NewInt64("DBPS", m.DBPS))
+	values = append(values, blaze.NewInt64("NATT", m.NATT))
+	values = append(values, blaze.NewInt64("UBPS", m.UBPS))
+	return values
+}
+
+// Decode sets the UpdateNetworkInfoRequestNQOS from decoded values
+func (m *UpdateNetworkInfoRequestNQOS) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "DBPS", false); err != nil {
+		return err
+	} else if ok {
+		m.DBPS = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "NATT", false); err != nil {
+		return err
+	} else if ok {
+		m.NATT = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "UBPS", false); err != nil {
+		return err
+	} else if ok {
+		m.UBPS = v.Value
+	}
+	return nil
+}
+
+// UpdateNetworkInfoRequestADDRVALU is internal and external address
+type UpdateNetworkInfoRequestADDRVALU struct {
+	// external address
+	EXIP UpdateNetworkInfoRequestADDRVALUEXIP
+	// internal address
+	INIP UpdateNetworkInfoRequestADDRVALUINIP
+}
+
+// Values encodes the UpdateNetworkInfoRequestADDRVALU as a list of values
+func (m *UpdateNetworkInfoRequestADDRVALU) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewStruct("EXIP", m.EXIP.Values()...))
+	values = append(values, blaze.NewStruct("INIP", m.INIP.Values()...))
+	return values
+}
+
+// Decode sets the UpdateNetworkInfoRequestADDRVALU from decoded values
+func (m *UpdateNetworkInfoRequestADDRVALU) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "EXIP", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.EXIP.Decode(v.Values); err != nil {
+			return blaze.NestedError("EXIP", err)
+		}
+	}
+	if v, ok, err := blaze.DecodeField[blaze.StructTdf](values, "INIP", false); err != nil {
+		return err
+	} else if ok {
+		if err := m.INIP.Decode(v.Values); err != nil {
+			return blaze.NestedError("INIP", err)
+		}
+	}
+	return nil
+}
+
+// UpdateNetworkInfoRequestADDRVALUEXIP is external address
+type UpdateNetworkInfoRequestADDRVALUEXIP struct {
+	// ip address as a number
+	IP int64
+	// port
+	PORT int64
+}
+
+// Values encodes the UpdateNetworkInfoRequestADDRVALUEXIP as a list of values
+func (m *UpdateNetworkInfoRequestADDRVALUEXIP) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("IP", m.IP))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	return values
+}
+
+// Decode sets the UpdateNetworkInfoRequestADDRVALUEXIP from decoded values
+func (m *UpdateNetworkInfoRequestADDRVALUEXIP) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "IP", false); err != nil {
+		return err
+	} else if ok {
+		m.IP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	return nil
+}
+
+// UpdateNetworkInfoRequestADDRVALUINIP is internal address
+type UpdateNetworkInfoRequestADDRVALUINIP struct {
+	// ip address as a number
+	IP int64
+	// port
+	PORT int64
+}
+
+// Values encodes the UpdateNetworkInfoRequestADDRVALUINIP as a list of values
+func (m *UpdateNetworkInfoRequestADDRVALUINIP) Values() []blaze.Tdf {
+	values := make([]blaze.Tdf, 0, 2)
+	values = append(values, blaze.NewInt64("IP", m.IP))
+	values = append(values, blaze.NewInt64("PORT", m.PORT))
+	return values
+}
+
+// Decode sets the UpdateNetworkInfoRequestADDRVALUINIP from decoded values
+func (m *UpdateNetworkInfoRequestADDRVALUINIP) Decode(values blaze.Values) error {
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "IP", false); err != nil {
+		return err
+	} else if ok {
+		m.IP = v.Value
+	}
+	if v, ok, err := blaze.DecodeField[blaze.Int64Tdf](values, "PORT", false); err != nil {
+		return err
+	} else if ok {
+		m.PORT = v.Value
+	}
+	return nil
+}
+
+// UpdateNetworkInfoResponse is the response of UserSessions updateNetworkInfo which sets the addresses and connection quality of the client
+type UpdateNetworkInfoResponse struct {
+}
+
+// Values encodes the UpdateNetworkInfoResponse as a list of values
+func (m *UpdateNetworkInfoResponse) Values() []blaze.Tdf {
+	return nil
+}
+
+// Decode sets the UpdateNetworkInfoResponse from decoded values
+func (m *UpdateNetworkInfoResponse) Decode(values blaze.Values) error {
+	return nil
+}
+
+// UserSessionsHandler handles the requests of the UserSessions component
+type UserSessionsHandler interface {
+	// UpdateHardwareFlags sets the hardware flags of the session
+	UpdateHardwareFlags(ctx context.Context, request *UpdateHardwareFlagsRequest) (*UpdateHardwareFlagsResponse, error)
+	// UpdateNetworkInfo sets the addresses and connection quality of the client
+	UpdateNetworkInfo(ctx context.Context, request *UpdateNetworkInfoRequest) (*UpdateNetworkInfoResponse, error)
+}
+
+// UnimplementedUserSessionsHandler can be embedded in a UserSessionsHandler so that the
+// commands without a method fail with blaze.ErrUnimplemented
+type UnimplementedUserSessionsHandler struct{}
+
+func (UnimplementedUserSessionsHandler) UpdateHardwareFlags(context.Context, *UpdateHardwareFlagsRequest) (*UpdateHardwareFlagsResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+func (UnimplementedUserSessionsHandler) UpdateNetworkInfo(context.Context, *UpdateNetworkInfoRequest) (*UpdateNetworkInfoResponse, error) {
+	return nil, blaze.ErrUnimplemented
+}
+
+// DispatchUserSessions decodes the request in the packet, passes it to the handler
+// and encodes the response. Packets for other commands are an error
+func DispatchUserSessions(ctx context.Context, handler UserSessionsHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {
+	if packet.Component != UserSessionsComponent {
+		return nil, blaze.ErrUnknownCommand
+	}
+	switch packet.Command {
+	case UserSessionsUpdateHardwareFlags:
+		request := &UpdateHardwareFlagsRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UpdateHardwareFlags(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	case UserSessionsUpdateNetworkInfo:
+		request := &UpdateNetworkInfoRequest{}
+		if err := request.Decode(packet.ReadContent()); err != nil {
+			return nil, err
+		}
+		response, err := handler.UpdateNetworkInfo(ctx, request)
+		if err != nil || response == nil {
+			return nil, err
+		}
+		return response.Values(), nil
+	}
+	return nil, blaze.ErrUnknownCommand
+}
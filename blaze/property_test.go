@@ -0,0 +1,263 @@
+package blaze
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+const labelChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func randomLabel(r *rand.Rand) string {
+	out := make([]byte, 1+r.Intn(4))
+	for i := range out {
+		out[i] = labelChars[r.Intn(len(labelChars))]
+	}
+	return string(out)
+}
+
+func randomInt(r *rand.Rand) int64 {
+	switch r.Intn(4) {
+	case 0:
+		return int64(r.Intn(64))
+	case 1:
+		return -r.Int63()
+	case 2:
+		return math.MinInt64
+	default:
+		return r.Int63()
+	}
+}
+
+func randomString(r *rand.Rand) string {
+	var out strings.Builder
+	for i := r.Intn(12); i > 0; i-- {
+		out.WriteByte(byte(0x20 + r.Intn(0x5F)))
+	}
+	return out.String()
+}
+
+func randomBlob(r *rand.Rand) []byte {
+	out := make([]byte, r.Intn(12))
+	r.Read(out)
+	return out
+}
+
+func randomValues(r *rand.Rand, depth int) []Tdf {
+	out := make([]Tdf, r.Intn(5))
+	for i := range out {
+		out[i] = randomTdf(r, randomLabel(r), depth)
+	}
+	return out
+}
+
+// randomTdf creates a value of a random type. Types that hold other values
+// are only picked while depth is above zero
+func randomTdf(r *rand.Rand, label string, depth int) Tdf {
+	types := []TdfType{IntType, StringType, BlobType, VarIntListType, PairType, TripleType, FloatType}
+	if depth > 0 {
+		types = append(types, StructType, ListType, PairListType, UnionType, GenericType)
+	}
+	switch types[r.Intn(len(types))] {
+	case IntType:
+		return NewInt64(label, randomInt(r))
+	case StringType:
+		return NewString(label, randomString(r))
+	case BlobType:
+		return NewBlob(label, randomBlob(r))
+	case StructType:
+		out := NewStruct(label, randomValues(r, depth-1)...)
+		out.Start2 = r.Intn(4) == 0
+		return out
+	case ListType:
+		subType := randomSubType(r, depth)
+		list, _ := NewListOf(label, subType, randomItems(r, subType, depth-1))
+		return list
+	case PairListType:
+		keyType, valueType := randomSubType(r, depth), randomSubType(r, depth)
+		count := r.Intn(4)
+		keys, values := make([]any, count), make([]any, count)
+		for i := 0; i < count; i++ {
+			keys[i] = randomItem(r, keyType, depth-1)
+			values[i] = randomItem(r, valueType, depth-1)
+		}
+		value, _ := NewMapOf(label, keyType, valueType, keys, values)
+		return value
+	case UnionType:
+		return randomUnion(r, label, depth-1)
+	case GenericType:
+		if r.Intn(4) == 0 {
+			return NewGeneric(label, 0, nil)
+		}
+		return NewGeneric(label, randomInt(r), randomTdf(r, randomLabel(r), depth-1))
+	case VarIntListType:
+		values := make([]int64, r.Intn(5))
+		for i := range values {
+			values[i] = randomInt(r)
+		}
+		return NewVarIntList(label, values)
+	case PairType:
+		return NewPair(label, Pair{A: randomInt(r), B: randomInt(r)})
+	case TripleType:
+		return NewTriple(label, Triple{A: randomInt(r), B: randomInt(r), C: randomInt(r)})
+	default:
+		return NewFloat(label, r.NormFloat64())
+	}
+}
+
+func randomUnion(r *rand.Rand, label string, depth int) UnionTdf {
+	if r.Intn(4) == 0 {
+		return NewUnion(label, EmptyType, nil)
+	}
+	return NewUnion(label, TdfType(r.Intn(4)), randomTdf(r, randomLabel(r), depth))
+}
+
+// randomSubType picks a list subtype only picking nested lists and maps
+// while depth is above one
+func randomSubType(r *rand.Rand, depth int) SubType {
+	for {
+		subType := SubType(r.Intn(int(FloatList) + 1))
+		if depth > 1 || (subType != ListList && subType != MapList) {
+			return subType
+		}
+	}
+}
+
+func randomItems(r *rand.Rand, subType SubType, depth int) []any {
+	out := make([]any, r.Intn(4))
+	for i := range out {
+		out[i] = randomItem(r, subType, depth)
+	}
+	return out
+}
+
+func randomItem(r *rand.Rand, subType SubType, depth int) any {
+	switch subType {
+	case IntList:
+		return randomInt(r)
+	case StringList:
+		return randomString(r)
+	case BlobList:
+		return randomBlob(r)
+	case StructList:
+		return NewStructStub(randomValues(r, depth), r.Intn(4) == 0)
+	case ListList:
+		itemType := randomSubType(r, depth)
+		list, _ := NewListOf("", itemType, randomItems(r, itemType, depth-1))
+		return NestedList{list.(ListValue)}
+	case MapList:
+		keyType, valueType := randomSubType(r, depth), randomSubType(r, depth)
+		value, _ := NewMapOf("", keyType, valueType, nil, nil)
+		if r.Intn(2) == 0 {
+			key, item := randomItem(r, keyType, depth-1), randomItem(r, valueType, depth-1)
+			value, _ = NewMapOf("", keyType, valueType, []any{key}, []any{item})
+		}
+		return NestedMap{value.(MapValue)}
+	case UnionList:
+		return randomUnion(r, "", depth)
+	case VarIntListList:
+		return NewVarIntList("", []int64{randomInt(r), randomInt(r)})
+	case PairList:
+		return Pair{A: randomInt(r), B: randomInt(r)}
+	case TripleList:
+		return Triple{A: randomInt(r), B: randomInt(r), C: randomInt(r)}
+	default:
+		return r.NormFloat64()
+	}
+}
+
+// TestRandomRoundTrip writes random trees of values and checks that reading
+// them back and writing them again gives the same bytes
+func TestRandomRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	iterations := 2000
+	if testing.Short() {
+		iterations = 200
+	}
+	for i := 0; i < iterations; i++ {
+		content := randomValues(r, 3)
+		wire := encodeContent(content...)
+		text := FormatContent(content, DefaultFormatOptions)
+
+		read := readContentBuff(wire)
+		if len(read) != len(content) || !bytes.Equal(encodeContent(read...), wire) {
+			t.Fatalf("PacketBuff round trip changed the bytes\n%s", text)
+		}
+		if decoded := DecodeContent(wire); !bytes.Equal(encodeContent(decoded...), wire) {
+			t.Fatalf("Decoder round trip changed the bytes\n%s", text)
+		}
+		lazy := NewLazyReader(wire)
+		count := 0
+		for lazy.Next() {
+			count++
+		}
+		if lazy.Err() != nil || count != len(content) {
+			t.Fatalf("lazy reader skipped %d of %d values: %v\n%s", count, len(content), lazy.Err(), text)
+		}
+	}
+}
+
+// TestVarIntRoundTrip checks ints around each byte boundary
+func TestVarIntRoundTrip(t *testing.T) {
+	values := []int64{math.MinInt64, math.MaxInt64, -1}
+	for shift := uint(0); shift < 63; shift++ {
+		values = append(values, 1<<shift-1, 1<<shift, -(1 << shift))
+	}
+	for _, value := range values {
+		buff := PacketBuff{Buffer: &bytes.Buffer{}}
+		buff.WriteVarInt(value)
+		data := append([]byte(nil), buff.Bytes()...)
+		if out := buff.ReadVarInt(); out != value || buff.Len() != 0 {
+			t.Errorf("PacketBuff read %d as %d", value, out)
+		}
+		d := NewDecoder(data)
+		if out := d.ReadVarInt(); out != value || d.Len() != 0 {
+			t.Errorf("Decoder read %d as %d", value, out)
+		}
+	}
+}
+
+// TestLabelTagInverse checks every label made of upper case letters and
+// digits gives a tag that converts back to the same label
+func TestLabelTagInverse(t *testing.T) {
+	if testing.Short() {
+		t.Skip("checks every label")
+	}
+	label := make([]byte, 0, 4)
+	var check func()
+	check = func() {
+		if len(label) > 0 {
+			tag := LabelToTag(string(label))
+			if out := strings.TrimRight(TagToLabel(tag), " "); out != string(label) {
+				t.Fatalf("%q became tag %x and then %q", label, tag, out)
+			}
+		}
+		if len(label) == 4 {
+			return
+		}
+		for i := 0; i < len(labelChars); i++ {
+			label = append(label, labelChars[i])
+			check()
+			label = label[:len(label)-1]
+		}
+	}
+	check()
+}
+
+// TestTagLabelInverse checks every tag converts to a label that gives the
+// same tag back
+func TestTagLabelInverse(t *testing.T) {
+	step := uint32(1)
+	if testing.Short() {
+		step = 257
+	}
+	for tag := uint32(0); tag < 1<<24; tag += step {
+		if out := LabelToTag(TagToLabel(tag << 8)); out != tag<<8 {
+			t.Fatalf("tag %x became %q and then %x", tag<<8, TagToLabel(tag<<8), out)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Field describes a labelled value within the payload of a packet
+type Field struct {
+	Label Label
+	Type  TdfType
+	// ItemType is the type of list items and map values and KeyType is the
+	// type of map keys
+	KeyType  TdfType
+	ItemType TdfType
+	// Optional fields may be left out of the payload
+	Optional bool
+	// Doc describes what the field means
+	Doc string
+	// Fields describes the values of a struct, of the struct items of a
+	// list or map and the values a union can hold. Nil fields leave the
+	// values unchecked
+	Fields []Field
+}
+
+func newField(label string, t TdfType, doc string) Field {
+	return Field{Label: MustLabel(label), Type: t, Doc: doc}
+}
+
+func IntField(label, doc string) Field     { return newField(label, IntType, doc) }
+func StringField(label, doc string) Field  { return newField(label, StringType, doc) }
+func BlobField(label, doc string) Field    { return newField(label, BlobType, doc) }
+func PairField(label, doc string) Field    { return newField(label, PairType, doc) }
+func TripleField(label, doc string) Field  { return newField(label, TripleType, doc) }
+func FloatField(label, doc string) Field   { return newField(label, FloatType, doc) }
+func VarIntsField(label, doc string) Field { return newField(label, VarIntListType, doc) }
+
+// StructField describes a struct holding the provided fields
+func StructField(label, doc string, fields ...Field) Field {
+	field := newField(label, StructType, doc)
+	field.Fields = sortFields(fields)
+	return field
+}
+
+// ListField describes a list of items of the provided type. The fields
+// describe the values of struct items
+func ListField(label string, item TdfType, doc string, fields ...Field) Field {
+	field := newField(label, ListType, doc)
+	field.ItemType = item
+	field.Fields = sortFields(fields)
+	return field
+}
+
+// MapField describes a map with keys and values of the provided types. The
+// fields describe the values of struct map values
+func MapField(label string, key TdfType, value TdfType, doc string, fields ...Field) Field {
+	field := newField(label, PairListType, doc)
+	field.KeyType = key
+	field.ItemType = value
+	field.Fields = sortFields(fields)
+	return field
+}
+
+// UnionField describes a union that holds one of the provided fields
+func UnionField(label, doc string, fields ...Field) Field {
+	field := newField(label, UnionType, doc)
+	field.Fields = sortFields(fields)
+	return field
+}
+
+// sortFields copies the fields into tag order which is the order values are
+// written in. Nil fields stay nil
+func sortFields(fields []Field) []Field {
+	if fields == nil {
+		return nil
+	}
+	out := append([]Field{}, fields...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Label.Tag() < out[j].Label.Tag() })
+	return out
+}
+
+// AsOptional marks the field as one that may be left out
+func (f Field) AsOptional() Field {
+	f.Optional = true
+	return f
+}
+
+// Schema describes the payload of a request, response or notification
+type Schema struct {
+	Component uint16
+	Command   uint16
+	// Type is the message type such as RequestType
+	Type uint16
+	// Name is the command or notification name
+	Name   string
+	Doc    string
+	Fields []Field
+	// Open schemas only name a command whose payload hasn't been described
+	// so their values are never checked
+	Open bool
+}
+
+func schemaKey(component uint16, command uint16, messageType uint16) uint64 {
+	return uint64(component)<<32 | uint64(command)<<16 | uint64(messageType&0xF000)
+}
+
+var schemas = map[uint64]*Schema{}
+
+// RegisterSchema adds the schema to the registry replacing any schema for
+// the same component, command and message type. The name is taken from
+// CommandNames or NotificationNames when it isn't set
+func RegisterSchema(schema Schema) {
+	if schema.Name == "" {
+		names := CommandNames
+		if schema.Type&0xF000 == NotificationType {
+			names = NotificationNames
+		}
+		schema.Name = names[uint32(schema.Component)<<16|uint32(schema.Command)]
+	}
+	schema.Fields = sortFields(schema.Fields)
+	schemas[schemaKey(schema.Component, schema.Command, schema.Type)] = &schema
+}
+
+// LookupSchema finds the schema of the payload sent with the provided
+// component, command and message type
+func LookupSchema(component uint16, command uint16, messageType uint16) (*Schema, bool) {
+	schema, ok := schemas[schemaKey(component, command, messageType)]
+	return schema, ok
+}
+
+// Schemas is every registered schema ordered by component, command and
+// message type
+func Schemas() []*Schema {
+	out := make([]*Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		out = append(out, schema)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return schemaKey(out[i].Component, out[i].Command, out[i].Type) <
+			schemaKey(out[j].Component, out[j].Command, out[j].Type)
+	})
+	return out
+}
+
+// Schema finds the schema of the payload of the packet
+func (p *Packet) Schema() (*Schema, bool) {
+	return LookupSchema(p.Component, p.Command, p.QType)
+}
+
+var (
+	ErrMissingField    = errors.New("missing field")
+	ErrUnexpectedField = errors.New("unexpected field")
+	// ErrUnknownCommand and ErrUnimplemented are returned by the handlers
+	// generated from schemas
+	ErrUnknownCommand = errors.New("blaze: unknown command")
+	ErrUnimplemented  = errors.New("blaze: command not implemented")
+)
+
+// SchemaError is a value that doesn't match its schema along with the path
+// to the value
+type SchemaError struct {
+	Path string
+	Err  error
+}
+
+func (e *SchemaError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the values against the schema returning an error for
+// each missing, unexpected or wrongly typed value
+func (s *Schema) Validate(values []Tdf) []error {
+	if s.Open {
+		return nil
+	}
+	var out []error
+	validateFields(&out, "", s.Fields, values)
+	return out
+}
+
+// ReadValidated decodes the content of the packet and checks it against
+// the schema of the packet. Packets without a schema or with an open
+// schema are never invalid
+func (p *Packet) ReadValidated() (Values, []error) {
+	content := p.ReadContent()
+	schema, ok := p.Schema()
+	if !ok {
+		return content, nil
+	}
+	return content, schema.Validate(content)
+}
+
+// findField finds the field with the provided tag
+func findField(fields []Field, tag uint32) int {
+	for i, field := range fields {
+		if field.Label.Tag() == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+func validateFields(out *[]error, prefix string, fields []Field, values []Tdf) {
+	seen := make([]bool, len(fields))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		head := value.GetHead()
+		path := prefix + strings.TrimRight(head.Label, " ")
+		i := findField(fields, head.Tag)
+		if i < 0 {
+			*out = append(*out, &SchemaError{Path: path, Err: ErrUnexpectedField})
+			continue
+		}
+		seen[i] = true
+		validateField(out, path, fields[i], value)
+	}
+	for i, field := range fields {
+		if !seen[i] && !field.Optional {
+			*out = append(*out, &SchemaError{Path: prefix + string(field.Label), Err: ErrMissingField})
+		}
+	}
+}
+
+// wrongType adds an error when the actual type isn't the expected type
+func wrongType(out *[]error, path string, expected TdfType, actual TdfType) bool {
+	if expected == actual {
+		return false
+	}
+	*out = append(*out, &SchemaError{Path: path, Err: fmt.Errorf("%w: %s not %s", ErrWrongType, actual, expected)})
+	return true
+}
+
+func validateField(out *[]error, path string, field Field, value Tdf) {
+	if wrongType(out, path, field.Type, value.GetHead().Type) {
+		return
+	}
+	switch v := value.(type) {
+	case StructTdf:
+		if field.Fields != nil {
+			validateFields(out, path+".", field.Fields, v.Values)
+		}
+	case UnionTdf:
+		if field.Fields == nil || v.Type == EmptyType || v.Content == nil {
+			return
+		}
+		head := v.Content.GetHead()
+		contentPath := path + "." + strings.TrimRight(head.Label, " ")
+		if i := findField(field.Fields, head.Tag); i >= 0 {
+			validateField(out, contentPath, field.Fields[i], v.Content)
+		} else {
+			*out = append(*out, &SchemaError{Path: contentPath, Err: ErrUnexpectedField})
+		}
+	case ListValue:
+		if wrongType(out, path+"[]", field.ItemType, TdfType(v.ItemType())) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			validateItem(out, fmt.Sprintf("%s[%d]", path, i), field, v.Item(i))
+		}
+	case MapValue:
+		keyWrong := wrongType(out, path+"{key}", field.KeyType, TdfType(v.KeyType()))
+		if wrongType(out, path+"{}", field.ItemType, TdfType(v.ValueType())) || keyWrong {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			validateItem(out, fmt.Sprintf("%s[%v]", path, v.Key(i)), field, v.Value(i))
+		}
+	}
+}
+
+// validateItem checks the values of struct items against the fields
+func validateItem(out *[]error, path string, field Field, item any) {
+	if value, ok := item.(StructTdf); ok && field.Fields != nil {
+		validateFields(out, path+".", field.Fields, value.Values)
+	}
+}
+
+// lookupField finds the field with the provided tag
+func lookupField(fields []Field, tag uint32) (Field, bool) {
+	if i := findField(fields, tag); i >= 0 {
+		return fields[i], true
+	}
+	return Field{}, false
+}
+
+// DecodeField finds the value with the provided label for code generated
+// from schemas. Missing values are an error unless they are optional and
+// values of another type are always an error
+func DecodeField[T Tdf](values Values, label string, optional bool) (T, bool, error) {
+	var zero T
+	value, ok := values.Get(label)
+	if !ok {
+		if optional {
+			return zero, false, nil
+		}
+		return zero, false, &SchemaError{Path: label, Err: ErrMissingField}
+	}
+	out, ok := value.(T)
+	if !ok {
+		return zero, false, &SchemaError{Path: label, Err: fmt.Errorf("%w: found %s", ErrWrongType, typeNameOf(value))}
+	}
+	return out, true, nil
+}
+
+// NestedError adds the path of the value holding a nested value to an
+// error from decoding the nested value
+func NestedError(path string, err error) error {
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		return &SchemaError{Path: path + "." + schemaErr.Path, Err: schemaErr.Err}
+	}
+	return &SchemaError{Path: path, Err: err}
+}
+
+// NestedItemError is NestedError for the items of lists and maps
+func NestedItemError(path string, index any, err error) error {
+	return NestedError(fmt.Sprintf("%s[%v]", path, index), err)
+}
+
+// UnionOf creates a union holding the first of the values or an empty
+// union when there are none
+func UnionOf(label string, unionType TdfType, values []Tdf) UnionTdf {
+	if len(values) == 0 {
+		return NewUnion(label, EmptyType, nil)
+	}
+	return NewUnion(label, unionType, values[0])
+}
+
+// NewStructList creates a list of structs from items encoded by values
+func NewStructList[T any](label string, items []T, values func(item *T) []Tdf) List[StructTdf] {
+	out := make([]StructTdf, len(items))
+	for i := range items {
+		out[i] = NewStructStub(values(&items[i]), false)
+	}
+	return NewList(label, out)
+}
+
+// NewStructMap creates a map of structs from a go map with the entries in
+// key order
+func NewStructMap[K int64 | string, T any](label string, items map[K]T, values func(item *T) []Tdf) Map[K, StructTdf] {
+	keys := SortedKeys(items)
+	out := make([]StructTdf, len(keys))
+	for i, key := range keys {
+		item := items[key]
+		out[i] = NewStructStub(values(&item), false)
+	}
+	return NewMap(label, keys, out)
+}
+
+// ValuesOf returns the values for use with NewStructList and NewStructMap
+// when the items are already values
+func ValuesOf(values *Values) []Tdf {
+	return *values
+}
@@ -0,0 +1,147 @@
+package blaze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+func TestSeededSchemas(t *testing.T) {
+	all := Schemas()
+	if len(all) == 0 {
+		t.Fatal("no schemas are registered")
+	}
+	var check func(path string, fields []Field)
+	check = func(path string, fields []Field) {
+		for i, field := range fields {
+			if i > 0 && fields[i-1].Label.Tag() >= field.Label.Tag() {
+				t.Errorf("%s: %s isn't in tag order", path, field.Label)
+			}
+			check(path+"."+string(field.Label), field.Fields)
+		}
+	}
+	for _, schema := range all {
+		if schema.Name == "" {
+			t.Errorf("%x:%x has no name", schema.Component, schema.Command)
+		}
+		check(schema.Name, schema.Fields)
+	}
+}
+
+func TestWirePacketsMatchSchemas(t *testing.T) {
+	for _, wire := range wirePackets {
+		schema, ok := LookupSchema(wire.comp, wire.cmd, wire.qType)
+		if !ok {
+			continue
+		}
+		if errs := schema.Validate(wire.content); len(errs) > 0 {
+			t.Errorf("%s: %v", wire.name, errs)
+		}
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		StringField("NAME", "name"),
+		IntField("OPT", "optional").AsOptional(),
+		ListField("USRS", StructType, "users", IntField("ID", "id")),
+		UnionField("ADDR", "address", StructField("VALU", "value", StringField("HOST", "host"))),
+		MapField("ATTR", StringType, StringType, "attributes"),
+		IntField("MISS", "missing"),
+	}}
+	errs := schema.Validate([]Tdf{
+		NewInt64("NAME", 1),
+		NewList("USRS", []StructTdf{NewStructStub([]Tdf{NewInt64("ID", 1), NewInt64("EXTR", 2)}, false)}),
+		NewUnion("ADDR", 0, NewStruct("VALU", NewInt64("HOST", 1))),
+		NewMap("ATTR", []string{"a"}, []int64{1}),
+	})
+	expected := map[string]error{
+		"NAME":           ErrWrongType,
+		"USRS[0].EXTR":   ErrUnexpectedField,
+		"ADDR.VALU.HOST": ErrWrongType,
+		"ATTR{}":         ErrWrongType,
+		"MISS":           ErrMissingField,
+	}
+	for _, err := range errs {
+		var schemaErr *SchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Errorf("unexpected error %v", err)
+			continue
+		}
+		if want, ok := expected[schemaErr.Path]; !ok || !errors.Is(err, want) {
+			t.Errorf("unexpected error %v", err)
+		}
+		delete(expected, schemaErr.Path)
+	}
+	for path, err := range expected {
+		t.Errorf("%s: expected %v", path, err)
+	}
+}
+
+func TestReadValidated(t *testing.T) {
+	buf := PacketBuff{}
+	data := buf.EncodePacket(0xF, 0x1, 0, NotificationType, 0, []Tdf{
+		NewInt64("FLAG", 0),
+		NewInt64("MGID", 1),
+		NewString("NAME", "Shepard"),
+		NewStruct("PYLD",
+			NewMap("ATTR", []int64{2}, []string{"Hello"}),
+			NewInt64("FLAG", 0),
+			NewInt64("STAT", 0),
+			NewInt64("TAG", 0),
+			NewTriple("TARG", Triple{A: 0x7802, B: 1, C: 2}),
+			NewInt64("TYPE", 0),
+		),
+		NewTriple("SRCE", Triple{A: 0x7802, B: 1, C: 1}),
+	})
+	packet, _, err := DecodePacket(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, errs := packet.ReadValidated()
+	if len(content) != 5 || len(errs) != 1 || !errors.Is(errs[0], ErrMissingField) {
+		t.Errorf("unexpected result %d values %v", len(content), errs)
+	}
+}
+
+func TestFormatWithSchema(t *testing.T) {
+	schema, _ := LookupSchema(0x9, 0x1, RequestType)
+	options := DefaultFormatOptions
+	options.Schema = schema
+	text := FormatContent([]Tdf{NewString("CFID", "ME3_DATA"), NewInt64("EXTR", 1)}, options)
+	expected := "// config id such as ME3_DATA\nCFID (string) = \"ME3_DATA\"\nEXTR (int) = 1"
+	if text != expected {
+		t.Errorf("unexpected text\n%s", text)
+	}
+	options.Compact = true
+	if text := FormatContent([]Tdf{NewString("CFID", "ME3_DATA")}, options); strings.Contains(text, "//") {
+		t.Errorf("compact text was annotated %s", text)
+	}
+}
+
+func TestNamedCommandsHaveSchemas(t *testing.T) {
+	for key, name := range CommandNames {
+		for _, messageType := range []uint16{RequestType, ResponseType} {
+			if schema, ok := LookupSchema(uint16(key>>16), uint16(key), messageType); !ok || schema.Name != name {
+				t.Errorf("%s 0x%04X has no schema", name, messageType)
+			}
+		}
+	}
+	for key, name := range NotificationNames {
+		if schema, ok := LookupSchema(uint16(key>>16), uint16(key), NotificationType); !ok || schema.Name != name {
+			t.Errorf("%s has no schema", name)
+		}
+	}
+}
+
+func TestOpenSchemaValidate(t *testing.T) {
+	schema, ok := LookupSchema(0x4, 0x1, RequestType)
+	if !ok || !schema.Open {
+		t.Fatal("createGame isn't registered as open")
+	}
+	if errs := schema.Validate([]Tdf{NewInt64("GID", 1), NewString("GNAM", "game")}); len(errs) > 0 {
+		t.Errorf("open schema gave errors %v", errs)
+	}
+}
@@ -0,0 +1,652 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+// kind is how a field is stored in a generated struct
+type kind int
+
+const (
+	kindScalar kind = iota
+	kindSlice
+	kindStruct
+	kindStructValues
+	kindUnion
+	kindList
+	kindStructList
+	kindStructValuesList
+	kindMap
+	kindStructMap
+	kindStructValuesMap
+	kindRaw
+)
+
+// scalar describes the value type used for a scalar type
+type scalar struct {
+	goType      string
+	tdf         string
+	constructor string
+	field       string
+}
+
+var scalars = map[blaze.TdfType]scalar{
+	blaze.IntType:        {"int64", "Int64Tdf", "NewInt64", "Value"},
+	blaze.StringType:     {"string", "StringTdf", "NewString", "Value"},
+	blaze.BlobType:       {"[]byte", "BlobTdf", "NewBlob", "Data"},
+	blaze.FloatType:      {"float64", "FloatTdf", "NewFloat", "Value"},
+	blaze.PairType:       {"types.Pair", "PairTdf", "NewPair", "Pair"},
+	blaze.TripleType:     {"types.Triple", "TripleTdf", "NewTriple", "Triple"},
+	blaze.VarIntListType: {"[]int64", "VarIntListTdf", "NewVarIntList", "Values"},
+}
+
+var typeConstants = map[blaze.TdfType]string{
+	blaze.IntType:        "blaze.IntType",
+	blaze.StringType:     "blaze.StringType",
+	blaze.BlobType:       "blaze.BlobType",
+	blaze.StructType:     "blaze.StructType",
+	blaze.ListType:       "blaze.ListType",
+	blaze.PairListType:   "blaze.PairListType",
+	blaze.UnionType:      "blaze.UnionType",
+	blaze.VarIntListType: "blaze.VarIntListType",
+	blaze.PairType:       "blaze.PairType",
+	blaze.TripleType:     "blaze.TripleType",
+	blaze.FloatType:      "blaze.FloatType",
+	blaze.GenericType:    "blaze.GenericType",
+}
+
+var messageTypeConstants = map[uint16]string{
+	blaze.RequestType:      "blaze.RequestType",
+	blaze.ResponseType:     "blaze.ResponseType",
+	blaze.NotificationType: "blaze.NotificationType",
+	blaze.ErrorType:        "blaze.ErrorType",
+}
+
+// isItemScalar checks whether list items or map values of the type can be
+// stored as go values
+func isItemScalar(t blaze.TdfType) bool {
+	_, ok := scalars[t]
+	return ok && t != blaze.VarIntListType
+}
+
+// genField is a field along with how it's stored
+type genField struct {
+	blaze.Field
+	name string
+	kind kind
+	// nested is the name of the generated type of structs and unions
+	nested string
+	// key and item are the go types of map keys and list or map items
+	key  string
+	item string
+	// optional is set for every field of a union
+	optional bool
+}
+
+// pointer checks whether the field is stored as a pointer which is nil
+// when the field is left out
+func (f genField) pointer() bool {
+	return f.optional && (f.kind == kindScalar || f.kind == kindStruct || f.kind == kindUnion)
+}
+
+// goType is the type of the field in the generated struct
+func (f genField) goType() string {
+	var out string
+	switch f.kind {
+	case kindScalar, kindSlice:
+		out = scalars[f.Type].goType
+	case kindStruct, kindUnion:
+		out = f.nested
+	case kindStructValues:
+		out = "blaze.Values"
+	case kindList:
+		out = "[]" + f.item
+	case kindStructList:
+		out = "[]" + f.nested
+	case kindStructValuesList:
+		out = "[]blaze.Values"
+	case kindMap:
+		out = "map[" + f.key + "]" + f.item
+	case kindStructMap:
+		out = "map[" + f.key + "]" + f.nested
+	case kindStructValuesMap:
+		out = "map[" + f.key + "]blaze.Values"
+	default:
+		out = "blaze.Tdf"
+	}
+	if f.pointer() {
+		return "*" + out
+	}
+	return out
+}
+
+// tdfType is the type the value is decoded as
+func (f genField) tdfType() string {
+	switch f.kind {
+	case kindScalar, kindSlice:
+		return "blaze." + scalars[f.Type].tdf
+	case kindStruct, kindStructValues:
+		return "blaze.StructTdf"
+	case kindUnion:
+		return "blaze.UnionTdf"
+	case kindList:
+		return "blaze.List[" + f.item + "]"
+	case kindStructList, kindStructValuesList:
+		return "blaze.List[blaze.StructTdf]"
+	case kindMap:
+		return "blaze.Map[" + f.key + ", " + f.item + "]"
+	case kindStructMap, kindStructValuesMap:
+		return "blaze.Map[" + f.key + ", blaze.StructTdf]"
+	default:
+		return "blaze.Tdf"
+	}
+}
+
+type generator struct {
+	out       bytes.Buffer
+	usesTypes bool
+	// names holds the names of the generated types
+	names map[string]bool
+	// pending are the nested types still to be written
+	pending []pendingType
+}
+
+type pendingType struct {
+	name   string
+	doc    string
+	fields []blaze.Field
+	union  bool
+}
+
+// generate creates the source of a file in the package holding the types
+// and handlers of the components
+func generate(pkg string, components []component, register bool) ([]byte, error) {
+	g := &generator{names: map[string]bool{}}
+	for _, c := range components {
+		if err := g.component(c); err != nil {
+			return nil, err
+		}
+	}
+	if register {
+		g.registration(components)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by schemagen. DO NOT EDIT.\n\n")
+	out.WriteString("package " + pkg + "\n\nimport (\n")
+	if g.usesHandlers(components) {
+		out.WriteString("\t\"context\"\n\n")
+	}
+	out.WriteString("\t\"github.com/jacobtread/gomes/blaze\"\n")
+	if g.usesTypes {
+		out.WriteString("\t\"github.com/jacobtread/gomes/types\"\n")
+	}
+	out.WriteString(")\n")
+	out.Write(g.out.Bytes())
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), fmt.Errorf("generated invalid source: %w", err)
+	}
+	return source, nil
+}
+
+func (g *generator) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&g.out, format, args...)
+}
+
+// exported turns a name such as preAuth or *notifyGameUpdated into an
+// exported go name
+func exported(name string) string {
+	var out strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			out.WriteRune(r)
+		}
+	}
+	text := out.String()
+	if text == "" {
+		return ""
+	}
+	if unicode.IsDigit(rune(text[0])) {
+		text = "X" + text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// fieldName is the name of the struct field for a label
+func fieldName(label blaze.Label) string {
+	name := string(label)
+	if unicode.IsDigit(rune(name[0])) {
+		return "L" + name
+	}
+	return name
+}
+
+// command is a command or notification and its schemas. Messages with an
+// open schema have no schema here so only the id of the command is written
+type command struct {
+	id           uint16
+	name         string
+	doc          string
+	notification bool
+	request      *blaze.Schema
+	response     *blaze.Schema
+	notify       *blaze.Schema
+}
+
+// commands groups the schemas of a component by command keeping the order
+func commands(c component) []*command {
+	var out []*command
+	find := func(schema *blaze.Schema) *command {
+		notification := schema.Type&0xF000 == blaze.NotificationType
+		for _, cmd := range out {
+			if cmd.id == schema.Command && cmd.notification == notification {
+				if cmd.doc == "" {
+					cmd.doc = schema.Doc
+				}
+				return cmd
+			}
+		}
+		name := exported(schema.Name)
+		if name == "" {
+			name = fmt.Sprintf("Command%X", schema.Command)
+		}
+		cmd := &command{id: schema.Command, name: name, doc: schema.Doc, notification: notification}
+		out = append(out, cmd)
+		return cmd
+	}
+	for _, schema := range c.Schemas {
+		if schema.Open {
+			find(schema)
+			continue
+		}
+		switch schema.Type & 0xF000 {
+		case blaze.RequestType:
+			find(schema).request = schema
+		case blaze.ResponseType:
+			find(schema).response = schema
+		case blaze.NotificationType:
+			find(schema).notify = schema
+		}
+	}
+	return out
+}
+
+// claim reserves a type name failing when it's already used
+func (g *generator) claim(name string) error {
+	if g.names[name] {
+		return fmt.Errorf("more than one type is named %s", name)
+	}
+	g.names[name] = true
+	return nil
+}
+
+func (g *generator) component(c component) error {
+	prefix := exported(c.Name)
+	cmds := commands(c)
+
+	g.printf("\n// %s component and the commands and notifications of it\nconst (\n", c.Name)
+	g.printf("%sComponent uint16 = 0x%X\n", prefix, c.Id)
+	for _, cmd := range cmds {
+		if err := g.claim(prefix + cmd.name); err != nil {
+			return err
+		}
+		g.printf("%s%s uint16 = 0x%X\n", prefix, cmd.name, cmd.id)
+	}
+	g.printf(")\n")
+
+	for _, cmd := range cmds {
+		messages := []struct {
+			schema *blaze.Schema
+			name   string
+			what   string
+		}{
+			{cmd.request, cmd.name + "Request", "request"},
+			{cmd.response, cmd.name + "Response", "response"},
+			{cmd.notify, cmd.name, "notification"},
+		}
+		for _, message := range messages {
+			if message.schema == nil {
+				continue
+			}
+			doc := fmt.Sprintf("%s is the %s of %s %s", message.name, message.what, c.Name, message.schema.Name)
+			if message.schema.Doc != "" {
+				doc += " which " + message.schema.Doc
+			}
+			if err := g.message(message.name, doc, message.schema.Fields, false); err != nil {
+				return err
+			}
+		}
+	}
+	g.handler(prefix, c.Name, cmds)
+	return nil
+}
+
+// message writes a struct type for the fields along with the types of
+// nested structs and unions
+func (g *generator) message(name string, doc string, fields []blaze.Field, union bool) error {
+	g.pending = append(g.pending, pendingType{name: name, doc: doc, fields: fields, union: union})
+	for len(g.pending) > 0 {
+		next := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.claim(next.name); err != nil {
+			return err
+		}
+		g.writeType(next)
+	}
+	return nil
+}
+
+// analyse works out how each field is stored queueing nested types
+func (g *generator) analyse(t pendingType) []genField {
+	out := make([]genField, len(t.fields))
+	for i, field := range t.fields {
+		f := genField{Field: field, name: fieldName(field.Label), optional: field.Optional || t.union}
+		nested := t.name + f.name
+		describe := field.Doc
+		if describe == "" {
+			describe = "the " + string(field.Label) + " field of " + t.name
+		}
+		switch field.Type {
+		case blaze.IntType, blaze.StringType, blaze.FloatType, blaze.PairType, blaze.TripleType:
+			f.kind = kindScalar
+		case blaze.BlobType, blaze.VarIntListType:
+			f.kind = kindSlice
+		case blaze.StructType:
+			f.kind = kindStructValues
+			if field.Fields != nil {
+				f.kind, f.nested = kindStruct, nested
+				g.pending = append(g.pending, pendingType{name: nested, doc: nested + " is " + describe, fields: field.Fields})
+			}
+		case blaze.UnionType:
+			f.kind = kindRaw
+			if field.Fields != nil {
+				f.kind, f.nested = kindUnion, nested
+				g.pending = append(g.pending, pendingType{name: nested, doc: nested + " is " + describe, fields: field.Fields, union: true})
+			}
+		case blaze.ListType:
+			f.kind = kindRaw
+			if isItemScalar(field.ItemType) {
+				f.kind, f.item = kindList, scalars[field.ItemType].goType
+			} else if field.ItemType == blaze.StructType {
+				f.kind = kindStructValuesList
+				if field.Fields != nil {
+					f.kind, f.nested = kindStructList, nested
+					g.pending = append(g.pending, pendingType{name: nested, doc: nested + " is an item of " + describe, fields: field.Fields})
+				}
+			}
+		case blaze.PairListType:
+			f.kind = kindRaw
+			if field.KeyType != blaze.IntType && field.KeyType != blaze.StringType {
+				break
+			}
+			f.key = scalars[field.KeyType].goType
+			if isItemScalar(field.ItemType) {
+				f.kind, f.item = kindMap, scalars[field.ItemType].goType
+			} else if field.ItemType == blaze.StructType {
+				f.kind = kindStructValuesMap
+				if field.Fields != nil {
+					f.kind, f.nested = kindStructMap, nested
+					g.pending = append(g.pending, pendingType{name: nested, doc: nested + " is a value of " + describe, fields: field.Fields})
+				}
+			}
+		default:
+			f.kind = kindRaw
+		}
+		if strings.HasPrefix(f.goType(), "types.") || strings.Contains(f.item, "types.") {
+			g.usesTypes = true
+		}
+		out[i] = f
+	}
+	return out
+}
+
+func (g *generator) writeType(t pendingType) {
+	fields := g.analyse(t)
+	g.printf("\n// %s\n", t.doc)
+	g.printf("type %s struct {\n", t.name)
+	if t.union {
+		g.printf("// Type is the union type written before the value\nType blaze.TdfType\n")
+	}
+	for _, f := range fields {
+		if f.Doc != "" {
+			g.printf("// %s\n", f.Doc)
+		}
+		g.printf("%s %s\n", f.name, f.goType())
+	}
+	g.printf("}\n")
+
+	if t.union {
+		g.printf("\n// Values encodes the set values of the union. Only the first is written\n")
+	} else {
+		g.printf("\n// Values encodes the %s as a list of values\n", t.name)
+	}
+	g.printf("func (m *%s) Values() []blaze.Tdf {\n", t.name)
+	if len(fields) == 0 {
+		g.printf("return nil\n}\n")
+	} else {
+		g.printf("values := make([]blaze.Tdf, 0, %d)\n", len(fields))
+		for _, f := range fields {
+			g.encode(f)
+		}
+		g.printf("return values\n}\n")
+	}
+
+	g.printf("\n// Decode sets the %s from decoded values\n", t.name)
+	g.printf("func (m *%s) Decode(values blaze.Values) error {\n", t.name)
+	for _, f := range fields {
+		g.decode(f)
+	}
+	g.printf("return nil\n}\n")
+}
+
+// encode writes the code appending the field to values
+func (g *generator) encode(f genField) {
+	value := "m." + f.name
+	if f.pointer() && f.kind == kindScalar {
+		value = "*" + value
+	}
+	label := fmt.Sprintf("%q", f.Label)
+	var expr string
+	switch f.kind {
+	case kindScalar, kindSlice:
+		expr = fmt.Sprintf("blaze.%s(%s, %s)", scalars[f.Type].constructor, label, value)
+	case kindStruct:
+		expr = fmt.Sprintf("blaze.NewStruct(%s, %s.Values()...)", label, value)
+	case kindStructValues:
+		expr = fmt.Sprintf("blaze.NewStruct(%s, %s...)", label, value)
+	case kindUnion:
+		expr = fmt.Sprintf("blaze.UnionOf(%s, %s.Type, %s.Values())", label, value, value)
+	case kindList:
+		expr = fmt.Sprintf("blaze.NewList(%s, %s)", label, value)
+	case kindStructList:
+		expr = fmt.Sprintf("blaze.NewStructList(%s, %s, (*%s).Values)", label, value, f.nested)
+	case kindStructValuesList:
+		expr = fmt.Sprintf("blaze.NewStructList(%s, %s, blaze.ValuesOf)", label, value)
+	case kindMap:
+		expr = fmt.Sprintf("blaze.NewSortedMap(%s, %s)", label, value)
+	case kindStructMap:
+		expr = fmt.Sprintf("blaze.NewStructMap(%s, %s, (*%s).Values)", label, value, f.nested)
+	case kindStructValuesMap:
+		expr = fmt.Sprintf("blaze.NewStructMap(%s, %s, blaze.ValuesOf)", label, value)
+	default:
+		expr = value
+	}
+	// Optional values are left out when nil. Required values are always
+	// written as nil slices and maps are written empty
+	if f.optional || f.kind == kindRaw {
+		g.printf("if m.%s != nil {\nvalues = append(values, %s)\n}\n", f.name, expr)
+		return
+	}
+	g.printf("values = append(values, %s)\n", expr)
+}
+
+// decode writes the code setting the field from values
+func (g *generator) decode(f genField) {
+	g.printf("if v, ok, err := blaze.DecodeField[%s](values, %q, %t); err != nil {\nreturn err\n} else if ok {\n",
+		f.tdfType(), f.Label, f.optional)
+	switch f.kind {
+	case kindScalar:
+		if f.pointer() {
+			g.printf("m.%s = &v.%s\n", f.name, scalars[f.Type].field)
+		} else {
+			g.printf("m.%s = v.%s\n", f.name, scalars[f.Type].field)
+		}
+	case kindSlice:
+		g.printf("m.%s = v.%s\n", f.name, scalars[f.Type].field)
+	case kindStruct:
+		if f.pointer() {
+			g.printf("m.%s = &%s{}\n", f.name, f.nested)
+		}
+		g.printf("if err := m.%s.Decode(v.Values); err != nil {\nreturn blaze.NestedError(%q, err)\n}\n", f.name, f.Label)
+	case kindStructValues, kindList:
+		g.printf("m.%s = v.Values\n", f.name)
+	case kindUnion:
+		if f.pointer() {
+			g.printf("m.%s = &%s{}\n", f.name, f.nested)
+		}
+		g.printf("m.%s.Type = v.Type\n", f.name)
+		g.printf("if v.Content != nil {\nif err := m.%s.Decode(blaze.Values{v.Content}); err != nil {\nreturn blaze.NestedError(%q, err)\n}\n}\n", f.name, f.Label)
+	case kindStructList:
+		g.printf("m.%s = make([]%s, len(v.Values))\n", f.name, f.nested)
+		g.printf("for i, item := range v.Values {\nif err := m.%s[i].Decode(item.Values); err != nil {\nreturn blaze.NestedItemError(%q, i, err)\n}\n}\n", f.name, f.Label)
+	case kindStructValuesList:
+		g.printf("m.%s = make([]blaze.Values, len(v.Values))\n", f.name)
+		g.printf("for i, item := range v.Values {\nm.%s[i] = item.Values\n}\n", f.name)
+	case kindMap:
+		g.printf("m.%s = make(%s, len(v.Keys))\n", f.name, f.goType())
+		g.printf("for i, key := range v.Keys {\nm.%s[key] = v.Values[i]\n}\n", f.name)
+	case kindStructMap:
+		g.printf("m.%s = make(%s, len(v.Keys))\n", f.name, f.goType())
+		g.printf("for i, key := range v.Keys {\nvar item %s\nif err := item.Decode(v.Values[i].Values); err != nil {\nreturn blaze.NestedItemError(%q, key, err)\n}\nm.%s[key] = item\n}\n",
+			f.nested, f.Label, f.name)
+	case kindStructValuesMap:
+		g.printf("m.%s = make(%s, len(v.Keys))\n", f.name, f.goType())
+		g.printf("for i, key := range v.Keys {\nm.%s[key] = v.Values[i].Values\n}\n", f.name)
+	default:
+		g.printf("m.%s = v\n", f.name)
+	}
+	g.printf("}\n")
+}
+
+// handled are the commands with both a request and a response
+func handled(cmds []*command) []*command {
+	var out []*command
+	for _, cmd := range cmds {
+		if cmd.request != nil && cmd.response != nil {
+			out = append(out, cmd)
+		}
+	}
+	return out
+}
+
+func (g *generator) usesHandlers(components []component) bool {
+	for _, c := range components {
+		if len(handled(commands(c))) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// handler writes the handler interface of a component along with a stub
+// implementation and a function passing requests to a handler
+func (g *generator) handler(prefix string, name string, cmds []*command) {
+	cmds = handled(cmds)
+	if len(cmds) == 0 {
+		return
+	}
+	signature := func(cmd *command, named bool) string {
+		if named {
+			return fmt.Sprintf("%s(ctx context.Context, request *%sRequest) (*%sResponse, error)", cmd.name, cmd.name, cmd.name)
+		}
+		return fmt.Sprintf("%s(context.Context, *%sRequest) (*%sResponse, error)", cmd.name, cmd.name, cmd.name)
+	}
+
+	g.printf("\n// %sHandler handles the requests of the %s component\n", prefix, name)
+	g.printf("type %sHandler interface {\n", prefix)
+	for _, cmd := range cmds {
+		if cmd.doc != "" {
+			g.printf("// %s %s\n", cmd.name, cmd.doc)
+		}
+		g.printf("%s\n", signature(cmd, true))
+	}
+	g.printf("}\n")
+
+	g.printf("\n// Unimplemented%sHandler can be embedded in a %sHandler so that the\n", prefix, prefix)
+	g.printf("// commands without a method fail with blaze.ErrUnimplemented\n")
+	g.printf("type Unimplemented%sHandler struct{}\n", prefix)
+	for _, cmd := range cmds {
+		g.printf("\nfunc (Unimplemented%sHandler) %s {\nreturn nil, blaze.ErrUnimplemented\n}\n", prefix, signature(cmd, false))
+	}
+
+	g.printf("\n// Dispatch%s decodes the request in the packet, passes it to the handler\n", prefix)
+	g.printf("// and encodes the response. Packets for other commands are an error\n")
+	g.printf("func Dispatch%s(ctx context.Context, handler %sHandler, packet *blaze.Packet) ([]blaze.Tdf, error) {\n", prefix, prefix)
+	g.printf("if packet.Component != %sComponent {\nreturn nil, blaze.ErrUnknownCommand\n}\n", prefix)
+	g.printf("switch packet.Command {\n")
+	for _, cmd := range cmds {
+		g.printf("case %s%s:\n", prefix, cmd.name)
+		g.printf("request := &%sRequest{}\n", cmd.name)
+		g.printf("if err := request.Decode(packet.ReadContent()); err != nil {\nreturn nil, err\n}\n")
+		g.printf("response, err := handler.%s(ctx, request)\n", cmd.name)
+		g.printf("if err != nil || response == nil {\nreturn nil, err\n}\n")
+		g.printf("return response.Values(), nil\n")
+	}
+	g.printf("}\nreturn nil, blaze.ErrUnknownCommand\n}\n")
+}
+
+// registration writes an init function adding the schemas to the registry
+func (g *generator) registration(components []component) {
+	g.printf("\nfunc init() {\n")
+	for _, c := range components {
+		for _, schema := range c.Schemas {
+			g.printf("blaze.RegisterSchema(blaze.Schema{Component: 0x%X, Command: 0x%X, Type: %s, Name: %q, Doc: %q, Fields: %s})\n",
+				schema.Component, schema.Command, messageTypeConstants[schema.Type&0xF000], schema.Name, schema.Doc, fieldsSource(schema.Fields))
+		}
+	}
+	g.printf("}\n")
+}
+
+// fieldsSource is the source of a slice literal holding the fields
+func fieldsSource(fields []blaze.Field) string {
+	if fields == nil {
+		return "nil"
+	}
+	var out strings.Builder
+	out.WriteString("[]blaze.Field{\n")
+	for _, field := range fields {
+		fmt.Fprintf(&out, "{Label: %q, Type: %s", field.Label, typeSource(field.Type))
+		if field.Type == blaze.PairListType {
+			fmt.Fprintf(&out, ", KeyType: %s", typeSource(field.KeyType))
+		}
+		if field.Type == blaze.ListType || field.Type == blaze.PairListType {
+			fmt.Fprintf(&out, ", ItemType: %s", typeSource(field.ItemType))
+		}
+		if field.Optional {
+			out.WriteString(", Optional: true")
+		}
+		if field.Doc != "" {
+			fmt.Fprintf(&out, ", Doc: %q", field.Doc)
+		}
+		if field.Fields != nil {
+			out.WriteString(", Fields: " + fieldsSource(field.Fields))
+		}
+		out.WriteString("},\n")
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+func typeSource(t blaze.TdfType) string {
+	if name, ok := typeConstants[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("blaze.TdfType(%d)", t)
+}
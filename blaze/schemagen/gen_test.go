@@ -0,0 +1,176 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+func TestParseYAML(t *testing.T) {
+	value, err := parseYAML(`
+# comment
+name: it's "quoted" # comment
+list:
+- a
+- key: 'b # c'
+  other: "d"
+-
+  - e
+empty:
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"name":  `it's "quoted"`,
+		"list":  []any{"a", map[string]any{"key": "b # c", "other": "d"}, []any{"e"}},
+		"empty": nil,
+	}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("unexpected value %#v", value)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, text := range []string{
+		"a: 1\na: 2",
+		"a:\n  b: 1\n c: 2",
+		"- {a: 1}",
+		"a: [1, 2]",
+		"a: \"open",
+		"a:\n\t- b",
+		"just text",
+	} {
+		if _, err := parseYAML(text); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+}
+
+func TestReadSchemaFile(t *testing.T) {
+	data, err := os.ReadFile("testdata/preauth.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	components, err := readSchemaFile(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 1 || components[0].Id != 0x9 || len(components[0].Schemas) != 2 {
+		t.Fatalf("unexpected components %+v", components)
+	}
+	request, response := components[0].Schemas[0], components[0].Schemas[1]
+	if request.Type != blaze.RequestType || response.Type != blaze.ResponseType || request.Command != 0x7 {
+		t.Errorf("unexpected schemas %+v %+v", request, response)
+	}
+	var labels []blaze.Label
+	for _, field := range request.Fields {
+		labels = append(labels, field.Label)
+	}
+	if !reflect.DeepEqual(labels, []blaze.Label{"CDAT", "CINF", "FCCR"}) {
+		t.Errorf("unexpected request fields %v", labels)
+	}
+	conf := response.Fields[1].Fields[0]
+	if conf.Type != blaze.PairListType || conf.KeyType != blaze.StringType || conf.ItemType != blaze.StringType {
+		t.Errorf("unexpected map field %+v", conf)
+	}
+	if !response.Fields[2].Optional || request.Fields[2].Fields[0].Doc != "config id such as BlazeSDK # not a comment" {
+		t.Errorf("unexpected fields %+v", response.Fields)
+	}
+}
+
+func TestReadSchemaFileErrors(t *testing.T) {
+	for text, expected := range map[string]string{
+		"components:\n  - id: 0x9":                         "no name",
+		"components:\n  - name: A\n    id: x":              "invalid id",
+		"components:\n  - name: A\n    id: 1\n    cmds: 1": "unknown key",
+		"components:\n  - name: A\n    id: 1\n    commands:\n      - name: b\n        id: 1\n        request:\n          - label: toolong\n            type: int":  "toolong",
+		"components:\n  - name: A\n    id: 1\n    commands:\n      - name: b\n        id: 1\n        request:\n          - label: LIST\n            type: list":    "list<type>",
+		"components:\n  - name: A\n    id: 1\n    commands:\n      - name: b\n        id: 1\n        request:\n          - label: NUM\n            type: int<int>": "doesn't take types",
+		"components:\n  - name: A\n    id: 1\n    commands:\n      - name: b\n        id: 1\n        request:\n          - label: NUM\n            type: number":   "number",
+	} {
+		if _, err := readSchemaFile(text); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected an error containing %q got %v", expected, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	data, err := os.ReadFile("testdata/preauth.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	components, err := readSchemaFile(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := generate("util", components, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "util_gen.go", source, 0)
+	if err != nil {
+		t.Fatalf("invalid source %v\n%s", err, source)
+	}
+	declared := map[string]bool{}
+	for name := range file.Scope.Objects {
+		declared[name] = true
+	}
+	for _, name := range []string{
+		"UtilComponent", "UtilPreAuth", "PreAuthRequest", "PreAuthRequestCDAT", "PreAuthResponse",
+		"PreAuthResponseCONF", "UtilHandler", "UnimplementedUtilHandler", "DispatchUtil",
+	} {
+		if !declared[name] {
+			t.Errorf("%s isn't declared", name)
+		}
+	}
+	if !strings.Contains(string(source), "blaze.RegisterSchema(") {
+		t.Error("schemas aren't registered")
+	}
+}
+
+func TestGenerateBuiltin(t *testing.T) {
+	components := builtinComponents([]uint16{0x9})
+	if len(components) != 1 || components[0].Name != "Util" {
+		t.Fatalf("unexpected components %+v", components)
+	}
+	source, err := generate("util", components, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "util_gen.go", source, 0); err != nil {
+		t.Fatalf("invalid source %v", err)
+	}
+}
+
+func TestGenerateOpenSchemas(t *testing.T) {
+	source, err := generate("me3", builtinComponents([]uint16{0x4}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "me3_gen.go", source, 0)
+	if err != nil {
+		t.Fatalf("invalid source %v", err)
+	}
+	// Open schemas only get a command id
+	for name, expected := range map[string]bool{
+		"GameManagerCreateGame":           true,
+		"CreateGameRequest":               false,
+		"GameManagerStartMatchmaking":     true,
+		"StartMatchmakingRequest":         false,
+		"StartMatchmakingResponse":        true,
+		"GameManagerNotifyGameSetup":      true,
+		"NotifyGameSetup":                 false,
+		"NotifyGameStateChange":           true,
+		"UnimplementedGameManagerHandler": true,
+	} {
+		if declared := file.Scope.Lookup(name) != nil; declared != expected {
+			t.Errorf("%s declared %t", name, declared)
+		}
+	}
+}
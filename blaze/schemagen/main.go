@@ -0,0 +1,75 @@
+// Command schemagen generates go types for the payloads of Blaze commands
+// from schemas. Each request, response and notification becomes a struct
+// with Values and Decode methods using the blaze package and each
+// component gets a handler interface, a stub implementation of it and a
+// function passing request packets to a handler.
+//
+// The schemas are read from a schema file described by readSchemaFile or
+// taken from the schemas registered in the blaze package
+//
+//	//go:generate go run github.com/jacobtread/gomes/blaze/schemagen -in util.yaml -out util_gen.go
+//	//go:generate go run github.com/jacobtread/gomes/blaze/schemagen -builtin -components 0x9,0x5 -out me3_gen.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	in := flag.String("in", "", "schema file to read")
+	builtin := flag.Bool("builtin", false, "use the schemas registered in the blaze package instead of a file")
+	ids := flag.String("components", "", "comma separated ids of the registered components to use (default all of them)")
+	out := flag.String("out", "", "go file to write")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the go file (default the package running go generate)")
+	register := flag.Bool("register", false, "register the schemas of the file with the blaze package")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("schemagen: ")
+	if err := run(*in, *builtin, *ids, *out, *pkg, *register); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(in string, builtin bool, ids string, out string, pkg string, register bool) error {
+	if out == "" || pkg == "" {
+		return errors.New("-out and -package are required")
+	}
+	var components []component
+	switch {
+	case builtin && in == "":
+		var filter []uint16
+		if ids != "" {
+			for _, id := range strings.Split(ids, ",") {
+				parsed, err := parseId(strings.TrimSpace(id))
+				if err != nil {
+					return err
+				}
+				filter = append(filter, parsed)
+			}
+		}
+		components = builtinComponents(filter)
+	case in != "" && !builtin:
+		data, err := os.ReadFile(in)
+		if err != nil {
+			return err
+		}
+		if components, err = readSchemaFile(string(data)); err != nil {
+			return fmt.Errorf("%s: %w", in, err)
+		}
+	default:
+		return errors.New("expected either -in or -builtin")
+	}
+	if register && builtin {
+		return errors.New("builtin schemas are already registered")
+	}
+	source, err := generate(pkg, components, register)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, source, 0644)
+}
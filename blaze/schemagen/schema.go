@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+// component is a component along with the schemas of its commands and
+// notifications
+type component struct {
+	Name    string
+	Id      uint16
+	Schemas []*blaze.Schema
+}
+
+// readSchemaFile reads the components described by a schema file such as
+//
+//	components:
+//	  - name: Util
+//	    id: 0x9
+//	    commands:
+//	      - name: fetchClientConfig
+//	        id: 0x1
+//	        doc: fetches a named client config
+//	        request:
+//	          - label: CFID
+//	            type: string
+//	            doc: config id such as ME3_DATA
+//	        response:
+//	          - label: CONF
+//	            type: map<string, string>
+//	    notifications:
+//	      - name: NotifyExample
+//	        id: 0x1
+//	        fields:
+//	          - label: USER
+//	            type: struct
+//	            optional: true
+//	            fields:
+//	              - label: ID
+//	                type: int
+//
+// Requests and responses that are left out have no values
+func readSchemaFile(data string) ([]component, error) {
+	root, err := parseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	top, err := asMapping("file", root, "components")
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := asSequence("components", top["components"])
+	if err != nil {
+		return nil, err
+	}
+	var out []component
+	for i, node := range nodes {
+		c, err := readComponent(node)
+		if err != nil {
+			return nil, fmt.Errorf("component %d: %w", i, err)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func readComponent(node any) (component, error) {
+	fields, err := asMapping("component", node, "name", "id", "commands", "notifications")
+	if err != nil {
+		return component{}, err
+	}
+	c := component{Name: asString(fields["name"])}
+	if c.Name == "" {
+		return c, fmt.Errorf("component has no name")
+	}
+	if c.Id, err = parseId(fields["id"]); err != nil {
+		return c, fmt.Errorf("%s: %w", c.Name, err)
+	}
+	commands, err := asSequence(c.Name+" commands", fields["commands"])
+	if err != nil {
+		return c, err
+	}
+	for _, node := range commands {
+		request, response, err := readMessages(c.Id, node, "request", "response")
+		if err != nil {
+			return c, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		request.Type, response.Type = blaze.RequestType, blaze.ResponseType
+		c.Schemas = append(c.Schemas, request, response)
+	}
+	notifications, err := asSequence(c.Name+" notifications", fields["notifications"])
+	if err != nil {
+		return c, err
+	}
+	for _, node := range notifications {
+		notification, _, err := readMessages(c.Id, node, "fields", "")
+		if err != nil {
+			return c, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		notification.Type = blaze.NotificationType
+		c.Schemas = append(c.Schemas, notification)
+	}
+	return c, nil
+}
+
+// readMessages reads a command or notification. Commands have fields for
+// both the request and the response while notifications only have one
+func readMessages(componentId uint16, node any, first string, second string) (*blaze.Schema, *blaze.Schema, error) {
+	keys := []string{"name", "id", "doc", first}
+	if second != "" {
+		keys = append(keys, second)
+	}
+	fields, err := asMapping("command", node, keys...)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := asString(fields["name"])
+	if name == "" {
+		return nil, nil, fmt.Errorf("command has no name")
+	}
+	id, err := parseId(fields["id"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	read := func(key string) (*blaze.Schema, error) {
+		values, err := readFields(name+" "+key, fields[key])
+		if err != nil {
+			return nil, err
+		}
+		return &blaze.Schema{Component: componentId, Command: id, Name: name, Doc: asString(fields["doc"]), Fields: values}, nil
+	}
+	a, err := read(first)
+	if err != nil || second == "" {
+		return a, nil, err
+	}
+	b, err := read(second)
+	return a, b, err
+}
+
+func readFields(path string, node any) ([]blaze.Field, error) {
+	nodes, err := asSequence(path, node)
+	if err != nil {
+		return nil, err
+	}
+	var out []blaze.Field
+	for _, node := range nodes {
+		field, err := readField(path, node)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, field)
+	}
+	// Fields are written in tag order
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Label.Tag() < out[j].Label.Tag() })
+	return out, nil
+}
+
+func readField(path string, node any) (blaze.Field, error) {
+	values, err := asMapping(path+" field", node, "label", "type", "doc", "optional", "fields")
+	if err != nil {
+		return blaze.Field{}, err
+	}
+	label, err := blaze.ParseLabel(asString(values["label"]))
+	if err != nil {
+		return blaze.Field{}, fmt.Errorf("%s: %w", path, err)
+	}
+	path += "." + string(label)
+	field := blaze.Field{Label: label, Doc: asString(values["doc"])}
+	if err := parseFieldType(&field, asString(values["type"])); err != nil {
+		return field, fmt.Errorf("%s: %w", path, err)
+	}
+	switch optional := asString(values["optional"]); optional {
+	case "", "false":
+	case "true":
+		field.Optional = true
+	default:
+		return field, fmt.Errorf("%s: optional must be true or false not %q", path, optional)
+	}
+	if values["fields"] != nil {
+		if field.Fields, err = readFields(path, values["fields"]); err != nil {
+			return field, err
+		}
+	}
+	return field, nil
+}
+
+// parseFieldType parses types such as int, list<struct> and map<string, int>
+func parseFieldType(field *blaze.Field, text string) error {
+	name, params := text, ""
+	if open := strings.IndexByte(text, '<'); open >= 0 {
+		if !strings.HasSuffix(text, ">") {
+			return fmt.Errorf("type %q is missing a closing >", text)
+		}
+		name, params = text[:open], text[open+1:len(text)-1]
+	}
+	t, err := blaze.ParseTdfType(strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+	field.Type = t
+	var types []blaze.TdfType
+	if params != "" {
+		for _, param := range strings.Split(params, ",") {
+			parsed, err := blaze.ParseTdfType(strings.TrimSpace(param))
+			if err != nil {
+				return err
+			}
+			types = append(types, parsed)
+		}
+	}
+	switch {
+	case t == blaze.ListType && len(types) == 1:
+		field.ItemType = types[0]
+	case t == blaze.PairListType && len(types) == 2:
+		field.KeyType, field.ItemType = types[0], types[1]
+	case t == blaze.ListType:
+		return fmt.Errorf("lists are written as list<type>")
+	case t == blaze.PairListType:
+		return fmt.Errorf("maps are written as map<key type, value type>")
+	case len(types) > 0:
+		return fmt.Errorf("%s doesn't take types", name)
+	}
+	return nil
+}
+
+func parseId(node any) (uint16, error) {
+	value, err := strconv.ParseUint(asString(node), 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", asString(node))
+	}
+	return uint16(value), nil
+}
+
+func asString(node any) string {
+	value, _ := node.(string)
+	return value
+}
+
+// asMapping checks that the node is a mapping with only the provided keys
+func asMapping(path string, node any, keys ...string) (map[string]any, error) {
+	out, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a mapping", path)
+	}
+	for key := range out {
+		known := false
+		for _, allowed := range keys {
+			known = known || key == allowed
+		}
+		if !known {
+			return nil, fmt.Errorf("%s has unknown key %q", path, key)
+		}
+	}
+	return out, nil
+}
+
+// asSequence checks that the node is a sequence. Missing sequences are empty
+func asSequence(path string, node any) ([]any, error) {
+	if node == nil {
+		return nil, nil
+	}
+	out, ok := node.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a sequence", path)
+	}
+	return out, nil
+}
+
+// builtinComponents groups the schemas in the registry by component. When
+// ids are provided only those components are included
+func builtinComponents(ids []uint16) []component {
+	var out []component
+	for _, schema := range blaze.Schemas() {
+		if len(ids) > 0 && !containsId(ids, schema.Component) {
+			continue
+		}
+		if len(out) == 0 || out[len(out)-1].Id != schema.Component {
+			out = append(out, component{Name: componentName(schema.Component), Id: schema.Component})
+		}
+		out[len(out)-1].Schemas = append(out[len(out)-1].Schemas, schema)
+	}
+	return out
+}
+
+func containsId(ids []uint16, id uint16) bool {
+	for _, value := range ids {
+		if value == id {
+			return true
+		}
+	}
+	return false
+}
+
+// componentName turns names such as "Association Lists Component" into
+// AssociationLists
+func componentName(id uint16) string {
+	name, ok := blaze.ComponentNames[id]
+	if !ok {
+		return fmt.Sprintf("Component%X", id)
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(name, " Component"), " ", "")
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The schema files are written in the subset of YAML made of block
+// mappings, block sequences, plain or quoted scalars and comments. Flow
+// collections, anchors and multi line scalars are not supported. Mappings
+// are decoded as map[string]any, sequences as []any and scalars as strings
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses the document into maps, slices and strings
+func parseYAML(data string) (any, error) {
+	p := &yamlParser{}
+	for i, text := range strings.Split(data, "\n") {
+		text = stripComment(strings.TrimRight(text, " \t\r"))
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs can't be used for indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{number: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	out, err := p.block(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return out, nil
+}
+
+// stripComment removes a comment that isn't within a quoted scalar
+func stripComment(text string) string {
+	var quote rune
+	for i, c := range text {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && startsScalar(text[:i]):
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return strings.TrimRight(text[:i], " \t")
+		}
+	}
+	return text
+}
+
+// startsScalar checks whether a scalar starts after the text which is the
+// case at the start of a line and after a colon or dash
+func startsScalar(before string) bool {
+	trimmed := strings.TrimRight(before, " ")
+	if trimmed == "" {
+		return true
+	}
+	last := trimmed[len(trimmed)-1]
+	return len(trimmed) < len(before) && (last == ':' || last == '-')
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	line := p.lines[len(p.lines)-1]
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos]
+	}
+	return fmt.Errorf("line %d: %s", line.number, fmt.Sprintf(format, args...))
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// block parses the sequence or mapping starting at the current line
+func (p *yamlParser) block(indent int) (any, error) {
+	if isSequenceItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent)
+}
+
+func (p *yamlParser) sequence(indent int) ([]any, error) {
+	out := []any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		if rest == "" {
+			p.pos++
+			item, err := p.nested(indent, false)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+			continue
+		}
+		if _, _, ok := splitKey(rest); ok {
+			// The item is a mapping starting on the same line as the dash
+			// so the line is read again as the first key of the mapping
+			p.lines[p.pos] = yamlLine{number: line.number, indent: line.indent + len(line.text) - len(rest), text: rest}
+			item, err := p.mapping(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+			continue
+		}
+		value, err := parseScalar(rest)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		out = append(out, value)
+		p.pos++
+	}
+	return out, nil
+}
+
+func (p *yamlParser) mapping(indent int) (map[string]any, error) {
+	out := map[string]any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isSequenceItem(p.lines[p.pos].text) {
+		key, rest, ok := splitKey(p.lines[p.pos].text)
+		if !ok {
+			return nil, p.errorf("expected a key followed by a colon")
+		}
+		if _, exists := out[key]; exists {
+			return nil, p.errorf("%s is set twice", key)
+		}
+		if rest != "" {
+			value, err := parseScalar(rest)
+			if err != nil {
+				return nil, p.errorf("%v", err)
+			}
+			out[key] = value
+			p.pos++
+			continue
+		}
+		p.pos++
+		value, err := p.nested(indent, true)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+// nested parses the block after a key or dash with nothing following it.
+// Sequences within mappings may be at the same indent as the key
+func (p *yamlParser) nested(indent int, inMapping bool) (any, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	next := p.lines[p.pos]
+	if next.indent > indent || (inMapping && next.indent == indent && isSequenceItem(next.text)) {
+		return p.block(next.indent)
+	}
+	return nil, nil
+}
+
+// splitKey splits a mapping line into its key and the text after the colon
+func splitKey(text string) (string, string, bool) {
+	if strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") || strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") {
+		return "", "", false
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			key := strings.TrimSpace(text[:i])
+			return key, strings.TrimSpace(text[i+1:]), key != ""
+		}
+	}
+	return "", "", false
+}
+
+func parseScalar(text string) (string, error) {
+	switch {
+	case strings.HasPrefix(text, "\""):
+		out, err := strconv.Unquote(text)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted string %s", text)
+		}
+		return out, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return "", fmt.Errorf("invalid quoted string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{"):
+		return "", fmt.Errorf("flow collections such as %s aren't supported", text)
+	case strings.HasPrefix(text, "&") || strings.HasPrefix(text, "*") || strings.HasPrefix(text, "|") || strings.HasPrefix(text, ">"):
+		return "", fmt.Errorf("%s isn't supported", text)
+	}
+	return text, nil
+}
@@ -0,0 +1,530 @@
+package blaze
+
+// Schemas of the ME3 commands and notifications. The layouts are the ones
+// sent by the ME3 client and by this server. Commands and notifications in
+// CommandNames and NotificationNames without a known layout are registered
+// as open schemas
+
+// registerCommand registers the request and response schemas of a command.
+// Nil fields are an empty payload
+func registerCommand(component uint16, command uint16, doc string, request []Field, response []Field) {
+	RegisterSchema(Schema{Component: component, Command: command, Type: RequestType, Doc: doc, Fields: request})
+	RegisterSchema(Schema{Component: component, Command: command, Type: ResponseType, Doc: doc, Fields: response})
+}
+
+func registerNotification(component uint16, command uint16, doc string, fields ...Field) {
+	RegisterSchema(Schema{Component: component, Command: command, Type: NotificationType, Doc: doc, Fields: fields})
+}
+
+// registerOpen registers an open schema for the message unless it already
+// has a schema
+func registerOpen(component uint16, command uint16, messageType uint16) {
+	if _, ok := LookupSchema(component, command, messageType); !ok {
+		RegisterSchema(Schema{Component: component, Command: command, Type: messageType, Open: true})
+	}
+}
+
+// Fields shared between commands
+var (
+	clientInfoFields = []Field{
+		StringField("BSDK", "version of the Blaze SDK"),
+		StringField("BTIM", "build time of the Blaze SDK"),
+		StringField("CLNT", "client name"),
+		IntField("CPFT", "client platform type").AsOptional(),
+		StringField("CSKU", "client SKU"),
+		StringField("CVER", "client version"),
+		StringField("DSDK", "version of the DirtySDK"),
+		StringField("ENV", "environment such as prod"),
+		UnionField("FPID", "first party id").AsOptional(),
+		IntField("LOC", "locale such as 0x656e5553 for enUS"),
+		StringField("PLAT", "platform such as Windows"),
+	}
+	qosServerFields = []Field{
+		StringField("PSA", "address of the QoS server"),
+		IntField("PSP", "port of the QoS server"),
+		StringField("SNA", "name of the QoS server"),
+	}
+	qosConfigFields = []Field{
+		StructField("BWPS", "bandwidth test server", qosServerFields...),
+		IntField("LNP", "number of latency probes"),
+		MapField("LTPS", StringType, StructType, "latency test servers by name", qosServerFields...),
+		IntField("SVID", "id of the QoS service"),
+	}
+	telemetryFields = []Field{
+		StringField("ADRS", "address of the telemetry server"),
+		IntField("ANON", "whether telemetry is anonymous"),
+		StringField("DISA", "countries telemetry is disabled in"),
+		StringField("FILT", "filter of the telemetry sent"),
+		IntField("LOC", "locale"),
+		StringField("NOOK", "countries telemetry is allowed in"),
+		IntField("PORT", "port of the telemetry server"),
+		IntField("SDLY", "delay in milliseconds between sends"),
+		StringField("SESS", "telemetry session"),
+		StringField("SKEY", "telemetry key"),
+		IntField("SPCT", "percentage of data sent"),
+		StringField("STIM", "telemetry time"),
+	}
+	tickerFields = []Field{
+		StringField("ADRS", "address of the ticker server"),
+		IntField("PORT", "port of the ticker server"),
+		StringField("SKEY", "ticker key holding the player id and server"),
+	}
+	messageFilterFields = []Field{
+		IntField("FLAG", "message flags").AsOptional(),
+		IntField("MGID", "id of a single message").AsOptional(),
+		IntField("PIDX", "page index").AsOptional(),
+		IntField("PSIZ", "page size").AsOptional(),
+		IntField("SMSK", "status mask").AsOptional(),
+		IntField("SORT", "sort order").AsOptional(),
+		TripleField("SRCE", "object id of the sender").AsOptional(),
+		IntField("STAT", "message status").AsOptional(),
+		TripleField("TARG", "object id of the recipient").AsOptional(),
+		IntField("TYPE", "message type").AsOptional(),
+	}
+	messageFields = []Field{
+		IntField("FLAG", "0x1 when the message has been read"),
+		IntField("MGID", "id of the message"),
+		StringField("NAME", "name of the sender"),
+		StructField("PYLD", "message payload",
+			MapField("ATTR", IntType, StringType, "message attributes such as the body"),
+			IntField("FLAG", "message flags"),
+			IntField("STAT", "message status"),
+			IntField("TAG", "message tag"),
+			TripleField("TARG", "object id of the recipient"),
+			IntField("TYPE", "message type"),
+		),
+		TripleField("SRCE", "object id of the sender"),
+		IntField("TIME", "time the message was sent"),
+	}
+	listIdFields = []Field{
+		StringField("LNM", "list name such as friendList"),
+		IntField("TYPE", "list type"),
+	}
+	userFields = []Field{
+		IntField("ID", "player id").AsOptional(),
+		StringField("NAME", "player name").AsOptional(),
+	}
+	memberFields = []Field{
+		StructField("LMID", "list member", StructField("USER", "the member", userFields...)),
+		IntField("TIME", "time the member was added"),
+	}
+	listInfoFields = []Field{
+		TripleField("BOID", "object id of the list owner"),
+		IntField("FLGS", "list flags"),
+		StructField("LID", "list identification", listIdFields...),
+		IntField("LMS", "maximum list size"),
+		IntField("PRID", "id of the paired list"),
+	}
+	listMembersFields = []Field{
+		StructField("INFO", "list information", listInfoFields...),
+		ListField("MEML", StructType, "list members", memberFields...),
+		IntField("OFRC", "offset of the first member"),
+		IntField("TOCT", "total number of members"),
+	}
+	membershipRequest = []Field{
+		StructField("LID", "list identification", listIdFields...),
+		ListField("ULST", StructType, "users to change", userFields...),
+	}
+	listIdsRequest = []Field{
+		ListField("LIDS", StructType, "list identifications", listIdFields...),
+	}
+	personaFields = []Field{
+		StringField("DSNM", "display name of the persona"),
+		IntField("LAST", "time the persona last logged in"),
+		IntField("PID", "persona id"),
+		IntField("STAS", "persona status"),
+		IntField("XREF", "external reference id"),
+		IntField("XTYP", "external reference type"),
+	}
+	sessionFields = []Field{
+		IntField("BUID", "player id"),
+		IntField("FRST", "1 on the first login of the player"),
+		StringField("KEY", "session key"),
+		IntField("LLOG", "time of the last login"),
+		StringField("MAIL", "email address of the player"),
+		StructField("PDTL", "persona the session is logged into", personaFields...),
+		IntField("UID", "player id"),
+	}
+	loginFields = []Field{
+		StringField("LDHT", "host of the legal documents"),
+		IntField("NTOS", "1 when the terms of service must be accepted"),
+		StringField("PCTK", "token the client logs in with next time"),
+		StringField("PRIV", "uri of the privacy policy"),
+		IntField("SPAM", "whether the player accepted marketing emails"),
+		StringField("THST", "host of the terms of service"),
+		StringField("TSUI", "uri of the terms of service"),
+		StringField("TURI", "uri of the terms of service content"),
+	}
+	ipFields = []Field{
+		IntField("IP", "ip address as a number"),
+		IntField("PORT", "port"),
+	}
+	gameIdFields = []Field{
+		IntField("GID", "game id"),
+	}
+	playerRemovedFields = []Field{
+		IntField("CNTX", "context of the removal").AsOptional(),
+		IntField("GID", "game id"),
+		IntField("PID", "id of the removed player"),
+		IntField("REAS", "reason the player was removed"),
+	}
+	leaderboardRequestFields = []Field{
+		MapField("KSUM", StringType, IntType, "key scope values").AsOptional(),
+		StringField("NAME", "leaderboard name such as N7RatingGlobal"),
+		IntField("POFF", "period offset").AsOptional(),
+		IntField("TIME", "period time").AsOptional(),
+		StructField("USET", "set of users to rank").AsOptional(),
+	}
+	leaderboardResponse = []Field{
+		ListField("LDLS", StructType, "leaderboard rows",
+			StringField("ENAM", "name of the player"),
+			IntField("ENID", "player id"),
+			IntField("RANK", "rank of the player"),
+			StringField("RSTA", "value of the ranked stat"),
+			IntField("RWFG", "raw stat flags"),
+			UnionField("RWST", "raw stats").AsOptional(),
+			ListField("STAT", StringType, "stat values of the row"),
+			IntField("UATT", "user attributes"),
+		),
+	}
+)
+
+const (
+	authenticationComponent uint16 = 0x1
+	gameManagerComponent    uint16 = 0x4
+	redirectorComponent     uint16 = 0x5
+	statsComponent          uint16 = 0x7
+	utilComponent           uint16 = 0x9
+	messagingComponent      uint16 = 0xF
+	associationComponent    uint16 = 0x19
+	userSessionsComponent   uint16 = 0x7802
+)
+
+func init() {
+	registerCommand(redirectorComponent, 0x1, "finds the main server the client connects to",
+		append(append([]Field{}, clientInfoFields...),
+			StringField("NAME", "service name such as masseffect-3-pc"),
+			StringField("PROF", "profile"),
+		),
+		[]Field{
+			UnionField("ADDR", "address of the main server",
+				StructField("VALU", "ip address",
+					StringField("HOST", "host name"),
+					IntField("IP", "ip address as a number"),
+					IntField("PORT", "port"),
+				),
+			),
+			StringField("AMSG", "message shown to the player").AsOptional(),
+			IntField("SECU", "1 when the main server uses SSL"),
+			IntField("XDNS", "whether the client resolves the host itself"),
+		},
+	)
+
+	registerCommand(utilComponent, 0x1, "fetches a named client config",
+		[]Field{StringField("CFID", "config id such as ME3_DATA")},
+		[]Field{MapField("CONF", StringType, StringType, "config values by key")},
+	)
+	registerCommand(utilComponent, 0x2, "keeps the connection alive",
+		nil,
+		[]Field{IntField("STIM", "server time in seconds").AsOptional()},
+	)
+	registerCommand(utilComponent, 0x5, "finds the telemetry server", nil, telemetryFields)
+	registerCommand(utilComponent, 0x6, "finds the ticker server", nil, tickerFields)
+	registerCommand(utilComponent, 0x7, "exchanges client and server details before logging in",
+		[]Field{
+			StructField("CDAT", "client data",
+				IntField("IITO", "whether the client is in the opening"),
+				IntField("LANG", "language such as 0x656e for en"),
+				StringField("SVCN", "service name"),
+				IntField("TYPE", "client type"),
+			),
+			StructField("CINF", "client information", append(append([]Field{}, clientInfoFields...),
+				StringField("MAC", "mac address"),
+			)...),
+			StructField("FCCR", "config fetch request",
+				StringField("CFID", "config id such as BlazeSDK"),
+			),
+		},
+		[]Field{
+			IntField("ANON", "whether anonymous users are allowed"),
+			StringField("ASRC", "authentication source"),
+			VarIntsField("CIDS", "ids of the components the server has"),
+			StringField("CNGN", "connection group name"),
+			StructField("CONF", "client config",
+				MapField("CONF", StringType, StringType, "config values such as pingPeriod"),
+			),
+			StringField("INST", "server instance name"),
+			IntField("MINR", "minimum retry delay"),
+			StringField("NASP", "persona namespace"),
+			StringField("PILD", "legal documents platform"),
+			StringField("PLAT", "server platform"),
+			StringField("PTAG", "platform tag"),
+			StructField("QOSS", "QoS config", qosConfigFields...),
+			StringField("RSRC", "registration source"),
+			StringField("SVER", "server version"),
+		},
+	)
+	registerCommand(utilComponent, 0x8, "gives the telemetry and ticker servers after logging in",
+		nil,
+		[]Field{
+			StructField("TELE", "telemetry server", telemetryFields...),
+			StructField("TICK", "ticker server", tickerFields...),
+			StructField("UROP", "user options",
+				IntField("TMOP", "telemetry opt in"),
+				IntField("UID", "player id"),
+			),
+		},
+	)
+	registerCommand(utilComponent, 0xA, "loads a user setting",
+		[]Field{
+			StringField("KEY", "setting key"),
+			IntField("UID", "player id").AsOptional(),
+		},
+		[]Field{StringField("DATA", "setting value")},
+	)
+	registerCommand(utilComponent, 0xB, "saves a user setting",
+		[]Field{
+			StringField("DATA", "setting value"),
+			StringField("KEY", "setting key"),
+			IntField("UID", "player id").AsOptional(),
+		},
+		nil,
+	)
+	registerCommand(utilComponent, 0xC, "loads every user setting",
+		[]Field{IntField("UID", "player id").AsOptional()},
+		[]Field{MapField("SMAP", StringType, StringType, "setting values by key")},
+	)
+	registerCommand(utilComponent, 0x15, "fetches the QoS config", nil, qosConfigFields)
+
+	registerCommand(messagingComponent, 0x1, "sends a message to a player",
+		[]Field{
+			MapField("ATTR", IntType, StringType, "message attributes such as the body"),
+			IntField("FLAG", "message flags"),
+			IntField("STAT", "message status"),
+			IntField("TAG", "message tag"),
+			TripleField("TARG", "object id of the recipient"),
+			IntField("TYPE", "message type"),
+		},
+		[]Field{
+			IntField("MGID", "id of the first message"),
+			ListField("MIDS", IntType, "ids of the messages"),
+		},
+	)
+	countResponse := []Field{IntField("MCNT", "number of messages")}
+	registerCommand(messagingComponent, 0x2, "delivers the matching messages as notifications", messageFilterFields, countResponse)
+	registerCommand(messagingComponent, 0x3, "deletes the matching messages", messageFilterFields, countResponse)
+	registerCommand(messagingComponent, 0x4, "marks the matching messages as read", messageFilterFields, countResponse)
+	registerCommand(messagingComponent, 0x5, "gets messages by id",
+		[]Field{ListField("MIDS", IntType, "ids of the messages")},
+		[]Field{ListField("MSGS", StructType, "the messages", messageFields...)},
+	)
+	registerNotification(messagingComponent, 0x1, "a message for the player", messageFields...)
+
+	membersResponse := []Field{ListField("LMID", StructType, "the members changed", memberFields...)}
+	registerCommand(associationComponent, 0x1, "adds users to a list", membershipRequest, membersResponse)
+	registerCommand(associationComponent, 0x2, "removes users from a list", membershipRequest, membersResponse)
+	registerCommand(associationComponent, 0x3, "removes every user from the lists", listIdsRequest, nil)
+	registerCommand(associationComponent, 0x4, "replaces the users of a list", membershipRequest, membersResponse)
+	registerCommand(associationComponent, 0x5, "gets a list of a player",
+		[]Field{
+			IntField("BID", "id of the list owner, zero for the player").AsOptional(),
+			StructField("LID", "list identification", listIdFields...),
+		},
+		[]Field{StructField("LMEM", "the list and its members", listMembersFields...)},
+	)
+	registerCommand(associationComponent, 0x6, "gets the lists of the player",
+		append(append([]Field{}, listIdsRequest...),
+			IntField("MXRC", "maximum number of members").AsOptional(),
+			IntField("OFRC", "offset of the first member").AsOptional(),
+		),
+		[]Field{ListField("LMAP", StructType, "the lists and their members", listMembersFields...)},
+	)
+	registerCommand(associationComponent, 0x7, "subscribes to changes of the lists", listIdsRequest, nil)
+	registerCommand(associationComponent, 0x8, "unsubscribes from changes of the lists", listIdsRequest, nil)
+	registerCommand(associationComponent, 0x9, "gets the configuration of every list",
+		nil,
+		[]Field{ListField("CFGS", StructType, "the list configurations", listInfoFields...)},
+	)
+	registerNotification(associationComponent, 0x1, "the members of a subscribed list changed",
+		StructField("LID", "list identification", listIdFields...),
+		StructField("MEMB", "the member", memberFields...),
+		IntField("OPER", "0 when added and 1 when removed"),
+	)
+
+	registerNotification(userSessionsComponent, 0x2, "a friend came online",
+		StructField("USER", "the friend", userFields...),
+	)
+	registerNotification(userSessionsComponent, 0x3, "a friend went offline",
+		IntField("BUID", "player id of the friend"),
+	)
+	registerCommand(userSessionsComponent, 0x8, "sets the hardware flags of the session",
+		[]Field{IntField("HWFG", "hardware flags")},
+		nil,
+	)
+	registerCommand(userSessionsComponent, 0x14, "sets the addresses and connection quality of the client",
+		[]Field{
+			UnionField("ADDR", "addresses of the client",
+				StructField("VALU", "internal and external address",
+					StructField("EXIP", "external address", ipFields...),
+					StructField("INIP", "internal address", ipFields...),
+				),
+			),
+			MapField("NLMP", StringType, IntType, "latency in milliseconds to each QoS server"),
+			StructField("NQOS", "network quality",
+				IntField("DBPS", "download bits per second"),
+				IntField("NATT", "nat type"),
+				IntField("UBPS", "upload bits per second"),
+			),
+		},
+		nil,
+	)
+
+	registerCommand(authenticationComponent, 0x24, "gets the token for the HTTP services",
+		nil,
+		[]Field{StringField("AUTH", "token for the HTTP services")},
+	)
+	registerCommand(authenticationComponent, 0x28, "logs in with an email and password",
+		[]Field{
+			IntField("DVID", "device id").AsOptional(),
+			StringField("MAIL", "email address of the player"),
+			StringField("PASS", "password of the player"),
+			StringField("TOKN", "login token").AsOptional(),
+			IntField("TYPE", "login type").AsOptional(),
+		},
+		append(append([]Field{}, loginFields...),
+			ListField("PLST", StructType, "personas of the player", personaFields...),
+			StringField("SKEY", "session key"),
+			IntField("UID", "player id"),
+		),
+	)
+	registerCommand(authenticationComponent, 0x32, "logs in again with a token from an earlier login",
+		[]Field{
+			StringField("AUTH", "token from an earlier login"),
+			IntField("PID", "persona id"),
+			IntField("TYPE", "login type").AsOptional(),
+		},
+		append(append([]Field{}, loginFields...),
+			IntField("AGUP", "whether the player can be contacted"),
+			StructField("SESS", "the session", sessionFields...),
+		),
+	)
+	registerCommand(authenticationComponent, 0x46, "logs out", nil, nil)
+	registerCommand(authenticationComponent, 0x6E, "logs into a persona of the player",
+		[]Field{StringField("PNAM", "name of the persona")},
+		sessionFields,
+	)
+	registerCommand(authenticationComponent, 0x78, "logs out of the persona", nil, nil)
+
+	registerCommand(gameManagerComponent, 0x3, "changes the state of a game",
+		append(append([]Field{}, gameIdFields...), IntField("GSTA", "new game state")),
+		nil,
+	)
+	registerCommand(gameManagerComponent, 0x4, "changes the settings of a game",
+		append(append([]Field{}, gameIdFields...), IntField("GSET", "game setting flags")),
+		nil,
+	)
+	registerCommand(gameManagerComponent, 0x7, "changes attributes of a game",
+		append(append([]Field{}, gameIdFields...), MapField("ATTR", StringType, StringType, "attributes to change")),
+		nil,
+	)
+	registerCommand(gameManagerComponent, 0x8, "changes attributes of a player in a game",
+		append(append([]Field{}, gameIdFields...),
+			MapField("ATTR", StringType, StringType, "attributes to change"),
+			IntField("PID", "player id"),
+		),
+		nil,
+	)
+	registerCommand(gameManagerComponent, 0xB, "removes a player from a game", playerRemovedFields, nil)
+	// The matchmaking criteria haven't been described
+	RegisterSchema(Schema{Component: gameManagerComponent, Command: 0xD, Type: RequestType, Doc: "starts matchmaking for a game to join", Open: true})
+	RegisterSchema(Schema{Component: gameManagerComponent, Command: 0xD, Type: ResponseType, Doc: "starts matchmaking for a game to join",
+		Fields: []Field{IntField("MSID", "matchmaking session id")}})
+	registerCommand(gameManagerComponent, 0xE, "stops matchmaking",
+		[]Field{IntField("MSID", "matchmaking session id")},
+		nil,
+	)
+	registerCommand(gameManagerComponent, 0x1D, "reports the connections between the players of a game",
+		append(append([]Field{}, gameIdFields...),
+			ListField("TARG", StructType, "connections to other players",
+				IntField("FLGS", "connection flags"),
+				IntField("PID", "id of the other player"),
+				IntField("STAT", "connection state"),
+			),
+		),
+		nil,
+	)
+	registerNotification(gameManagerComponent, 0xA, "matchmaking found no game",
+		IntField("MAXF", "fit score of the best game"),
+		IntField("MSID", "matchmaking session id"),
+		IntField("RSLT", "matchmaking result"),
+		IntField("USID", "player id"),
+	)
+	registerNotification(gameManagerComponent, 0x10, "a game was destroyed",
+		IntField("DRSN", "reason the game was destroyed"),
+		IntField("GID", "game id"),
+	)
+	registerNotification(gameManagerComponent, 0x1E, "a player finished joining a game",
+		IntField("GID", "game id"),
+		IntField("PID", "player id"),
+	)
+	registerNotification(gameManagerComponent, 0x28, "a player left a game", playerRemovedFields...)
+	registerNotification(gameManagerComponent, 0x50, "attributes of a game changed",
+		MapField("ATTR", StringType, StringType, "the changed attributes"),
+		IntField("GID", "game id"),
+	)
+	registerNotification(gameManagerComponent, 0x5A, "attributes of a player in a game changed",
+		MapField("ATTR", StringType, StringType, "the changed attributes"),
+		IntField("GID", "game id"),
+		IntField("PID", "player id"),
+	)
+	registerNotification(gameManagerComponent, 0x64, "the state of a game changed",
+		IntField("GID", "game id"),
+		IntField("GSTA", "new game state"),
+	)
+	registerNotification(gameManagerComponent, 0x6E, "the settings of a game changed",
+		IntField("ATTR", "game setting flags"),
+		IntField("GID", "game id"),
+	)
+	registerNotification(gameManagerComponent, 0xCA, "a player was made or stopped being an admin of a game",
+		IntField("ALST", "id of the player that changed"),
+		IntField("GID", "game id"),
+		IntField("OPER", "0 when added and 1 when removed"),
+		IntField("UID", "id of the player making the change"),
+	)
+
+	registerCommand(statsComponent, 0xC, "gets rows of a leaderboard by rank",
+		append(append([]Field{}, leaderboardRequestFields...),
+			IntField("COUN", "number of rows"),
+			IntField("STRT", "rank of the first row"),
+		),
+		leaderboardResponse,
+	)
+	registerCommand(statsComponent, 0xD, "gets rows of a leaderboard around a player",
+		append(append([]Field{}, leaderboardRequestFields...),
+			IntField("CENT", "id of the player in the middle"),
+			IntField("COUN", "number of rows"),
+		),
+		leaderboardResponse,
+	)
+	registerCommand(statsComponent, 0xE, "gets the rows of a leaderboard for some players",
+		append(append([]Field{}, leaderboardRequestFields...),
+			ListField("IDLS", IntType, "ids of the players"),
+		),
+		leaderboardResponse,
+	)
+	registerCommand(statsComponent, 0x12, "counts the rows of a leaderboard",
+		leaderboardRequestFields,
+		[]Field{IntField("CNT", "number of rows")},
+	)
+
+	// Every other known command and notification is registered as open so
+	// it's at least named
+	for key := range CommandNames {
+		component, command := uint16(key>>16), uint16(key)
+		registerOpen(component, command, RequestType)
+		registerOpen(component, command, ResponseType)
+	}
+	for key := range NotificationNames {
+		registerOpen(uint16(key>>16), uint16(key), NotificationType)
+	}
+}
@@ -0,0 +1,204 @@
+// Code generated by gen_tags.go from labels.txt. DO NOT EDIT.
+
+package blaze
+
+// Tags of the labels in labels.txt for use in switches and other places
+// that need a constant
+const (
+	TagADDR uint32 = 0x86493200
+	TagADRS uint32 = 0x864CB300
+	TagANON uint32 = 0x86EBEE00
+	TagASRC uint32 = 0x873CA300
+	TagATTR uint32 = 0x874D3200
+	TagBID  uint32 = 0x8A990000
+	TagBOID uint32 = 0x8AFA6400
+	TagBUID uint32 = 0x8B5A6400
+	TagBWPS uint32 = 0x8B7C3300
+	TagCFGS uint32 = 0x8E69F300
+	TagCFID uint32 = 0x8E6A6400
+	TagCIDS uint32 = 0x8E993300
+	TagCNGN uint32 = 0x8EE9EE00
+	TagCONF uint32 = 0x8EFBA600
+	TagDATA uint32 = 0x921D2100
+	TagDBPS uint32 = 0x922C3300
+	TagDISA uint32 = 0x929CE100
+	TagDSNM uint32 = 0x933BAD00
+	TagEXIP uint32 = 0x978A7000
+	TagFILT uint32 = 0x9A9B3400
+	TagFLAG uint32 = 0x9AC86700
+	TagFLGS uint32 = 0x9AC9F300
+	TagHOST uint32 = 0xA2FCF400
+	TagID   uint32 = 0xA6400000
+	TagINFO uint32 = 0xA6E9AF00
+	TagINIP uint32 = 0xA6EA7000
+	TagINST uint32 = 0xA6ECF400
+	TagIP   uint32 = 0xA7000000
+	TagKEY  uint32 = 0xAE5E4000
+	TagLID  uint32 = 0xB2990000
+	TagLIDS uint32 = 0xB2993300
+	TagLMAP uint32 = 0xB2D87000
+	TagLMEM uint32 = 0xB2D96D00
+	TagLMID uint32 = 0xB2DA6400
+	TagLMS  uint32 = 0xB2DCC000
+	TagLNM  uint32 = 0xB2EB4000
+	TagLNP  uint32 = 0xB2EC0000
+	TagLOC  uint32 = 0xB2F8C000
+	TagLTPS uint32 = 0xB34C3300
+	TagMCNT uint32 = 0xB63BB400
+	TagMEMB uint32 = 0xB65B6200
+	TagMEML uint32 = 0xB65B6C00
+	TagMGID uint32 = 0xB67A6400
+	TagMIDS uint32 = 0xB6993300
+	TagMINR uint32 = 0xB69BB200
+	TagMSGS uint32 = 0xB739F300
+	TagMXRC uint32 = 0xB78CA300
+	TagNAME uint32 = 0xBA1B6500
+	TagNASP uint32 = 0xBA1CF000
+	TagNATT uint32 = 0xBA1D3400
+	TagNLMP uint32 = 0xBACB7000
+	TagNOOK uint32 = 0xBAFBEB00
+	TagNQOS uint32 = 0xBB1BF300
+	TagOFRC uint32 = 0xBE6CA300
+	TagOPER uint32 = 0xBF097200
+	TagPDTL uint32 = 0xC24D2C00
+	TagPID  uint32 = 0xC2990000
+	TagPILD uint32 = 0xC29B2400
+	TagPLAT uint32 = 0xC2C87400
+	TagPORT uint32 = 0xC2FCB400
+	TagPRID uint32 = 0xC32A6400
+	TagPSA  uint32 = 0xC3384000
+	TagPSP  uint32 = 0xC33C0000
+	TagPTAG uint32 = 0xC3486700
+	TagPYLD uint32 = 0xC39B2400
+	TagQOSS uint32 = 0xC6FCF300
+	TagRSRC uint32 = 0xCB3CA300
+	TagSDLY uint32 = 0xCE4B3900
+	TagSECU uint32 = 0xCE58F500
+	TagSESS uint32 = 0xCE5CF300
+	TagSKEY uint32 = 0xCEB97900
+	TagSMAP uint32 = 0xCED87000
+	TagSNA  uint32 = 0xCEE84000
+	TagSPCT uint32 = 0xCF08F400
+	TagSRCE uint32 = 0xCF28E500
+	TagSTAT uint32 = 0xCF487400
+	TagSTIM uint32 = 0xCF4A6D00
+	TagSVER uint32 = 0xCF697200
+	TagSVID uint32 = 0xCF6A6400
+	TagTAG  uint32 = 0xD219C000
+	TagTARG uint32 = 0xD21CA700
+	TagTELE uint32 = 0xD25B2500
+	TagTICK uint32 = 0xD298EB00
+	TagTIME uint32 = 0xD29B6500
+	TagTLM3 uint32 = 0xD2CB5300
+	TagTMOP uint32 = 0xD2DBF000
+	TagTOCT uint32 = 0xD2F8F400
+	TagTYPE uint32 = 0xD39C2500
+	TagUBPS uint32 = 0xD62C3300
+	TagUID  uint32 = 0xD6990000
+	TagULST uint32 = 0xD6CCF400
+	TagUROP uint32 = 0xD72BF000
+	TagUSER uint32 = 0xD7397200
+	TagVALU uint32 = 0xDA1B3500
+	TagXDNS uint32 = 0xE24BB300
+	TagXUID uint32 = 0xE35A6400
+)
+
+// knownTags maps each label in labels.txt to its tag constant
+var knownTags = map[Label]uint32{
+	"ADDR": TagADDR,
+	"ADRS": TagADRS,
+	"ANON": TagANON,
+	"ASRC": TagASRC,
+	"ATTR": TagATTR,
+	"BID":  TagBID,
+	"BOID": TagBOID,
+	"BUID": TagBUID,
+	"BWPS": TagBWPS,
+	"CFGS": TagCFGS,
+	"CFID": TagCFID,
+	"CIDS": TagCIDS,
+	"CNGN": TagCNGN,
+	"CONF": TagCONF,
+	"DATA": TagDATA,
+	"DBPS": TagDBPS,
+	"DISA": TagDISA,
+	"DSNM": TagDSNM,
+	"EXIP": TagEXIP,
+	"FILT": TagFILT,
+	"FLAG": TagFLAG,
+	"FLGS": TagFLGS,
+	"HOST": TagHOST,
+	"ID":   TagID,
+	"INFO": TagINFO,
+	"INIP": TagINIP,
+	"INST": TagINST,
+	"IP":   TagIP,
+	"KEY":  TagKEY,
+	"LID":  TagLID,
+	"LIDS": TagLIDS,
+	"LMAP": TagLMAP,
+	"LMEM": TagLMEM,
+	"LMID": TagLMID,
+	"LMS":  TagLMS,
+	"LNM":  TagLNM,
+	"LNP":  TagLNP,
+	"LOC":  TagLOC,
+	"LTPS": TagLTPS,
+	"MCNT": TagMCNT,
+	"MEMB": TagMEMB,
+	"MEML": TagMEML,
+	"MGID": TagMGID,
+	"MIDS": TagMIDS,
+	"MINR": TagMINR,
+	"MSGS": TagMSGS,
+	"MXRC": TagMXRC,
+	"NAME": TagNAME,
+	"NASP": TagNASP,
+	"NATT": TagNATT,
+	"NLMP": TagNLMP,
+	"NOOK": TagNOOK,
+	"NQOS": TagNQOS,
+	"OFRC": TagOFRC,
+	"OPER": TagOPER,
+	"PDTL": TagPDTL,
+	"PID":  TagPID,
+	"PILD": TagPILD,
+	"PLAT": TagPLAT,
+	"PORT": TagPORT,
+	"PRID": TagPRID,
+	"PSA":  TagPSA,
+	"PSP":  TagPSP,
+	"PTAG": TagPTAG,
+	"PYLD": TagPYLD,
+	"QOSS": TagQOSS,
+	"RSRC": TagRSRC,
+	"SDLY": TagSDLY,
+	"SECU": TagSECU,
+	"SESS": TagSESS,
+	"SKEY": TagSKEY,
+	"SMAP": TagSMAP,
+	"SNA":  TagSNA,
+	"SPCT": TagSPCT,
+	"SRCE": TagSRCE,
+	"STAT": TagSTAT,
+	"STIM": TagSTIM,
+	"SVER": TagSVER,
+	"SVID": TagSVID,
+	"TAG":  TagTAG,
+	"TARG": TagTARG,
+	"TELE": TagTELE,
+	"TICK": TagTICK,
+	"TIME": TagTIME,
+	"TLM3": TagTLM3,
+	"TMOP": TagTMOP,
+	"TOCT": TagTOCT,
+	"TYPE": TagTYPE,
+	"UBPS": TagUBPS,
+	"UID":  TagUID,
+	"ULST": TagULST,
+	"UROP": TagUROP,
+	"USER": TagUSER,
+	"VALU": TagVALU,
+	"XDNS": TagXDNS,
+	"XUID": TagXUID,
+}
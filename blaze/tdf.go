@@ -1,11 +1,12 @@
 package blaze
 
 import (
-	"container/list"
 	"encoding/binary"
+	"fmt"
 	. "github.com/jacobtread/gomes/types"
 	"io"
 	"log"
+	"sort"
 )
 
 type TdfType byte
@@ -22,10 +23,19 @@ const (
 	PairType
 	TripleType
 	FloatType
+	// GenericType holds a value of any type along with the id of its type
+	GenericType TdfType = 0x0C
 
 	EmptyType TdfType = 0x7F
 )
 
+// ObjectType and ObjectIdType are the names Blaze uses for pairs, which
+// hold a component and type, and triples which also hold an id
+const (
+	ObjectType   = PairType
+	ObjectIdType = TripleType
+)
+
 type Tdf interface {
 	Write(buf *PacketBuff)
 	GetHead() TdfImpl
@@ -40,6 +50,7 @@ type TdfImpl struct {
 }
 
 func NewTdf(label string, t TdfType) TdfImpl {
+	lintLabel(label)
 	return TdfImpl{
 		Label: label,
 		Type:  t,
@@ -48,7 +59,7 @@ func NewTdf(label string, t TdfType) TdfImpl {
 }
 
 func LabelToTag(label string) uint32 {
-	res := make([]byte, 3)
+	res := make([]byte, 4)
 	for len(label) < 4 {
 		label += "\x00"
 	}
@@ -80,24 +91,27 @@ func TagToLabel(tag uint32) string {
 
 	res := make([]byte, 4)
 
-	res[0] |= (buff[3] & 0x40) << 1
-	res[0] |= (buff[3] & 0x10) << 2
-	res[0] |= (buff[3] & 0x0F) << 2
-	res[0] |= (buff[2] & 0x40) >> 5
-	res[0] |= (buff[2] & 0x10) >> 4
+	res[0] |= (buff[0] & 0x80) >> 1
+	res[0] |= (buff[0] & 0x40) >> 2
+	res[0] |= (buff[0] & 0x3C) >> 2
+
+	res[1] |= (buff[0] & 0x02) << 5
+	res[1] |= (buff[0] & 0x01) << 4
+	res[1] |= (buff[1] & 0xF0) >> 4
 
-	res[1] |= (buff[2] & 0x0F) << 4
-	res[1] |= (buff[1] & 0x40) >> 3
-	res[1] |= (buff[1] & 0x10) >> 2
-	res[1] |= (buff[1] & 0x0C) >> 2
+	res[2] |= (buff[1] & 0x08) << 3
+	res[2] |= (buff[1] & 0x04) << 2
+	res[2] |= (buff[1] & 0x03) << 2
+	res[2] |= (buff[2] & 0xC0) >> 6
 
-	res[2] |= (buff[1] & 0x03) << 6
-	res[2] |= (buff[0] & 0x40) >> 1
-	res[2] |= buff[0] & 0x1F
+	res[3] |= (buff[2] & 0x20) << 1
+	res[3] |= buff[2] & 0x1F
 
+	// Each character is stored as its offset from a space so characters
+	// below 0x40 such as digits and padding need the 0x20 added back
 	for i := 0; i < 4; i++ {
-		if res[i] == 0 {
-			res[i] = 0x20
+		if res[i]&0x40 == 0 {
+			res[i] |= 0x20
 		}
 	}
 	return string(res)
@@ -167,13 +181,13 @@ func (t BlobTdf) Write(buf *PacketBuff) {
 }
 
 type StructTdf struct {
-	Values *list.List // List of TdfImpl values
+	Values Values
 	Start2 bool
 
 	TdfImpl
 }
 
-func NewStruct(label string, values *list.List) StructTdf {
+func NewStruct(label string, values ...Tdf) StructTdf {
 	return StructTdf{
 		Values:  values,
 		TdfImpl: NewTdf(label, StructType),
@@ -181,14 +195,17 @@ func NewStruct(label string, values *list.List) StructTdf {
 	}
 }
 
-func NewStruct2(label string, values *list.List) StructTdf {
+func NewStruct2(label string, values ...Tdf) StructTdf {
 	return StructTdf{
 		Values:  values,
 		TdfImpl: NewTdf(label, StructType),
 		Start2:  true,
 	}
 }
-func NewStructStub(values *list.List, start2 bool) StructTdf {
+
+// NewStructStub creates a struct without a label for use as a list item or
+// map value
+func NewStructStub(values []Tdf, start2 bool) StructTdf {
 	return StructTdf{
 		Values: values,
 		Start2: start2,
@@ -199,9 +216,8 @@ func (t StructTdf) Write(buf *PacketBuff) {
 	if t.Start2 {
 		_ = buf.WriteByte(2)
 	}
-	values := t.Values
-	for l := values.Front(); l != nil; l = l.Next() {
-		WriteTdf(buf, l.Value.(Tdf))
+	for _, value := range t.Values {
+		WriteTdf(buf, value)
 	}
 	_ = buf.WriteByte(0)
 }
@@ -213,119 +229,401 @@ func (t StructTdf) GetHead() TdfImpl {
 type SubType = byte
 
 const (
-	IntList    SubType = 0
-	StringList         = 1
-	StructList         = 3
-	TripleList         = 9
-	FloatList          = 10
+	IntList        SubType = 0
+	StringList     SubType = 1
+	BlobList       SubType = 2
+	StructList     SubType = 3
+	ListList       SubType = 4
+	MapList        SubType = 5
+	UnionList      SubType = 6
+	VarIntListList SubType = 7
+	PairList       SubType = 8
+	TripleList     SubType = 9
+	FloatList      SubType = 10
 )
 
-type ListTdf struct {
-	SubType SubType
-	Count   int32
-	List    *list.List
+// ListItem are the types that can be stored in lists and maps. Structs,
+// unions and var int lists are stored without their label
+type ListItem interface {
+	int64 | string | []byte | StructTdf | NestedList | NestedMap | UnionTdf | VarIntListTdf | Pair | Triple | float64
+}
+
+// NestedList is a list stored as an item of another list or map
+type NestedList struct {
+	ListValue
+}
+
+// NestedMap is a map stored as an item of a list or map
+type NestedMap struct {
+	MapValue
+}
+
+// subTypeOf returns the list subtype used for items of type T
+func subTypeOf[T ListItem]() SubType {
+	var zero T
+	switch any(zero).(type) {
+	case int64:
+		return IntList
+	case string:
+		return StringList
+	case []byte:
+		return BlobList
+	case StructTdf:
+		return StructList
+	case NestedList:
+		return ListList
+	case NestedMap:
+		return MapList
+	case UnionTdf:
+		return UnionList
+	case VarIntListTdf:
+		return VarIntListList
+	case Pair:
+		return PairList
+	case Triple:
+		return TripleList
+	default:
+		return FloatList
+	}
+}
+
+func writeItem[T ListItem](buf *PacketBuff, value T) {
+	switch v := any(value).(type) {
+	case int64:
+		buf.WriteVarInt(v)
+	case string:
+		buf.WriteString(v)
+	case []byte:
+		buf.WriteVarInt(int64(len(v)))
+		_, _ = buf.Write(v)
+	case StructTdf:
+		v.Write(buf)
+	case NestedList:
+		v.Write(buf)
+	case NestedMap:
+		v.Write(buf)
+	case UnionTdf:
+		v.Write(buf)
+	case VarIntListTdf:
+		v.Write(buf)
+	case Pair:
+		buf.WriteVarInt(v.A)
+		buf.WriteVarInt(v.B)
+	case Triple:
+		buf.WriteVarInt(v.A)
+		buf.WriteVarInt(v.B)
+		buf.WriteVarInt(v.C)
+	case float64:
+		buf.WriteNum(v)
+	}
+}
+
+// readItem reads an item of type T returning false when a nested list or
+// map has a type that isn't supported
+func readItem[T ListItem](buf ValueReader) (T, bool) {
+	var zero T
+	var out any
+	switch any(zero).(type) {
+	case int64:
+		out = buf.ReadVarInt()
+	case string:
+		out = buf.ReadString()
+	case []byte:
+		out = buf.ReadBlob()
+	case StructTdf:
+		values, start2 := readStructValues(buf)
+		out = NewStructStub(values, start2)
+	case NestedList:
+		list, ok := readListTdf(buf, TdfImpl{}).(ListValue)
+		if !ok {
+			return zero, false
+		}
+		out = NestedList{list}
+	case NestedMap:
+		value, ok := readPairListTdf(buf, TdfImpl{}).(MapValue)
+		if !ok {
+			return zero, false
+		}
+		out = NestedMap{value}
+	case UnionTdf:
+		union := readUnionTdf(buf, TdfImpl{})
+		if union.Type != EmptyType && union.Content == nil {
+			return zero, false
+		}
+		out = union
+	case VarIntListTdf:
+		out = readVarIntListTdf(buf, TdfImpl{})
+	case Pair:
+		out = ReadPair(buf)
+	case Triple:
+		out = ReadTriple(buf)
+	case float64:
+		out = buf.Float64()
+	}
+	return out.(T), true
+}
+
+// ListValue is implemented by every List giving access to the items without
+// knowing their type
+type ListValue interface {
+	Tdf
+	ItemType() SubType
+	Len() int
+	Item(i int) any
+}
+
+// List is a list of values of the same type. The subtype written to the
+// wire comes from the type of the items
+type List[T ListItem] struct {
+	Values []T
 
 	TdfImpl
 }
 
-func NewList(label string, subtype SubType, count int32, list *list.List) ListTdf {
-	return ListTdf{
-		SubType: subtype,
-		Count:   count,
-		List:    list,
+func NewList[T ListItem](label string, values []T) List[T] {
+	return List[T]{
+		Values:  values,
 		TdfImpl: NewTdf(label, ListType),
 	}
 }
 
-func (t ListTdf) Write(buf *PacketBuff) {
-	_ = buf.WriteByte(t.SubType)
-	buf.WriteVarInt(int64(t.Count))
-	for el := t.List.Front(); el != nil; el = el.Next() {
-		switch t.SubType {
-		case IntList:
-			buf.WriteVarInt(el.Value.(int64))
-		case StringList:
-			buf.WriteString(el.Value.(string))
-		case StructList:
-			el.Value.(StructTdf).Write(buf)
-		case TripleList:
-			el.Value.(TripleTdf).Write(buf)
-		}
+func (t List[T]) ItemType() SubType {
+	return subTypeOf[T]()
+}
+
+func (t List[T]) Len() int {
+	return len(t.Values)
+}
+
+func (t List[T]) Item(i int) any {
+	return t.Values[i]
+}
+
+func (t List[T]) Write(buf *PacketBuff) {
+	_ = buf.WriteByte(subTypeOf[T]())
+	buf.WriteVarInt(int64(len(t.Values)))
+	for _, value := range t.Values {
+		writeItem(buf, value)
 	}
 }
 
-func (t ListTdf) GetHead() TdfImpl {
+func (t List[T]) GetHead() TdfImpl {
 	return t.TdfImpl
 }
 
-type PairListTdf struct {
-	SubTypeA SubType
-	SubTypeB SubType
-	Count    int32
+// MapValue is implemented by every Map giving access to the entries without
+// knowing their types
+type MapValue interface {
+	Tdf
+	KeyType() SubType
+	ValueType() SubType
+	Len() int
+	Key(i int) any
+	Value(i int) any
+}
 
-	ListA *list.List
-	ListB *list.List
+// Map is a list of key value pairs. The keys and values are kept in order
+// as parallel slices so that the entries are written in the same order
+type Map[K ListItem, V ListItem] struct {
+	Keys   []K
+	Values []V
 
 	TdfImpl
 }
 
-func NewPairList(label string, subtypeA SubType, subtypeB SubType, listA *list.List, listB *list.List, count int32) PairListTdf {
-	return PairListTdf{
-		SubTypeA: subtypeA,
-		SubTypeB: subtypeB,
-		ListA:    listA,
-		ListB:    listB,
-		Count:    count,
-		TdfImpl:  NewTdf(label, PairListType),
+// NewMap creates a map from keys and values which must be the same length
+func NewMap[K ListItem, V ListItem](label string, keys []K, values []V) Map[K, V] {
+	if len(keys) != len(values) {
+		panic("blaze: map " + label + " has a different number of keys and values")
+	}
+	return Map[K, V]{
+		Keys:    keys,
+		Values:  values,
+		TdfImpl: NewTdf(label, PairListType),
 	}
 }
 
-func (l PairListTdf) Write(buf *PacketBuff) {
-	buf.WriteByte(l.SubTypeA)
-	buf.WriteByte(l.SubTypeB)
-	buf.WriteVarInt(int64(l.Count))
+// SortedKeys returns the keys of a go map in order
+func SortedKeys[K int64 | string, V any](values map[K]V) []K {
+	keys := make([]K, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
 
-	listA := l.ListA
-	listB := l.ListB
-	for {
-		a := listA.Front()
-		b := listB.Front()
+// NewSortedMap creates a map from a go map with the entries in key order
+func NewSortedMap[K int64 | string, V ListItem](label string, values map[K]V) Map[K, V] {
+	keys := SortedKeys(values)
+	items := make([]V, len(keys))
+	for i, key := range keys {
+		items[i] = values[key]
+	}
+	return NewMap(label, keys, items)
+}
 
-		if a == nil || b == nil {
-			break
-		}
+func (t Map[K, V]) KeyType() SubType {
+	return subTypeOf[K]()
+}
 
-		switch l.SubTypeA {
-		case IntList:
-			buf.WriteVarInt(a.Value.(int64))
-		case StringList:
-			buf.WriteString(a.Value.(string))
-		case StructList:
-			a.Value.(StructTdf).Write(buf)
-		case FloatList:
-			buf.WriteNum(a.Value.(float64))
-		}
+func (t Map[K, V]) ValueType() SubType {
+	return subTypeOf[V]()
+}
 
-		switch l.SubTypeB {
-		case IntList:
-			buf.WriteVarInt(b.Value.(int64))
-		case StringList:
-			buf.WriteString(b.Value.(string))
-		case StructList:
-			b.Value.(StructTdf).Write(buf)
-		case FloatList:
-			buf.WriteNum(b.Value.(float64))
-		}
+func (t Map[K, V]) Len() int {
+	return len(t.Keys)
+}
 
-		a.Next()
-		b.Next()
+func (t Map[K, V]) Key(i int) any {
+	return t.Keys[i]
+}
+
+func (t Map[K, V]) Value(i int) any {
+	return t.Values[i]
+}
+
+func (t Map[K, V]) Write(buf *PacketBuff) {
+	_ = buf.WriteByte(subTypeOf[K]())
+	_ = buf.WriteByte(subTypeOf[V]())
+	buf.WriteVarInt(int64(len(t.Keys)))
+	for i, key := range t.Keys {
+		writeItem(buf, key)
+		writeItem(buf, t.Values[i])
 	}
 }
 
-func (t PairListTdf) GetHead() TdfImpl {
+func (t Map[K, V]) GetHead() TdfImpl {
 	return t.TdfImpl
 }
 
+// castItems converts untyped items into a slice of T
+func castItems[T ListItem](items []any) ([]T, error) {
+	out := make([]T, len(items))
+	for i, item := range items {
+		value, ok := item.(T)
+		if !ok {
+			return nil, fmt.Errorf("item %d is %T not %T", i, item, value)
+		}
+		out[i] = value
+	}
+	return out, nil
+}
+
+// NewListOf creates a list from untyped items using the subtype to pick the
+// type of the list. This is used when the type is only known at runtime
+func NewListOf(label string, subType SubType, items []any) (Tdf, error) {
+	switch subType {
+	case IntList:
+		return newListOf[int64](label, items)
+	case StringList:
+		return newListOf[string](label, items)
+	case BlobList:
+		return newListOf[[]byte](label, items)
+	case StructList:
+		return newListOf[StructTdf](label, items)
+	case ListList:
+		return newListOf[NestedList](label, items)
+	case MapList:
+		return newListOf[NestedMap](label, items)
+	case UnionList:
+		return newListOf[UnionTdf](label, items)
+	case VarIntListList:
+		return newListOf[VarIntListTdf](label, items)
+	case PairList:
+		return newListOf[Pair](label, items)
+	case TripleList:
+		return newListOf[Triple](label, items)
+	case FloatList:
+		return newListOf[float64](label, items)
+	default:
+		return nil, fmt.Errorf("lists of %s are not supported", TdfType(subType))
+	}
+}
+
+func newListOf[T ListItem](label string, items []any) (Tdf, error) {
+	values, err := castItems[T](items)
+	if err != nil {
+		return nil, err
+	}
+	return NewList(label, values), nil
+}
+
+// NewMapOf creates a map from untyped keys and values using the key and
+// value types to pick the type of the map
+func NewMapOf(label string, keyType SubType, valueType SubType, keys []any, values []any) (Tdf, error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("map has %d keys and %d values", len(keys), len(values))
+	}
+	switch keyType {
+	case IntList:
+		return newMapOf[int64](label, valueType, keys, values)
+	case StringList:
+		return newMapOf[string](label, valueType, keys, values)
+	case BlobList:
+		return newMapOf[[]byte](label, valueType, keys, values)
+	case StructList:
+		return newMapOf[StructTdf](label, valueType, keys, values)
+	case ListList:
+		return newMapOf[NestedList](label, valueType, keys, values)
+	case MapList:
+		return newMapOf[NestedMap](label, valueType, keys, values)
+	case UnionList:
+		return newMapOf[UnionTdf](label, valueType, keys, values)
+	case VarIntListList:
+		return newMapOf[VarIntListTdf](label, valueType, keys, values)
+	case PairList:
+		return newMapOf[Pair](label, valueType, keys, values)
+	case TripleList:
+		return newMapOf[Triple](label, valueType, keys, values)
+	case FloatList:
+		return newMapOf[float64](label, valueType, keys, values)
+	default:
+		return nil, fmt.Errorf("maps with %s keys are not supported", TdfType(keyType))
+	}
+}
+
+func newMapOf[K ListItem](label string, valueType SubType, keys []any, values []any) (Tdf, error) {
+	typedKeys, err := castItems[K](keys)
+	if err != nil {
+		return nil, err
+	}
+	switch valueType {
+	case IntList:
+		return newMap(label, typedKeys, values, castItems[int64])
+	case StringList:
+		return newMap(label, typedKeys, values, castItems[string])
+	case BlobList:
+		return newMap(label, typedKeys, values, castItems[[]byte])
+	case StructList:
+		return newMap(label, typedKeys, values, castItems[StructTdf])
+	case ListList:
+		return newMap(label, typedKeys, values, castItems[NestedList])
+	case MapList:
+		return newMap(label, typedKeys, values, castItems[NestedMap])
+	case UnionList:
+		return newMap(label, typedKeys, values, castItems[UnionTdf])
+	case VarIntListList:
+		return newMap(label, typedKeys, values, castItems[VarIntListTdf])
+	case PairList:
+		return newMap(label, typedKeys, values, castItems[Pair])
+	case TripleList:
+		return newMap(label, typedKeys, values, castItems[Triple])
+	case FloatList:
+		return newMap(label, typedKeys, values, castItems[float64])
+	default:
+		return nil, fmt.Errorf("maps with %s values are not supported", TdfType(valueType))
+	}
+}
+
+func newMap[K ListItem, V ListItem](label string, keys []K, values []any, cast func([]any) ([]V, error)) (Tdf, error) {
+	typedValues, err := cast(values)
+	if err != nil {
+		return nil, err
+	}
+	return NewMap(label, keys, typedValues), nil
+}
+
 type UnionTdf struct {
 	Type    TdfType
 	Content Tdf
@@ -352,25 +650,21 @@ func (t UnionTdf) GetHead() TdfImpl {
 }
 
 type VarIntListTdf struct {
-	Count int32
-	List  *list.List // List of int64
+	Values []int64
 	TdfImpl
 }
 
-func NewVarIntList(label string, count int32, list *list.List) VarIntListTdf {
+func NewVarIntList(label string, values []int64) VarIntListTdf {
 	return VarIntListTdf{
-		Count:   count,
-		List:    list,
+		Values:  values,
 		TdfImpl: NewTdf(label, VarIntListType),
 	}
 }
 
 func (t VarIntListTdf) Write(buf *PacketBuff) {
-	buf.WriteVarInt(int64(t.Count))
-	if t.Count > 0 {
-		for l := t.List.Front(); l != nil; l = l.Next() {
-			buf.WriteVarInt(l.Value.(int64))
-		}
+	buf.WriteVarInt(int64(len(t.Values)))
+	for _, value := range t.Values {
+		buf.WriteVarInt(value)
 	}
 }
 
@@ -399,10 +693,10 @@ func (t PairTdf) GetHead() TdfImpl {
 	return t.TdfImpl
 }
 
-func ReadPair(buf *PacketBuff) Pair {
+func ReadPair(buf ValueReader) Pair {
 	return Pair{
-		A: int64(buf.ReadVarInt()),
-		B: int64(buf.ReadVarInt()),
+		A: buf.ReadVarInt(),
+		B: buf.ReadVarInt(),
 	}
 }
 
@@ -424,11 +718,11 @@ func (t TripleTdf) Write(buf *PacketBuff) {
 	buf.WriteVarInt(t.C)
 }
 
-func ReadTriple(buf *PacketBuff) Triple {
+func ReadTriple(buf ValueReader) Triple {
 	return Triple{
-		A: int64(buf.ReadVarInt()),
-		B: int64(buf.ReadVarInt()),
-		C: int64(buf.ReadVarInt()),
+		A: buf.ReadVarInt(),
+		B: buf.ReadVarInt(),
+		C: buf.ReadVarInt(),
 	}
 }
 
@@ -456,45 +750,117 @@ func (t FloatTdf) GetHead() TdfImpl {
 	return t.TdfImpl
 }
 
+// GenericTdf holds a value of any type along with the id of the type it
+// was created from. A generic without a value is written as a single zero
+type GenericTdf struct {
+	Id    int64
+	Value Tdf
+	TdfImpl
+}
+
+func NewGeneric(label string, id int64, value Tdf) GenericTdf {
+	return GenericTdf{
+		Id:      id,
+		Value:   value,
+		TdfImpl: NewTdf(label, GenericType),
+	}
+}
+
+func (t GenericTdf) Write(buf *PacketBuff) {
+	if t.Value == nil {
+		_ = buf.WriteByte(0)
+		return
+	}
+	_ = buf.WriteByte(1)
+	buf.WriteVarInt(t.Id)
+	WriteTdf(buf, t.Value)
+	_ = buf.WriteByte(0)
+}
+
+func (t GenericTdf) GetHead() TdfImpl {
+	return t.TdfImpl
+}
+
 func WriteTdf[T Tdf](buf *PacketBuff, value T) {
 	head := value.GetHead()
-	_ = binary.Write(buf, binary.BigEndian, head.Tag)
-	_ = buf.WriteByte(byte(head.Type))
+	var out [4]byte
+	binary.BigEndian.PutUint32(out[:], head.Tag|uint32(head.Type))
+	_, _ = buf.Write(out[:])
 	value.Write(buf)
 }
 
+// ValueReader is the source that values are decoded from. It is implemented
+// by PacketBuff and Decoder so that both decode every type the same way
+type ValueReader interface {
+	io.ByteScanner
+	Len() int
+	UInt32() uint32
+	ReadVarInt() int64
+	ReadString() string
+	ReadBlob() []byte
+	Float64() float64
+}
+
 func (b *PacketBuff) ReadTdf() Tdf {
+	return readTdf(b)
+}
+
+// readHead reads the tag and type that come before every value
+func readHead(b ValueReader) (TdfImpl, bool) {
+	if b.Len() < 4 {
+		return TdfImpl{}, false
+	}
 	head := b.UInt32()
 	tag := head & 0xFFFFFF00
-	t := TdfType(head & 0xFF)
-	impl := TdfImpl{
+	return TdfImpl{
 		Tag:   tag,
 		Label: TagToLabel(tag),
-		Type:  t,
+		Type:  TdfType(head & 0xFF),
+	}, true
+}
+
+func readTdf(b ValueReader) Tdf {
+	impl, ok := readHead(b)
+	if !ok {
+		return nil
 	}
-	switch t {
+	return readValue(b, impl)
+}
+
+// readValue reads the value for the provided head
+func readValue(b ValueReader, impl TdfImpl) Tdf {
+	switch t := impl.Type; t {
 	case IntType:
-		return b.ReadIntTdf(impl)
+		return readIntTdf(b, impl)
 	case StringType:
-		return b.ReadStringTdf(impl)
+		return readStringTdf(b, impl)
 	case BlobType:
-		return b.ReadBlobTdf(impl)
+		return readBlobTdf(b, impl)
 	case StructType:
-		return b.ReadStructTdf(impl)
+		return readStructTdf(b, impl)
 	case ListType:
-		return b.ReadListTdf(impl)
+		return readListTdf(b, impl)
 	case PairListType:
-		return b.ReadPairListTdf(impl)
+		return readPairListTdf(b, impl)
 	case UnionType:
-		return b.ReadUnionTdf(impl)
+		// Unions with content that can't be read can't be read either
+		if union := readUnionTdf(b, impl); union.Type == EmptyType || union.Content != nil {
+			return union
+		}
+		return nil
 	case VarIntListType:
-		return b.ReadVarIntListTdf(impl)
+		return readVarIntListTdf(b, impl)
 	case PairType:
-		return b.ReadPairTdf(impl)
+		return readPairTdf(b, impl)
 	case TripleType:
-		return b.ReadTripleTdf(impl)
+		return readTripleTdf(b, impl)
 	case FloatType:
-		return b.ReadFloatTdf(impl)
+		return readFloatTdf(b, impl)
+	case GenericType:
+		if generic, ok := readGeneric(b, impl); ok {
+			return generic
+		}
+		return nil
 	default:
 		log.Printf("Dont know how to handle tdf with type '%d'", t)
 		return nil
@@ -502,13 +868,21 @@ func (b *PacketBuff) ReadTdf() Tdf {
 }
 
 func (b *PacketBuff) ReadIntTdf(head TdfImpl) Int64Tdf {
+	return readIntTdf(b, head)
+}
+
+func readIntTdf(b ValueReader, head TdfImpl) Int64Tdf {
 	return Int64Tdf{
-		Value:   int64(b.ReadVarInt()),
+		Value:   b.ReadVarInt(),
 		TdfImpl: head,
 	}
 }
 
 func (b *PacketBuff) ReadStringTdf(head TdfImpl) StringTdf {
+	return readStringTdf(b, head)
+}
+
+func readStringTdf(b ValueReader, head TdfImpl) StringTdf {
 	return StringTdf{
 		Value:   b.ReadString(),
 		TdfImpl: head,
@@ -516,35 +890,52 @@ func (b *PacketBuff) ReadStringTdf(head TdfImpl) StringTdf {
 }
 
 func (b *PacketBuff) ReadBlobTdf(head TdfImpl) BlobTdf {
-	size := b.ReadVarInt()
-	data := make([]byte, size)
-	_, _ = io.ReadFull(b, data)
+	return readBlobTdf(b, head)
+}
+
+func readBlobTdf(b ValueReader, head TdfImpl) BlobTdf {
 	return BlobTdf{
-		Data:    data,
+		Data:    b.ReadBlob(),
 		TdfImpl: head,
 	}
 }
 
-func (b *PacketBuff) ReadStructValues() (*list.List, bool) {
-	out := list.New()
+func (b *PacketBuff) ReadStructValues() ([]Tdf, bool) {
+	return readStructValues(b)
+}
+
+func readStructValues(b ValueReader) ([]Tdf, bool) {
+	var out []Tdf
 	start2 := false
+	first := true
 	for {
 		by, err := b.ReadByte()
-		if err != nil {
+		if err != nil || by == 0 {
 			break
 		}
-		if by != 2 {
-			_ = b.UnreadByte()
-		} else {
+		if first && by == 2 {
+			// The 2 can be followed straight away by the end of the struct
 			start2 = true
+			first = false
+			continue
 		}
-		out.PushBack(b.ReadTdf())
+		_ = b.UnreadByte()
+		first = false
+		value := readTdf(b)
+		if value == nil {
+			break
+		}
+		out = append(out, value)
 	}
 	return out, start2
 }
 
 func (b *PacketBuff) ReadStructTdf(head TdfImpl) StructTdf {
-	values, start2 := b.ReadStructValues()
+	return readStructTdf(b, head)
+}
+
+func readStructTdf(b ValueReader, head TdfImpl) StructTdf {
+	values, start2 := readStructValues(b)
 	return StructTdf{
 		Values:  values,
 		Start2:  start2,
@@ -552,92 +943,170 @@ func (b *PacketBuff) ReadStructTdf(head TdfImpl) StructTdf {
 	}
 }
 
-func (b *PacketBuff) ReadListTdf(head TdfImpl) ListTdf {
-	subType, _ := b.ReadByte()
+// readCount reads the number of items in a list limiting it to the number
+// of bytes left so that a corrupt count can't allocate huge slices
+func readCount(b ValueReader) int {
 	count := b.ReadVarInt()
-	out := list.New()
-	var i uint64 = 0
-	for i < count {
-		switch subType {
-		case IntList:
-			out.PushBack(b.ReadVarInt())
-		case StringList:
-			out.PushBack(b.ReadString())
-		case StructList:
-			values, start2 := b.ReadStructValues()
-			out.PushBack(StructTdf{
-				Values: values,
-				Start2: start2,
-			})
-		case TripleList:
-			out.PushBack(ReadTriple(b))
-		default:
-			log.Printf("Don't know how to handle list type '%d'", subType)
-		}
-		i++
+	if count < 0 || count > int64(b.Len()) {
+		return b.Len()
 	}
-	return ListTdf{
-		List:    out,
-		SubType: subType,
-		Count:   int32(count),
-		TdfImpl: head,
+	return int(count)
+}
+
+// maxPreallocate limits the items allocated up front for a list. Nested
+// lists can each claim every remaining byte as items so allocating the
+// full count for each of them would use memory quadratic in the input
+const maxPreallocate = 64
+
+func preallocate(count int) int {
+	if count > maxPreallocate {
+		return maxPreallocate
 	}
+	return count
 }
 
-func (b *PacketBuff) ReadPairListTdf(head TdfImpl) PairListTdf {
-	subTypeA, _ := b.ReadByte()
-	subTypeB, _ := b.ReadByte()
-	count := b.ReadVarInt()
-	outA := list.New()
-	outB := list.New()
-	var i uint64 = 0
-	for i < count {
-		switch subTypeA {
-		case IntList:
-			outA.PushBack(b.ReadVarInt())
-		case StringList:
-			outA.PushBack(b.ReadString())
-		case StructList:
-			values, start2 := b.ReadStructValues()
-			outA.PushBack(StructTdf{
-				Values: values,
-				Start2: start2,
-			})
-		case FloatList:
-			outA.PushBack(b.Float64())
-		default:
-			log.Printf("Don't know how to handle list type '%d'", subTypeA)
+func readList[T ListItem](b ValueReader, head TdfImpl) Tdf {
+	count := readCount(b)
+	values := make([]T, 0, preallocate(count))
+	for i := 0; i < count && b.Len() > 0; i++ {
+		value, ok := readItem[T](b)
+		if !ok {
+			return nil
 		}
+		values = append(values, value)
+	}
+	return List[T]{Values: values, TdfImpl: head}
+}
+
+// ReadListTdf reads a list returning nil when the subtype isn't supported
+func (b *PacketBuff) ReadListTdf(head TdfImpl) Tdf {
+	return readListTdf(b, head)
+}
+
+func readListTdf(b ValueReader, head TdfImpl) Tdf {
+	subType, _ := b.ReadByte()
+	switch subType {
+	case IntList:
+		return readList[int64](b, head)
+	case StringList:
+		return readList[string](b, head)
+	case BlobList:
+		return readList[[]byte](b, head)
+	case StructList:
+		return readList[StructTdf](b, head)
+	case ListList:
+		return readList[NestedList](b, head)
+	case MapList:
+		return readList[NestedMap](b, head)
+	case UnionList:
+		return readList[UnionTdf](b, head)
+	case VarIntListList:
+		return readList[VarIntListTdf](b, head)
+	case PairList:
+		return readList[Pair](b, head)
+	case TripleList:
+		return readList[Triple](b, head)
+	case FloatList:
+		return readList[float64](b, head)
+	default:
+		log.Printf("Don't know how to handle list type '%d'", subType)
+		return nil
+	}
+}
 
-		switch subTypeB {
-		case IntList:
-			outB.PushBack(b.ReadVarInt())
-		case StringList:
-			outB.PushBack(b.ReadString())
-		case StructList:
-			values, start2 := b.ReadStructValues()
-			outB.PushBack(StructTdf{
-				Values: values,
-				Start2: start2,
-			})
-		case FloatList:
-			outB.PushBack(b.Float64())
-		default:
-			log.Printf("Don't know how to handle list type '%d'", subTypeB)
+func readMap[K ListItem, V ListItem](b ValueReader, head TdfImpl) Tdf {
+	count := readCount(b)
+	keys := make([]K, 0, preallocate(count))
+	values := make([]V, 0, preallocate(count))
+	for i := 0; i < count && b.Len() > 0; i++ {
+		key, ok := readItem[K](b)
+		if !ok {
+			return nil
 		}
-		i++
+		value, ok := readItem[V](b)
+		if !ok {
+			return nil
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return Map[K, V]{Keys: keys, Values: values, TdfImpl: head}
+}
+
+// readMapValues picks the value type of a map with keys of type K
+func readMapValues[K ListItem](b ValueReader, head TdfImpl, subType SubType) Tdf {
+	switch subType {
+	case IntList:
+		return readMap[K, int64](b, head)
+	case StringList:
+		return readMap[K, string](b, head)
+	case BlobList:
+		return readMap[K, []byte](b, head)
+	case StructList:
+		return readMap[K, StructTdf](b, head)
+	case ListList:
+		return readMap[K, NestedList](b, head)
+	case MapList:
+		return readMap[K, NestedMap](b, head)
+	case UnionList:
+		return readMap[K, UnionTdf](b, head)
+	case VarIntListList:
+		return readMap[K, VarIntListTdf](b, head)
+	case PairList:
+		return readMap[K, Pair](b, head)
+	case TripleList:
+		return readMap[K, Triple](b, head)
+	case FloatList:
+		return readMap[K, float64](b, head)
+	default:
+		log.Printf("Don't know how to handle map value type '%d'", subType)
+		return nil
 	}
-	return PairListTdf{
-		SubTypeA: subTypeA,
-		SubTypeB: subTypeB,
-		ListA:    outA,
-		ListB:    outB,
-		Count:    int32(count),
-		TdfImpl:  head,
+}
+
+// ReadPairListTdf reads a map returning nil when the key or value type
+// isn't supported
+func (b *PacketBuff) ReadPairListTdf(head TdfImpl) Tdf {
+	return readPairListTdf(b, head)
+}
+
+func readPairListTdf(b ValueReader, head TdfImpl) Tdf {
+	keyType, _ := b.ReadByte()
+	valueType, _ := b.ReadByte()
+	switch keyType {
+	case IntList:
+		return readMapValues[int64](b, head, valueType)
+	case StringList:
+		return readMapValues[string](b, head, valueType)
+	case BlobList:
+		return readMapValues[[]byte](b, head, valueType)
+	case StructList:
+		return readMapValues[StructTdf](b, head, valueType)
+	case ListList:
+		return readMapValues[NestedList](b, head, valueType)
+	case MapList:
+		return readMapValues[NestedMap](b, head, valueType)
+	case UnionList:
+		return readMapValues[UnionTdf](b, head, valueType)
+	case VarIntListList:
+		return readMapValues[VarIntListTdf](b, head, valueType)
+	case PairList:
+		return readMapValues[Pair](b, head, valueType)
+	case TripleList:
+		return readMapValues[Triple](b, head, valueType)
+	case FloatList:
+		return readMapValues[float64](b, head, valueType)
+	default:
+		log.Printf("Don't know how to handle map key type '%d'", keyType)
+		return nil
 	}
 }
 
 func (b *PacketBuff) ReadUnionTdf(head TdfImpl) UnionTdf {
+	return readUnionTdf(b, head)
+}
+
+func readUnionTdf(b ValueReader, head TdfImpl) UnionTdf {
 	t, _ := b.ReadByte()
 	ty := TdfType(t)
 	out := UnionTdf{
@@ -645,25 +1114,32 @@ func (b *PacketBuff) ReadUnionTdf(head TdfImpl) UnionTdf {
 		TdfImpl: head,
 	}
 	if ty != EmptyType {
-		out.Content = b.ReadTdf()
+		out.Content = readTdf(b)
 	}
 	return out
 }
 
 func (b *PacketBuff) ReadVarIntListTdf(head TdfImpl) VarIntListTdf {
-	count := int32(b.ReadVarInt())
-	out := list.New()
-	for i := int32(0); i < count; i++ {
-		out.PushBack(b.ReadVarInt())
+	return readVarIntListTdf(b, head)
+}
+
+func readVarIntListTdf(b ValueReader, head TdfImpl) VarIntListTdf {
+	count := readCount(b)
+	values := make([]int64, 0, preallocate(count))
+	for i := 0; i < count && b.Len() > 0; i++ {
+		values = append(values, b.ReadVarInt())
 	}
 	return VarIntListTdf{
-		Count:   count,
-		List:    out,
+		Values:  values,
 		TdfImpl: head,
 	}
 }
 
 func (b *PacketBuff) ReadPairTdf(head TdfImpl) PairTdf {
+	return readPairTdf(b, head)
+}
+
+func readPairTdf(b ValueReader, head TdfImpl) PairTdf {
 	return PairTdf{
 		Pair:    ReadPair(b),
 		TdfImpl: head,
@@ -671,6 +1147,10 @@ func (b *PacketBuff) ReadPairTdf(head TdfImpl) PairTdf {
 }
 
 func (b *PacketBuff) ReadTripleTdf(head TdfImpl) TripleTdf {
+	return readTripleTdf(b, head)
+}
+
+func readTripleTdf(b ValueReader, head TdfImpl) TripleTdf {
 	return TripleTdf{
 		Triple:  ReadTriple(b),
 		TdfImpl: head,
@@ -678,9 +1158,35 @@ func (b *PacketBuff) ReadTripleTdf(head TdfImpl) TripleTdf {
 }
 
 func (b *PacketBuff) ReadFloatTdf(head TdfImpl) FloatTdf {
+	return readFloatTdf(b, head)
+}
+
+func readFloatTdf(b ValueReader, head TdfImpl) FloatTdf {
 	f := b.Float64()
 	return FloatTdf{
 		Value:   f,
 		TdfImpl: head,
 	}
 }
+
+func (b *PacketBuff) ReadGenericTdf(head TdfImpl) GenericTdf {
+	return readGenericTdf(b, head)
+}
+
+func readGenericTdf(b ValueReader, head TdfImpl) GenericTdf {
+	out, _ := readGeneric(b, head)
+	return out
+}
+
+// readGeneric reads a generic returning false when it should have a value
+// but the value can't be read
+func readGeneric(b ValueReader, head TdfImpl) (GenericTdf, bool) {
+	out := GenericTdf{TdfImpl: head}
+	if present, _ := b.ReadByte(); present == 0 {
+		return out, true
+	}
+	out.Id = b.ReadVarInt()
+	out.Value = readTdf(b)
+	_, _ = b.ReadByte()
+	return out, out.Value != nil
+}
@@ -0,0 +1,111 @@
+package blaze
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/jacobtread/gomes/types"
+)
+
+// sampleItems has two items for each list subtype
+var sampleItems = map[SubType][]any{
+	IntList:        {int64(1), int64(-300)},
+	StringList:     {"a", ""},
+	BlobList:       {[]byte{1, 2}, []byte{}},
+	StructList:     {NewStructStub([]Tdf{NewInt64("A", 1)}, false), NewStructStub(nil, true)},
+	ListList:       {NestedList{NewList("", []int64{1})}, NestedList{NewList("", []string{"b"})}},
+	MapList:        {NestedMap{NewMap("", []string{"k"}, []int64{1})}, NestedMap{NewMap("", []int64{}, []float64{})}},
+	UnionList:      {NewUnion("", 2, NewBlob("DATA", []byte{3})), NewUnion("", EmptyType, nil)},
+	VarIntListList: {NewVarIntList("", []int64{1, -2}), NewVarIntList("", nil)},
+	PairList:       {Pair{A: 0x19, B: 1}, Pair{}},
+	TripleList:     {Triple{A: 0x19, B: 1, C: 100}, Triple{}},
+	FloatList:      {0.5, -2.0},
+}
+
+// typeMatrix has a list of each subtype and a map of every combination of
+// key and value types
+func typeMatrix(t *testing.T) []Tdf {
+	var out []Tdf
+	for subType, items := range sampleItems {
+		list, err := NewListOf("LIST", subType, items)
+		if err != nil {
+			t.Fatalf("list of %s: %v", TdfType(subType), err)
+		}
+		out = append(out, list)
+		for valueType, values := range sampleItems {
+			value, err := NewMapOf("MAP", subType, valueType, items, values)
+			if err != nil {
+				t.Fatalf("map of %s to %s: %v", TdfType(subType), TdfType(valueType), err)
+			}
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+func TestTypeMatrixRoundTrip(t *testing.T) {
+	options := CompactFormatOptions
+	options.MaxString, options.MaxBlob, options.MaxItems = 0, 0, 0
+	for _, value := range typeMatrix(t) {
+		name := FormatWith(value, options)
+		wire := encodeContent(value)
+
+		read := readContentBuff(wire)
+		if len(read) != 1 || !bytes.Equal(encodeContent(read...), wire) {
+			t.Errorf("%s: PacketBuff read different bytes", name)
+			continue
+		}
+		if decoded := DecodeContent(wire); !bytes.Equal(encodeContent(decoded...), wire) {
+			t.Errorf("%s: Decoder read different bytes", name)
+		}
+
+		r := NewLazyReader(wire)
+		for r.Next() {
+		}
+		if r.Err() != nil || r.d.Len() != 0 {
+			t.Errorf("%s: lazy reader stopped at %d: %v", name, r.d.Offset(), r.Err())
+		}
+
+		data, err := ContentToJSON(read)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		fromJSON, err := ContentFromJSON(data)
+		if err != nil || !bytes.Equal(encodeContent(fromJSON...), wire) {
+			t.Errorf("%s: json round trip failed %v\n%s", name, err, data)
+		}
+
+		parsed, err := ParseContent(name)
+		if err != nil || !bytes.Equal(encodeContent(parsed...), wire) {
+			t.Errorf("%s: text round trip failed %v", name, err)
+		}
+	}
+}
+
+func TestNestedListOfUnsupportedType(t *testing.T) {
+	// A list holding a list of an unknown subtype can't be read
+	wire := encodeContent(NewList("GRID", []NestedList{{NewList("", []int64{1})}}))
+	wire[6] = 0x20
+	if values := DecodeContent(wire); len(values) != 0 {
+		t.Errorf("expected no values got %v", values)
+	}
+}
+
+func TestGenericRoundTrip(t *testing.T) {
+	for _, value := range []Tdf{
+		NewGeneric("GNRC", 0x1234, NewList("IDS", []int64{1, 2})),
+		NewGeneric("GNRC", 1, NewUnion("ADDR", 0, NewString("HOST", "127.0.0.1"))),
+		NewGeneric("GEMP", 0, nil),
+	} {
+		wire := encodeContent(value, NewInt64("NEXT", 1))
+		read := DecodeContent(wire)
+		if len(read) != 2 || !bytes.Equal(encodeContent(read...), wire) {
+			t.Errorf("%s: read different values %v", Format(value), read)
+		}
+	}
+	values := Values{NewGeneric("GNRC", 7, NewStruct("VALU", NewInt64("ID", 3)))}
+	if id, ok := values.Int("GNRC.ID"); !ok || id != 3 {
+		t.Errorf("GNRC.ID = %d, %t", id, ok)
+	}
+}
@@ -0,0 +1,85 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// wirePackets are packets along with the bytes they are sent as. The bytes
+// were worked out by hand from the packet format rather than captured from
+// the game so they only pin the encoding down
+var wirePackets = []struct {
+	name    string
+	hex     string
+	comp    uint16
+	cmd     uint16
+	qType   uint16
+	id      uint16
+	content []Tdf
+}{
+	{
+		name: "util ping",
+		hex:  "000000090002000000000001",
+		comp: 0x9, cmd: 0x2, qType: RequestType, id: 1,
+	},
+	{
+		name: "util fetchClientConfig",
+		hex:  "000e000900010000000000028e6a6401094d45335f4441544100",
+		comp: 0x9, cmd: 0x1, qType: RequestType, id: 2,
+		content: []Tdf{NewString("CFID", "ME3_DATA")},
+	},
+	{
+		name: "redirector getServerInstance",
+		hex: "0033000500010000100000008649320600da1b3503a2fcf4010a3132372e302e302e3100a7000000" +
+			"818080f00fc2fcb4008bde0100ce58f50001e24bb30000",
+		comp: 0x5, cmd: 0x1, qType: ResponseType,
+		content: []Tdf{
+			NewUnion("ADDR", 0, NewStruct("VALU",
+				NewString("HOST", "127.0.0.1"),
+				NewInt64("IP", 0x7F000001),
+				NewInt64("PORT", 14219),
+			)),
+			NewInt64("SECU", 1),
+			NewInt64("XDNS", 0),
+		},
+	},
+}
+
+func TestWirePackets(t *testing.T) {
+	for _, wire := range wirePackets {
+		data, err := hex.DecodeString(wire.hex)
+		if err != nil {
+			t.Fatalf("%s: %v", wire.name, err)
+		}
+		buff := PacketBuff{}
+		encoded := buff.EncodePacket(wire.comp, wire.cmd, 0, wire.qType, wire.id, wire.content)
+		if !bytes.Equal(encoded, data) {
+			t.Errorf("%s: encoded as\n%x\nexpected\n%x", wire.name, encoded, data)
+		}
+
+		packet, n, err := DecodePacket(data)
+		if err != nil || n != len(data) {
+			t.Errorf("%s: decoded %d of %d bytes: %v", wire.name, n, len(data), err)
+			continue
+		}
+		if packet.Component != wire.comp || packet.Command != wire.cmd || packet.QType != wire.qType || packet.Id != wire.id {
+			t.Errorf("%s: unexpected header %+v", wire.name, packet)
+		}
+		if content := encodeContent(packet.ReadContent()...); !bytes.Equal(content, encodeContent(wire.content...)) {
+			t.Errorf("%s: content read differently\n%s", wire.name, FormatContent(packet.ReadContent(), DefaultFormatOptions))
+		}
+	}
+}
+
+func TestWireValues(t *testing.T) {
+	data, _ := hex.DecodeString(wirePackets[2].hex)
+	packet, _, _ := DecodePacket(data)
+	values := packet.ReadContent()
+	if host, _ := values.String("ADDR.HOST"); host != "127.0.0.1" {
+		t.Errorf("ADDR.HOST = %q", host)
+	}
+	if port := values.IntOr("ADDR.PORT", 0); port != 14219 {
+		t.Errorf("ADDR.PORT = %d", port)
+	}
+}
@@ -0,0 +1,150 @@
+package capture
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/types"
+)
+
+// DefaultIgnoredLabels are the labels of values that change between runs
+// such as times, addresses and login tokens which are skipped when
+// comparing packets
+var DefaultIgnoredLabels = []string{"TIME", "STIM", "SKEY", "SESS", "USID", "HOST", "IP", "PSA", "ADRS", "PCTK", "KEY", "AUTH", "LAST", "LLOG"}
+
+// DiffPackets compares the header and contents of two packets returning a
+// description of each difference. Values with an ignored label are skipped
+// along with everything inside them
+func DiffPackets(expected blaze.Packet, actual blaze.Packet, ignore []string) []string {
+	var out []string
+	if expected.Component != actual.Component || expected.Command != actual.Command {
+		out = append(out, fmt.Sprintf("command %s != %s", expected.ToDescriptor(), actual.ToDescriptor()))
+	}
+	if expected.QType&0xF000 != actual.QType&0xF000 {
+		out = append(out, fmt.Sprintf("type 0x%04X != 0x%04X", expected.QType&0xF000, actual.QType&0xF000))
+	}
+	if expected.Error != actual.Error {
+		out = append(out, fmt.Sprintf("error 0x%04X != 0x%04X", expected.Error, actual.Error))
+	}
+	if expected.Id != actual.Id {
+		out = append(out, fmt.Sprintf("id %d != %d", expected.Id, actual.Id))
+	}
+	ignored := map[string]bool{}
+	for _, label := range ignore {
+		ignored[strings.TrimSpace(label)] = true
+	}
+	a := flattenValues(expected.ReadContent(), ignored)
+	b := flattenValues(actual.ReadContent(), ignored)
+	values := map[string]string{}
+	for _, value := range b {
+		values[value.path] = value.value
+	}
+	seen := map[string]bool{}
+	for _, value := range a {
+		seen[value.path] = true
+		other, ok := values[value.path]
+		if !ok {
+			out = append(out, fmt.Sprintf("%s: missing, expected %s", value.path, value.value))
+		} else if other != value.value {
+			out = append(out, fmt.Sprintf("%s: %s != %s", value.path, value.value, other))
+		}
+	}
+	for _, value := range b {
+		if !seen[value.path] {
+			out = append(out, fmt.Sprintf("%s: unexpected %s", value.path, value.value))
+		}
+	}
+	return out
+}
+
+type flatValue struct {
+	path  string
+	value string
+}
+
+// flattenValues turns a tree of values into a list of paths to each scalar
+// value in the order they appear
+func flattenValues(values []blaze.Tdf, ignored map[string]bool) []flatValue {
+	var out []flatValue
+	flattenList(&out, "", values, ignored)
+	return out
+}
+
+func flattenList(out *[]flatValue, prefix string, values []blaze.Tdf, ignored map[string]bool) {
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		label := strings.TrimSpace(value.GetHead().Label)
+		if ignored[label] {
+			continue
+		}
+		flattenTdf(out, prefix+label, value, ignored)
+	}
+}
+
+func flattenTdf(out *[]flatValue, path string, value any, ignored map[string]bool) {
+	switch v := value.(type) {
+	case blaze.StructTdf:
+		*out = append(*out, flatValue{path, fmt.Sprintf("struct(%d)", len(v.Values))})
+		flattenList(out, path+".", v.Values, ignored)
+	case blaze.UnionTdf:
+		*out = append(*out, flatValue{path, fmt.Sprintf("union(%d)", v.Type)})
+		if v.Content != nil && !ignored[strings.TrimSpace(v.Content.GetHead().Label)] {
+			flattenTdf(out, path+"."+strings.TrimSpace(v.Content.GetHead().Label), v.Content, ignored)
+		}
+	case blaze.GenericTdf:
+		*out = append(*out, flatValue{path, fmt.Sprintf("generic(%d)", v.Id)})
+		if v.Value != nil && !ignored[strings.TrimSpace(v.Value.GetHead().Label)] {
+			flattenTdf(out, path+"."+strings.TrimSpace(v.Value.GetHead().Label), v.Value, ignored)
+		}
+	case blaze.ListValue:
+		*out = append(*out, flatValue{path, fmt.Sprintf("list(%d)", v.Len())})
+		for i := 0; i < v.Len(); i++ {
+			flattenTdf(out, fmt.Sprintf("%s[%d]", path, i), v.Item(i), ignored)
+		}
+	case blaze.VarIntListTdf:
+		*out = append(*out, flatValue{path, fmt.Sprintf("list(%d)", len(v.Values))})
+		for i, item := range v.Values {
+			flattenTdf(out, fmt.Sprintf("%s[%d]", path, i), item, ignored)
+		}
+	case blaze.MapValue:
+		*out = append(*out, flatValue{path, fmt.Sprintf("map(%d)", v.Len())})
+		for i := 0; i < v.Len(); i++ {
+			flattenTdf(out, fmt.Sprintf("%s[%s]", path, scalarString(v.Key(i))), v.Value(i), ignored)
+		}
+	default:
+		*out = append(*out, flatValue{path, scalarString(value)})
+	}
+}
+
+func scalarString(value any) string {
+	switch v := value.(type) {
+	case blaze.Int64Tdf:
+		return fmt.Sprintf("%d", v.Value)
+	case blaze.StringTdf:
+		return fmt.Sprintf("%q", v.Value)
+	case blaze.BlobTdf:
+		return hex.EncodeToString(v.Data)
+	case blaze.FloatTdf:
+		return fmt.Sprintf("%g", v.Value)
+	case blaze.PairTdf:
+		return scalarString(v.Pair)
+	case blaze.TripleTdf:
+		return scalarString(v.Triple)
+	case types.Pair:
+		return fmt.Sprintf("(%d, %d)", v.A, v.B)
+	case types.Triple:
+		return fmt.Sprintf("(%d, %d, %d)", v.A, v.B, v.C)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		return hex.EncodeToString(v)
+	case blaze.StructTdf:
+		return fmt.Sprintf("struct(%d)", len(v.Values))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,165 @@
+package capture
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+// Segment is a TCP segment extracted from a frame
+type Segment struct {
+	Src     net.TCPAddr
+	Dst     net.TCPAddr
+	Seq     uint32
+	SYN     bool
+	FIN     bool
+	RST     bool
+	Payload []byte
+}
+
+// TCP flags
+const (
+	tcpFIN = 0x01
+	tcpSYN = 0x02
+	tcpRST = 0x04
+)
+
+// IP protocol numbers
+const (
+	protoTCP      = 6
+	protoHopByHop = 0
+	protoRouting  = 43
+	protoDestOpts = 60
+)
+
+// ParseSegment extracts the TCP segment from a frame returning false when
+// the frame isn't TCP over IPv4 or IPv6. Fragmented IP packets are skipped
+func ParseSegment(frame Frame) (Segment, bool) {
+	data := frame.Data
+	var etherType uint16
+	switch frame.LinkType {
+	case LinkEthernet:
+		if len(data) < 14 {
+			return Segment{}, false
+		}
+		etherType = binary.BigEndian.Uint16(data[12:])
+		data = data[14:]
+		// Skip any VLAN tags
+		for (etherType == 0x8100 || etherType == 0x88A8) && len(data) >= 4 {
+			etherType = binary.BigEndian.Uint16(data[2:])
+			data = data[4:]
+		}
+	case LinkLinuxSLL:
+		if len(data) < 16 {
+			return Segment{}, false
+		}
+		etherType = binary.BigEndian.Uint16(data[14:])
+		data = data[16:]
+	case LinkNull:
+		if len(data) < 4 {
+			return Segment{}, false
+		}
+		// The address family is in the byte order of the capturing host
+		family := binary.LittleEndian.Uint32(data)
+		if family > 0xFFFF {
+			family = binary.BigEndian.Uint32(data)
+		}
+		data = data[4:]
+		etherType = 0x0800
+		if family == 24 || family == 28 || family == 30 {
+			etherType = 0x86DD
+		}
+	case LinkRaw, LinkIPv4, LinkIPv6:
+		if len(data) == 0 {
+			return Segment{}, false
+		}
+		etherType = 0x0800
+		if data[0]>>4 == 6 {
+			etherType = 0x86DD
+		}
+	default:
+		return Segment{}, false
+	}
+	switch etherType {
+	case 0x0800:
+		return parseIPv4(data)
+	case 0x86DD:
+		return parseIPv6(data)
+	default:
+		return Segment{}, false
+	}
+}
+
+func parseIPv4(data []byte) (Segment, bool) {
+	if len(data) < 20 || data[0]>>4 != 4 {
+		return Segment{}, false
+	}
+	headerLength := int(data[0]&0x0F) * 4
+	totalLength := int(binary.BigEndian.Uint16(data[2:]))
+	// More fragments flag or a fragment offset
+	if binary.BigEndian.Uint16(data[6:])&0x3FFF != 0 || data[9] != protoTCP {
+		return Segment{}, false
+	}
+	// Captures with segmentation offload can report a total length of zero
+	if totalLength == 0 || totalLength > len(data) {
+		totalLength = len(data)
+	}
+	if headerLength < 20 || headerLength > totalLength {
+		return Segment{}, false
+	}
+	src := net.IP(append([]byte{}, data[12:16]...))
+	dst := net.IP(append([]byte{}, data[16:20]...))
+	return parseTCP(data[headerLength:totalLength], src, dst)
+}
+
+func parseIPv6(data []byte) (Segment, bool) {
+	if len(data) < 40 || data[0]>>4 != 6 {
+		return Segment{}, false
+	}
+	payloadLength := int(binary.BigEndian.Uint16(data[4:]))
+	next := data[6]
+	src := net.IP(append([]byte{}, data[8:24]...))
+	dst := net.IP(append([]byte{}, data[24:40]...))
+	data = data[40:]
+	if payloadLength > 0 && payloadLength < len(data) {
+		data = data[:payloadLength]
+	}
+	for {
+		switch next {
+		case protoTCP:
+			return parseTCP(data, src, dst)
+		case protoHopByHop, protoRouting, protoDestOpts:
+			if len(data) < 8 {
+				return Segment{}, false
+			}
+			length := (int(data[1]) + 1) * 8
+			if length > len(data) {
+				return Segment{}, false
+			}
+			next = data[0]
+			data = data[length:]
+		default:
+			// Fragments and anything else are not handled
+			return Segment{}, false
+		}
+	}
+}
+
+func parseTCP(data []byte, src net.IP, dst net.IP) (Segment, bool) {
+	if len(data) < 20 {
+		return Segment{}, false
+	}
+	offset := int(data[12]>>4) * 4
+	if offset < 20 || offset > len(data) {
+		return Segment{}, false
+	}
+	flags := data[13]
+	return Segment{
+		Src:     net.TCPAddr{IP: src, Port: int(binary.BigEndian.Uint16(data[0:]))},
+		Dst:     net.TCPAddr{IP: dst, Port: int(binary.BigEndian.Uint16(data[2:]))},
+		Seq:     binary.BigEndian.Uint32(data[4:]),
+		SYN:     flags&tcpSYN != 0,
+		FIN:     flags&tcpFIN != 0,
+		RST:     flags&tcpRST != 0,
+		Payload: data[offset:],
+	}, true
+}
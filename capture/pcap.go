@@ -0,0 +1,265 @@
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Link types of the frames within a capture
+const (
+	LinkNull     uint32 = 0
+	LinkEthernet uint32 = 1
+	LinkRaw      uint32 = 101
+	LinkLinuxSLL uint32 = 113
+	LinkIPv4     uint32 = 228
+	LinkIPv6     uint32 = 229
+)
+
+var ErrUnknownFormat = errors.New("not a pcap or pcapng file")
+
+// Frame is a single captured link layer frame
+type Frame struct {
+	Time     time.Time
+	LinkType uint32
+	Data     []byte
+}
+
+// FrameReader reads the frames from a capture file
+type FrameReader interface {
+	// Next returns the next frame or io.EOF once there are none left
+	Next() (Frame, error)
+}
+
+// NewFrameReader detects whether the reader contains a pcap or a pcapng file
+// and returns a reader for its frames
+func NewFrameReader(r io.Reader) (FrameReader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return nil, ErrUnknownFormat
+	}
+	switch binary.LittleEndian.Uint32(magic) {
+	case 0xA1B2C3D4, 0xD4C3B2A1, 0xA1B23C4D, 0x4D3CB2A1:
+		return newPcapReader(br)
+	case 0x0A0D0D0A:
+		return newPcapngReader(br)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// pcapReader reads the classic libpcap format
+type pcapReader struct {
+	r        io.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	linkType uint32
+}
+
+func newPcapReader(r io.Reader) (*pcapReader, error) {
+	header := make([]byte, 24)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	p := &pcapReader{r: r}
+	switch binary.LittleEndian.Uint32(header) {
+	case 0xA1B2C3D4:
+		p.order = binary.LittleEndian
+	case 0xA1B23C4D:
+		p.order, p.nanos = binary.LittleEndian, true
+	case 0xD4C3B2A1:
+		p.order = binary.BigEndian
+	case 0x4D3CB2A1:
+		p.order, p.nanos = binary.BigEndian, true
+	}
+	p.linkType = p.order.Uint32(header[20:]) & 0x0FFFFFFF
+	return p, nil
+}
+
+func (p *pcapReader) Next() (Frame, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(p.r, header); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return Frame{}, io.EOF
+		}
+		return Frame{}, err
+	}
+	seconds := int64(p.order.Uint32(header[0:]))
+	fraction := int64(p.order.Uint32(header[4:]))
+	length := p.order.Uint32(header[8:])
+	if length > 0x4000000 {
+		return Frame{}, fmt.Errorf("pcap record length %d is too large", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(p.r, data); err != nil {
+		return Frame{}, io.EOF
+	}
+	if !p.nanos {
+		fraction *= 1000
+	}
+	return Frame{Time: time.Unix(seconds, fraction), LinkType: p.linkType, Data: data}, nil
+}
+
+// pcapngInterface is the link type and timestamp resolution of an interface
+// described in a pcapng file
+type pcapngInterface struct {
+	linkType uint32
+	// units is the number of timestamp units per second
+	units uint64
+}
+
+// pcapngReader reads the pcapng format. Each section may use a different
+// byte order and describes its own interfaces
+type pcapngReader struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []pcapngInterface
+}
+
+func newPcapngReader(r io.Reader) (*pcapngReader, error) {
+	return &pcapngReader{r: r, order: binary.LittleEndian}, nil
+}
+
+const (
+	pcapngSectionHeader    = 0x0A0D0D0A
+	pcapngInterfaceDesc    = 0x00000001
+	pcapngSimplePacket     = 0x00000003
+	pcapngEnhancedPacket   = 0x00000006
+	pcapngObsoletePacket   = 0x00000002
+	pcapngOptionEnd        = 0
+	pcapngOptionTsResol    = 9
+	pcapngMaxBlockSize     = 0x4000000
+	pcapngByteOrderMagic   = 0x1A2B3C4D
+	pcapngBlockHeaderBytes = 12
+)
+
+func (p *pcapngReader) Next() (Frame, error) {
+	for {
+		blockType, body, err := p.readBlock()
+		if err != nil {
+			return Frame{}, err
+		}
+		switch blockType {
+		case pcapngInterfaceDesc:
+			if len(body) < 8 {
+				return Frame{}, errors.New("pcapng interface block is too short")
+			}
+			p.interfaces = append(p.interfaces, pcapngInterface{
+				linkType: uint32(p.order.Uint16(body)),
+				units:    p.timestampUnits(body[8:]),
+			})
+		case pcapngEnhancedPacket, pcapngObsoletePacket:
+			if len(body) < 20 {
+				return Frame{}, errors.New("pcapng packet block is too short")
+			}
+			var id uint32
+			if blockType == pcapngEnhancedPacket {
+				id = p.order.Uint32(body)
+			} else {
+				id = uint32(p.order.Uint16(body))
+			}
+			if int(id) >= len(p.interfaces) {
+				return Frame{}, fmt.Errorf("pcapng packet uses unknown interface %d", id)
+			}
+			iface := p.interfaces[id]
+			timestamp := uint64(p.order.Uint32(body[4:]))<<32 | uint64(p.order.Uint32(body[8:]))
+			length := p.order.Uint32(body[12:])
+			if int(length) > len(body)-20 {
+				return Frame{}, errors.New("pcapng packet data is truncated")
+			}
+			return Frame{
+				Time:     unitsToTime(timestamp, iface.units),
+				LinkType: iface.linkType,
+				Data:     body[20 : 20+length],
+			}, nil
+		case pcapngSimplePacket:
+			if len(p.interfaces) == 0 || len(body) < 4 {
+				return Frame{}, errors.New("pcapng simple packet without an interface")
+			}
+			length := p.order.Uint32(body)
+			if int(length) > len(body)-4 {
+				length = uint32(len(body) - 4)
+			}
+			return Frame{LinkType: p.interfaces[0].linkType, Data: body[4 : 4+length]}, nil
+		}
+	}
+}
+
+// readBlock reads the next block returning its type and the body between
+// the header and the trailing length
+func (p *pcapngReader) readBlock() (uint32, []byte, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(p.r, header[:8]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, err
+	}
+	blockType := p.order.Uint32(header)
+	if blockType == pcapngSectionHeader {
+		// The byte order magic follows the length and decides how the
+		// length and everything in the section is read
+		if _, err := io.ReadFull(p.r, header[8:12]); err != nil {
+			return 0, nil, io.EOF
+		}
+		if binary.LittleEndian.Uint32(header[8:]) == pcapngByteOrderMagic {
+			p.order = binary.LittleEndian
+		} else if binary.BigEndian.Uint32(header[8:]) == pcapngByteOrderMagic {
+			p.order = binary.BigEndian
+		} else {
+			return 0, nil, errors.New("invalid pcapng byte order magic")
+		}
+		p.interfaces = nil
+	}
+	length := p.order.Uint32(header[4:])
+	if length < pcapngBlockHeaderBytes || length > pcapngMaxBlockSize || length%4 != 0 {
+		return 0, nil, fmt.Errorf("invalid pcapng block length %d", length)
+	}
+	read := 8
+	if blockType == pcapngSectionHeader {
+		read = 12
+	}
+	rest := make([]byte, int(length)-read)
+	if _, err := io.ReadFull(p.r, rest); err != nil {
+		return 0, nil, io.EOF
+	}
+	// Drop the trailing copy of the block length
+	body := rest[:len(rest)-4]
+	return blockType, body, nil
+}
+
+// timestampUnits finds the if_tsresol option of an interface block which
+// defaults to microseconds
+func (p *pcapngReader) timestampUnits(options []byte) uint64 {
+	for len(options) >= 4 {
+		code := p.order.Uint16(options)
+		length := int(p.order.Uint16(options[2:]))
+		if code == pcapngOptionEnd || 4+length > len(options) {
+			break
+		}
+		if code == pcapngOptionTsResol && length >= 1 {
+			resolution := options[4]
+			exponent := float64(resolution & 0x7F)
+			if resolution&0x80 != 0 {
+				return uint64(math.Pow(2, exponent))
+			}
+			return uint64(math.Pow(10, exponent))
+		}
+		options = options[4+(length+3)/4*4:]
+	}
+	return 1000000
+}
+
+func unitsToTime(timestamp uint64, units uint64) time.Time {
+	if units == 0 {
+		units = 1000000
+	}
+	seconds := timestamp / units
+	fraction := timestamp % units
+	return time.Unix(int64(seconds), int64(fraction*1000000000/units))
+}
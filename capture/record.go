@@ -0,0 +1,170 @@
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+// recordingMagic starts every recording file followed by the version
+var recordingMagic = []byte("GMRC")
+
+const recordingVersion = 1
+
+var ErrNotRecording = errors.New("not a packet recording")
+
+// Record is a single packet of a recording
+type Record struct {
+	Time      time.Time
+	Direction Direction
+	Packet    blaze.Packet
+}
+
+// Recorder writes the packets of a session to a recording. The file starts
+// with a header holding the start time and each packet is written as the
+// time since the previous packet, the direction and the encoded packet
+type Recorder struct {
+	lock   sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer
+	last   time.Time
+}
+
+// NewRecorder writes the recording header to the writer
+func NewRecorder(w io.Writer, start time.Time) (*Recorder, error) {
+	r := &Recorder{w: bufio.NewWriter(w), last: start}
+	if closer, ok := w.(io.Closer); ok {
+		r.closer = closer
+	}
+	header := make([]byte, 0, 13)
+	header = append(header, recordingMagic...)
+	header = append(header, recordingVersion)
+	header = header[:13]
+	binary.BigEndian.PutUint64(header[5:], uint64(start.UnixNano()))
+	if _, err := r.w.Write(header); err != nil {
+		return nil, err
+	}
+	return r, r.w.Flush()
+}
+
+// CreateRecording creates a new recording file at the provided path
+func CreateRecording(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewRecorder(file, time.Now())
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record writes the packet to the recording. Records are flushed straight
+// away so that a crash loses nothing
+func (r *Recorder) Record(direction Direction, packet blaze.Packet) error {
+	buf := blaze.PacketBuff{}
+	return r.RecordRaw(direction, buf.EncodePacketRaw(packet))
+}
+
+// RecordRaw writes an already encoded packet to the recording
+func (r *Recorder) RecordRaw(direction Direction, data []byte) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := time.Now()
+	delta := now.Sub(r.last)
+	if delta < 0 {
+		delta = 0
+	}
+	r.last = r.last.Add(delta)
+	var header [2*binary.MaxVarintLen64 + 1]byte
+	n := binary.PutUvarint(header[:], uint64(delta))
+	header[n] = byte(direction)
+	n++
+	n += binary.PutUvarint(header[n:], uint64(len(data)))
+	if _, err := r.w.Write(header[:n]); err != nil {
+		return err
+	}
+	if _, err := r.w.Write(data); err != nil {
+		return err
+	}
+	return r.w.Flush()
+}
+
+// Close flushes the recording and closes the underlying file
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := r.w.Flush()
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// ReadRecording reads every packet of a recording. A recording that was cut
+// off part way through a packet returns the packets before it
+func ReadRecording(r io.Reader) ([]Record, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, 13)
+	if _, err := io.ReadFull(br, header); err != nil || !bytes.Equal(header[:4], recordingMagic) {
+		return nil, ErrNotRecording
+	}
+	if header[4] != recordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d", header[4])
+	}
+	last := time.Unix(0, int64(binary.BigEndian.Uint64(header[5:])))
+	var out []Record
+	for {
+		delta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return out, nil
+		}
+		direction, err := br.ReadByte()
+		if err != nil {
+			return out, nil
+		}
+		length, err := binary.ReadUvarint(br)
+		if err != nil || length > maxPacketSize {
+			return out, nil
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return out, nil
+		}
+		buff := blaze.PacketBuff{Buffer: bytes.NewBuffer(data)}
+		packet := buff.ReadPacket()
+		if packet == nil {
+			return out, fmt.Errorf("invalid packet in recording at record %d", len(out))
+		}
+		last = last.Add(time.Duration(delta))
+		out = append(out, Record{Time: last, Direction: Direction(direction), Packet: *packet})
+	}
+}
+
+// RecordingTranscript creates a transcript of a single connection from the
+// records so that it can be printed like a capture
+func RecordingTranscript(records []Record) *Transcript {
+	conn := &Conn{Id: 1}
+	transcript := &Transcript{Conns: []*Conn{conn}}
+	for _, record := range records {
+		transcript.Entries = append(transcript.Entries, Entry{
+			Time:      record.Time,
+			Conn:      conn,
+			Direction: record.Direction,
+			Packet:    record.Packet,
+		})
+	}
+	return transcript
+}
@@ -0,0 +1,78 @@
+package capture
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+func TestRecordingRoundTrip(t *testing.T) {
+	out := &bytes.Buffer{}
+	start := time.Unix(1700000000, 0)
+	recorder, err := NewRecorder(out, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := blaze.NewPacket(0x9, 0x7, 0, blaze.RequestType, 1, nil)
+	response := encodePacket(0x9, 0x7, blaze.ResponseType, 1, blaze.NewString("SVER", "GoMES"))
+	if err := recorder.Record(ClientToServer, request); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.RecordRaw(ServerToClient, response); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadRecording(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Direction != ClientToServer || records[1].Direction != ServerToClient {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	if records[0].Time.Before(start) || records[1].Time.Before(records[0].Time) {
+		t.Errorf("unexpected times %v %v", records[0].Time, records[1].Time)
+	}
+	if diff := DiffPackets(records[0].Packet, request, nil); len(diff) != 0 {
+		t.Errorf("request changed %v", diff)
+	}
+
+	// A recording cut off part way through a packet keeps the packets before it
+	records, err = ReadRecording(bytes.NewReader(out.Bytes()[:out.Len()-3]))
+	if err != nil || len(records) != 1 {
+		t.Errorf("expected 1 record from truncated recording got %d %v", len(records), err)
+	}
+	if _, err := ReadRecording(bytes.NewBufferString("nope")); err != ErrNotRecording {
+		t.Errorf("expected not a recording got %v", err)
+	}
+}
+
+func TestDiffPackets(t *testing.T) {
+	expected := packetFrom(t, encodePacket(0x9, 0x8, blaze.ResponseType, 2,
+		blaze.NewString("NAME", "Shepard"), blaze.NewInt64("TIME", 100), blaze.NewInt64("LVL", 30)))
+	actual := packetFrom(t, encodePacket(0x9, 0x8, blaze.ResponseType, 2,
+		blaze.NewString("NAME", "Garrus"), blaze.NewInt64("TIME", 200), blaze.NewInt64("XP", 5)))
+	diff := DiffPackets(expected, actual, []string{"TIME"})
+	want := []string{`NAME: "Shepard" != "Garrus"`, "LVL: missing, expected 30", "XP: unexpected 5"}
+	if len(diff) != len(want) {
+		t.Fatalf("unexpected differences %q", diff)
+	}
+	for i := range want {
+		if diff[i] != want[i] {
+			t.Errorf("difference %d is %q expected %q", i, diff[i], want[i])
+		}
+	}
+}
+
+func packetFrom(t *testing.T, data []byte) blaze.Packet {
+	buff := blaze.PacketBuff{Buffer: bytes.NewBuffer(data)}
+	packet := buff.ReadPacket()
+	if packet == nil {
+		t.Fatal("invalid packet")
+	}
+	return *packet
+}
@@ -0,0 +1,124 @@
+package capture
+
+import "time"
+
+// maxPendingSegments is the number of out of order segments kept for a
+// stream before the missing data is given up on
+const maxPendingSegments = 4096
+
+type pendingSegment struct {
+	data []byte
+	time time.Time
+}
+
+// halfStream reassembles one direction of a TCP connection delivering the
+// data in order to its consumer
+type halfStream struct {
+	started bool
+	syn     bool
+	isn     uint32
+	next    uint32
+	pending map[uint32]pendingSegment
+
+	// deliver is called with in order data along with the time of the
+	// segment it arrived in
+	deliver func(data []byte, t time.Time)
+	// gap is called when data was lost and the stream skipped ahead
+	gap func()
+}
+
+// reopened checks whether the segment opens a new connection on the same
+// addresses rather than being a retransmitted SYN
+func (h *halfStream) reopened(segment Segment) bool {
+	return segment.SYN && h.started && (!h.syn || segment.Seq != h.isn)
+}
+
+func (h *halfStream) add(segment Segment, t time.Time) {
+	seq := segment.Seq
+	if segment.SYN {
+		// The SYN uses a sequence number of its own
+		h.syn, h.isn = true, seq
+		seq++
+		h.next = seq
+		h.started = true
+	}
+	if len(segment.Payload) == 0 {
+		return
+	}
+	if !h.started {
+		// The capture started after the connection was opened
+		h.next = seq
+		h.started = true
+	}
+	h.insert(seq, segment.Payload, t)
+	h.flush()
+}
+
+// insert delivers the data if it is next in the stream otherwise it is kept
+// until the data before it arrives. Retransmitted data is dropped
+func (h *halfStream) insert(seq uint32, data []byte, t time.Time) {
+	diff := int32(seq - h.next)
+	if int(diff)+len(data) <= 0 {
+		return
+	}
+	if diff < 0 {
+		data = data[-diff:]
+		diff = 0
+		seq = h.next
+	}
+	if diff == 0 {
+		h.next += uint32(len(data))
+		h.deliver(data, t)
+		return
+	}
+	if h.pending == nil {
+		h.pending = map[uint32]pendingSegment{}
+	}
+	if existing, ok := h.pending[seq]; !ok || len(existing.data) < len(data) {
+		h.pending[seq] = pendingSegment{data: append([]byte{}, data...), time: t}
+	}
+	if len(h.pending) > maxPendingSegments {
+		h.skip()
+	}
+}
+
+// flush delivers any pending segments that are now in order
+func (h *halfStream) flush() {
+	for len(h.pending) > 0 {
+		found := false
+		for seq, segment := range h.pending {
+			if int32(seq-h.next) <= 0 {
+				delete(h.pending, seq)
+				h.insert(seq, segment.data, segment.time)
+				found = true
+			}
+		}
+		if !found {
+			return
+		}
+	}
+}
+
+// skip gives up on missing data moving the stream to the earliest pending
+// segment
+func (h *halfStream) skip() {
+	first := true
+	var lowest uint32
+	for seq := range h.pending {
+		if first || int32(seq-lowest) < 0 {
+			lowest = seq
+			first = false
+		}
+	}
+	h.next = lowest
+	h.gap()
+	h.flush()
+}
+
+// finish is called at the end of the capture. Any remaining pending data is
+// delivered after reporting the gap before it
+func (h *halfStream) finish() {
+	if len(h.pending) > 0 {
+		h.skip()
+	}
+}
@@ -0,0 +1,160 @@
+package capture
+
+import (
+	"bufio"
+	"crypto/rc4"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RC4Keys are the client and server write keys of an SSL connection using
+// an RC4 cipher suite
+type RC4Keys struct {
+	Client []byte
+	Server []byte
+}
+
+// Keys are the known RC4 keys keyed by the address of the client. The key
+// "*" is used for connections without keys of their own
+type Keys map[string]RC4Keys
+
+// Find returns the keys for the connection from the provided client address
+func (k Keys) Find(client string) (RC4Keys, bool) {
+	if keys, ok := k[client]; ok {
+		return keys, true
+	}
+	keys, ok := k["*"]
+	return keys, ok
+}
+
+// ParseKeys reads a key file. Each line holds the client address (or "*")
+// followed by the client and server write keys as hex. Empty lines and
+// lines starting with # are ignored
+func ParseKeys(r io.Reader) (Keys, error) {
+	keys := Keys{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected client address, client key and server key", line)
+		}
+		client, err := hex.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid client key: %w", line, err)
+		}
+		server, err := hex.DecodeString(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid server key: %w", line, err)
+		}
+		keys[fields[0]] = RC4Keys{Client: client, Server: server}
+	}
+	return keys, scanner.Err()
+}
+
+// SSL record content types
+const (
+	recordChangeCipherSpec = 20
+	recordAlert            = 21
+	recordHandshake        = 22
+	recordApplicationData  = 23
+)
+
+const handshakeServerHello = 2
+
+// macSizes are the MAC sizes of the RC4 cipher suites
+var macSizes = map[uint16]int{
+	0x0004: 16, // RC4_128_MD5
+	0x0005: 20, // RC4_128_SHA
+}
+
+// isTLS checks whether the start of a stream is an SSL record
+func isTLS(data []byte) bool {
+	return len(data) >= 3 && data[0] == recordHandshake && data[1] == 3
+}
+
+// tlsStream decodes the SSL records of one direction of a connection
+// passing the decrypted application data to the output
+type tlsStream struct {
+	conn   *Conn
+	key    []byte
+	buf    []byte
+	cipher *rc4.Cipher
+	// encrypted is set once the change cipher spec has been sent
+	encrypted bool
+	failed    bool
+	output    func(data []byte)
+}
+
+func (s *tlsStream) write(data []byte) {
+	if s.failed {
+		return
+	}
+	s.buf = append(s.buf, data...)
+	for len(s.buf) >= 5 {
+		length := int(binary.BigEndian.Uint16(s.buf[3:]))
+		if len(s.buf) < 5+length {
+			return
+		}
+		recordType := s.buf[0]
+		body := append([]byte{}, s.buf[5:5+length]...)
+		s.buf = s.buf[5+length:]
+		s.record(recordType, body)
+		if s.failed {
+			return
+		}
+	}
+}
+
+func (s *tlsStream) record(recordType byte, body []byte) {
+	if s.encrypted {
+		if s.cipher == nil {
+			s.fail("encrypted without a known key")
+			return
+		}
+		s.cipher.XORKeyStream(body, body)
+		if s.conn.macSize == 0 || len(body) < s.conn.macSize {
+			s.fail("unknown cipher suite or short record")
+			return
+		}
+		body = body[:len(body)-s.conn.macSize]
+	}
+	switch recordType {
+	case recordChangeCipherSpec:
+		s.encrypted = true
+		if s.key != nil {
+			cipher, err := rc4.NewCipher(s.key)
+			if err != nil {
+				s.fail(err.Error())
+				return
+			}
+			s.cipher = cipher
+		}
+		s.conn.Encrypted = true
+	case recordHandshake:
+		if !s.encrypted && len(body) >= 39 && body[0] == handshakeServerHello {
+			sessionLength := int(body[38])
+			if len(body) >= 41+sessionLength {
+				suite := binary.BigEndian.Uint16(body[39+sessionLength:])
+				s.conn.CipherSuite = suite
+				s.conn.macSize = macSizes[suite]
+			}
+		}
+	case recordApplicationData:
+		s.output(body)
+	case recordAlert:
+	}
+}
+
+func (s *tlsStream) fail(reason string) {
+	s.failed = true
+	s.conn.addError(reason)
+}
@@ -0,0 +1,240 @@
+// Package capture reads pcap and pcapng captures reconstructing the Blaze
+// packets sent over the redirector and main server connections
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+// DefaultPorts are the server ports of the redirector and main server
+var DefaultPorts = []int{42127, 14219}
+
+// Direction is the direction a packet was sent in
+type Direction int
+
+const (
+	ClientToServer Direction = iota
+	ServerToClient
+)
+
+func (d Direction) String() string {
+	if d == ClientToServer {
+		return "C->S"
+	}
+	return "S->C"
+}
+
+// Conn is a single TCP connection to one of the server ports
+type Conn struct {
+	Id     int
+	Client net.TCPAddr
+	Server net.TCPAddr
+	// Encrypted is set for connections using SSL
+	Encrypted   bool
+	CipherSuite uint16
+	// Errors describes anything that stopped packets being extracted
+	Errors []string
+
+	macSize int
+	halves  [2]*halfStream
+}
+
+func (c *Conn) addError(reason string) {
+	c.Errors = append(c.Errors, reason)
+}
+
+// Entry is a single Blaze packet from a connection
+type Entry struct {
+	Time      time.Time
+	Conn      *Conn
+	Direction Direction
+	Packet    blaze.Packet
+}
+
+// Transcript is every Blaze packet in a capture in the order they were
+// completed
+type Transcript struct {
+	Conns   []*Conn
+	Entries []Entry
+}
+
+// Options change which connections are read and how they are decrypted
+type Options struct {
+	// Ports are the server ports, DefaultPorts when empty
+	Ports []int
+	// Keys are the RC4 keys used for encrypted connections
+	Keys Keys
+}
+
+var errPacketTooLarge = errors.New("packet is too large")
+
+// maxPacketSize limits the size of a single packet so that a stream that
+// has lost its place doesn't buffer the rest of the capture
+const maxPacketSize = 0x1000000
+
+// Read reads every frame of the capture building a transcript of the Blaze
+// packets sent to and from the server ports
+func Read(r io.Reader, options Options) (*Transcript, error) {
+	frames, err := NewFrameReader(r)
+	if err != nil {
+		return nil, err
+	}
+	ports := options.Ports
+	if len(ports) == 0 {
+		ports = DefaultPorts
+	}
+	isServer := func(port int) bool {
+		for _, p := range ports {
+			if p == port {
+				return true
+			}
+		}
+		return false
+	}
+	transcript := &Transcript{}
+	conns := map[string]*Conn{}
+	for {
+		frame, err := frames.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return transcript, err
+		}
+		segment, ok := ParseSegment(frame)
+		if !ok {
+			continue
+		}
+		var client, server net.TCPAddr
+		var direction Direction
+		switch {
+		case isServer(segment.Dst.Port):
+			client, server, direction = segment.Src, segment.Dst, ClientToServer
+		case isServer(segment.Src.Port):
+			client, server, direction = segment.Dst, segment.Src, ServerToClient
+		default:
+			continue
+		}
+		key := client.String() + "-" + server.String()
+		conn := conns[key]
+		// A new SYN from the client reuses the address for a new connection
+		if conn == nil || (direction == ClientToServer && conn.halves[direction].reopened(segment)) {
+			conn = transcript.newConn(client, server, options.Keys)
+			conns[key] = conn
+		}
+		conn.halves[direction].add(segment, frame.Time)
+	}
+	for _, conn := range transcript.Conns {
+		for _, half := range conn.halves {
+			half.finish()
+		}
+	}
+	return transcript, nil
+}
+
+// newConn creates a connection along with the streams that decode each of
+// its directions
+func (t *Transcript) newConn(client net.TCPAddr, server net.TCPAddr, keys Keys) *Conn {
+	conn := &Conn{Id: len(t.Conns) + 1, Client: client, Server: server}
+	t.Conns = append(t.Conns, conn)
+	rc4Keys, hasKeys := keys.Find(client.String())
+	for _, direction := range []Direction{ClientToServer, ServerToClient} {
+		direction := direction
+		packets := &packetStream{}
+		var last time.Time
+		packets.output = func(packet blaze.Packet) {
+			t.Entries = append(t.Entries, Entry{Time: last, Conn: conn, Direction: direction, Packet: packet})
+		}
+		packets.fail = func(err error) {
+			conn.addError(fmt.Sprintf("%s: %v", direction, err))
+		}
+		tls := &tlsStream{conn: conn, output: packets.write}
+		if hasKeys {
+			tls.key = rc4Keys.Client
+			if direction == ServerToClient {
+				tls.key = rc4Keys.Server
+			}
+		}
+		decided, useTLS := false, false
+		half := &halfStream{}
+		half.deliver = func(data []byte, at time.Time) {
+			last = at
+			if !decided {
+				decided, useTLS = true, isTLS(data)
+			}
+			if useTLS {
+				tls.write(data)
+			} else {
+				packets.write(data)
+			}
+		}
+		half.gap = func() {
+			conn.addError(fmt.Sprintf("%s: missing data in capture", direction))
+			// The RC4 stream can't continue past missing data but plain
+			// packets can pick up again once the partial packet is dropped
+			tls.failed = true
+			packets.buf = nil
+			packets.failed = false
+		}
+		conn.halves[direction] = half
+	}
+	return conn
+}
+
+// packetStream splits the data of one direction into Blaze packets
+type packetStream struct {
+	buf    []byte
+	failed bool
+	output func(packet blaze.Packet)
+	fail   func(err error)
+}
+
+func (s *packetStream) write(data []byte) {
+	if s.failed {
+		return
+	}
+	s.buf = append(s.buf, data...)
+	for {
+		size, ok := packetSize(s.buf)
+		if !ok || len(s.buf) < size {
+			return
+		}
+		if size > maxPacketSize {
+			s.failed = true
+			s.fail(errPacketTooLarge)
+			return
+		}
+		buff := blaze.PacketBuff{Buffer: bytes.NewBuffer(s.buf[:size])}
+		packet := buff.ReadPacket()
+		s.buf = s.buf[size:]
+		if packet != nil {
+			s.output(*packet)
+		}
+	}
+}
+
+// packetSize returns the total size of the packet at the start of the data
+// including the header. False is returned when the header is incomplete
+func packetSize(data []byte) (int, bool) {
+	if len(data) < 12 {
+		return 0, false
+	}
+	length := int(binary.BigEndian.Uint16(data))
+	header := 12
+	if binary.BigEndian.Uint16(data[8:])&0x10 != 0 {
+		if len(data) < 14 {
+			return 0, false
+		}
+		header = 14
+		length += int(binary.BigEndian.Uint16(data[12:])) << 16
+	}
+	return header + length, true
+}
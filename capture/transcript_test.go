@@ -0,0 +1,269 @@
+package capture
+
+import (
+	"bytes"
+	"crypto/rc4"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+var (
+	clientIP = []byte{192, 168, 1, 10}
+	serverIP = []byte{192, 168, 1, 20}
+)
+
+const clientPort = 50000
+
+// testSegment is a TCP segment used to build captures
+type testSegment struct {
+	toServer bool
+	seq      uint32
+	syn      bool
+	payload  []byte
+}
+
+// ethernetFrame builds an ethernet frame containing an IPv4 TCP segment
+func ethernetFrame(segment testSegment, serverPort int) []byte {
+	src, dst := clientIP, serverIP
+	srcPort, dstPort := clientPort, serverPort
+	if !segment.toServer {
+		src, dst = dst, src
+		srcPort, dstPort = dstPort, srcPort
+	}
+	tcp := make([]byte, 20, 20+len(segment.payload))
+	binary.BigEndian.PutUint16(tcp[0:], uint16(srcPort))
+	binary.BigEndian.PutUint16(tcp[2:], uint16(dstPort))
+	binary.BigEndian.PutUint32(tcp[4:], segment.seq)
+	tcp[12] = 5 << 4
+	tcp[13] = 0x10
+	if segment.syn {
+		tcp[13] |= tcpSYN
+	}
+	tcp = append(tcp, segment.payload...)
+
+	ip := make([]byte, 20, 20+len(tcp))
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(20+len(tcp)))
+	ip[8] = 64
+	ip[9] = protoTCP
+	copy(ip[12:], src)
+	copy(ip[16:], dst)
+	ip = append(ip, tcp...)
+
+	frame := make([]byte, 14, 14+len(ip))
+	binary.BigEndian.PutUint16(frame[12:], 0x0800)
+	return append(frame, ip...)
+}
+
+func writePcap(segments []testSegment, serverPort int) []byte {
+	out := &bytes.Buffer{}
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], 0xA1B2C3D4)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	binary.LittleEndian.PutUint32(header[16:], 65535)
+	binary.LittleEndian.PutUint32(header[20:], LinkEthernet)
+	out.Write(header)
+	for i, segment := range segments {
+		frame := ethernetFrame(segment, serverPort)
+		record := make([]byte, 16)
+		binary.LittleEndian.PutUint32(record[0:], 1700000000)
+		binary.LittleEndian.PutUint32(record[4:], uint32(i*1000))
+		binary.LittleEndian.PutUint32(record[8:], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(record[12:], uint32(len(frame)))
+		out.Write(record)
+		out.Write(frame)
+	}
+	return out.Bytes()
+}
+
+func pcapngBlock(blockType uint32, body []byte) []byte {
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	out := make([]byte, 8, 12+len(body))
+	binary.BigEndian.PutUint32(out[0:], blockType)
+	binary.BigEndian.PutUint32(out[4:], uint32(12+len(body)))
+	out = append(out, body...)
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(12+len(body)))
+	return append(out, length...)
+}
+
+// writePcapng builds a big endian pcapng file with nanosecond timestamps
+func writePcapng(segments []testSegment, serverPort int) []byte {
+	out := &bytes.Buffer{}
+	section := make([]byte, 16)
+	binary.BigEndian.PutUint32(section[0:], pcapngByteOrderMagic)
+	binary.BigEndian.PutUint16(section[4:], 1)
+	binary.BigEndian.PutUint64(section[8:], 0xFFFFFFFFFFFFFFFF)
+	out.Write(pcapngBlock(pcapngSectionHeader, section))
+
+	iface := make([]byte, 8)
+	binary.BigEndian.PutUint16(iface[0:], uint16(LinkEthernet))
+	// if_tsresol of 10^-9 followed by the end of the options
+	iface = append(iface, 0, 9, 0, 1, 9, 0, 0, 0, 0, 0, 0, 0)
+	out.Write(pcapngBlock(pcapngInterfaceDesc, iface))
+
+	for i, segment := range segments {
+		frame := ethernetFrame(segment, serverPort)
+		timestamp := uint64(1700000000+i) * 1000000000
+		body := make([]byte, 20, 20+len(frame))
+		binary.BigEndian.PutUint32(body[4:], uint32(timestamp>>32))
+		binary.BigEndian.PutUint32(body[8:], uint32(timestamp))
+		binary.BigEndian.PutUint32(body[12:], uint32(len(frame)))
+		binary.BigEndian.PutUint32(body[16:], uint32(len(frame)))
+		out.Write(pcapngBlock(pcapngEnhancedPacket, append(body, frame...)))
+	}
+	return out.Bytes()
+}
+
+func encodePacket(component uint16, command uint16, qType uint16, id uint16, values ...blaze.Tdf) []byte {
+	buff := blaze.PacketBuff{Buffer: &bytes.Buffer{}}
+	return buff.EncodePacket(component, command, 0, qType, id, values)
+}
+
+// splitSegments creates the segments carrying the data starting at the
+// provided sequence number with each segment holding at most size bytes
+func splitSegments(toServer bool, seq uint32, data []byte, size int) []testSegment {
+	var out []testSegment
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		out = append(out, testSegment{toServer: toServer, seq: seq, payload: data[:n]})
+		seq += uint32(n)
+		data = data[n:]
+	}
+	return out
+}
+
+func TestReadPlaintext(t *testing.T) {
+	request := encodePacket(0x9, 0x7, blaze.RequestType, 1, blaze.NewString("CLNT", "MassEffect3-pc"))
+	response := encodePacket(0x9, 0x7, blaze.ResponseType, 1, blaze.NewInt64("ANON", 0))
+
+	requestSegments := splitSegments(true, 1001, request, 7)
+	// Reorder the request and retransmit one of its segments
+	requestSegments[0], requestSegments[2] = requestSegments[2], requestSegments[0]
+	requestSegments = append(requestSegments, requestSegments[1])
+
+	segments := []testSegment{
+		{toServer: true, seq: 1000, syn: true},
+		{toServer: false, seq: 5000, syn: true},
+	}
+	segments = append(segments, requestSegments...)
+	segments = append(segments, splitSegments(false, 5001, response, 9)...)
+
+	for name, data := range map[string][]byte{
+		"pcap":   writePcap(segments, 14219),
+		"pcapng": writePcapng(segments, 14219),
+	} {
+		transcript, err := Read(bytes.NewReader(data), Options{})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(transcript.Conns) != 1 || len(transcript.Conns[0].Errors) != 0 {
+			t.Fatalf("%s: unexpected connections %+v", name, transcript.Conns)
+		}
+		if len(transcript.Entries) != 2 {
+			t.Fatalf("%s: expected 2 packets got %d", name, len(transcript.Entries))
+		}
+		first, second := transcript.Entries[0], transcript.Entries[1]
+		if first.Direction != ClientToServer || first.Packet.ToDescriptor() != "Util Component:preAuth" {
+			t.Errorf("%s: unexpected request %s %s", name, first.Direction, first.Packet.ToDescriptor())
+		}
+		if second.Direction != ServerToClient || second.Packet.QType != blaze.ResponseType {
+			t.Errorf("%s: unexpected response %s %x", name, second.Direction, second.Packet.QType)
+		}
+		if !second.Time.After(first.Time) || first.Time.Before(time.Unix(1700000000, 0)) {
+			t.Errorf("%s: unexpected times %v %v", name, first.Time, second.Time)
+		}
+	}
+}
+
+// sslRecord builds an SSL record encrypting it with the cipher when one is
+// provided. A zeroed SHA-1 sized MAC is appended to encrypted records
+func sslRecord(recordType byte, body []byte, cipher *rc4.Cipher) []byte {
+	if cipher != nil {
+		body = append(append([]byte{}, body...), make([]byte, 20)...)
+		cipher.XORKeyStream(body, body)
+	}
+	out := []byte{recordType, 3, 0, 0, 0}
+	binary.BigEndian.PutUint16(out[3:], uint16(len(body)))
+	return append(out, body...)
+}
+
+func TestReadRC4(t *testing.T) {
+	clientKey := bytes.Repeat([]byte{0x11}, 16)
+	serverKey := bytes.Repeat([]byte{0x22}, 16)
+	clientCipher, _ := rc4.NewCipher(clientKey)
+	serverCipher, _ := rc4.NewCipher(serverKey)
+
+	// Server hello with an empty session ID selecting RC4_128_SHA
+	hello := make([]byte, 4+2+32+1+2+1)
+	hello[0] = handshakeServerHello
+	hello[3] = byte(len(hello) - 4)
+	hello[4] = 3
+	binary.BigEndian.PutUint16(hello[39:], 0x0005)
+
+	request := encodePacket(0x5, 0x1, blaze.RequestType, 0)
+	response := encodePacket(0x5, 0x1, blaze.ResponseType, 0, blaze.NewInt64("SECU", 1))
+
+	clientHello := sslRecord(recordHandshake, []byte{1, 0, 0, 0}, nil)
+	var client, server []byte
+	server = append(server, sslRecord(recordHandshake, hello, nil)...)
+	server = append(server, sslRecord(recordChangeCipherSpec, []byte{1}, nil)...)
+	server = append(server, sslRecord(recordHandshake, []byte{20, 0, 0, 0}, serverCipher)...)
+	client = append(client, sslRecord(recordChangeCipherSpec, []byte{1}, nil)...)
+	client = append(client, sslRecord(recordHandshake, []byte{20, 0, 0, 0}, clientCipher)...)
+	client = append(client, sslRecord(recordApplicationData, request, clientCipher)...)
+	server = append(server, sslRecord(recordApplicationData, response, serverCipher)...)
+
+	// The client hello is sent before the server picks the cipher suite
+	segments := splitSegments(true, 1, clientHello, 10)
+	segments = append(segments, splitSegments(false, 1, server, 10)...)
+	segments = append(segments, splitSegments(true, uint32(1+len(clientHello)), client, 10)...)
+	data := writePcap(segments, 42127)
+
+	transcript, err := Read(bytes.NewReader(data), Options{Keys: Keys{"*": {Client: clientKey, Server: serverKey}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript.Conns) != 1 || !transcript.Conns[0].Encrypted || len(transcript.Conns[0].Errors) != 0 {
+		t.Fatalf("unexpected connections %+v", *transcript.Conns[0])
+	}
+	if len(transcript.Entries) != 2 {
+		t.Fatalf("expected 2 packets got %d", len(transcript.Entries))
+	}
+	if descriptor := transcript.Entries[1].Packet.ToDescriptor(); descriptor != "Redirect Component:getServerInstance" {
+		t.Errorf("unexpected response %s", descriptor)
+	}
+
+	transcript, err = Read(bytes.NewReader(data), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript.Entries) != 0 || len(transcript.Conns[0].Errors) == 0 {
+		t.Errorf("expected connection without keys to fail %+v", transcript.Conns[0])
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys(bytes.NewBufferString("# keys\n\n10.0.0.1:5000 0011 2233\n* aa bb\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, ok := keys.Find("10.0.0.1:5000"); !ok || !bytes.Equal(k.Server, []byte{0x22, 0x33}) {
+		t.Errorf("unexpected keys %+v", k)
+	}
+	if k, ok := keys.Find("10.0.0.2:5000"); !ok || !bytes.Equal(k.Client, []byte{0xAA}) {
+		t.Errorf("expected fallback keys %+v", k)
+	}
+	if _, err := ParseKeys(bytes.NewBufferString("* zz 00\n")); err == nil {
+		t.Error("expected invalid key error")
+	}
+}
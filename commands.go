@@ -0,0 +1,500 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
+	"github.com/jacobtread/gomes/game"
+	"github.com/jacobtread/gomes/server"
+	"github.com/jacobtread/gomes/store"
+)
+
+// dataFlag registers the flag used to choose the data directory
+func dataFlag(flags *flag.FlagSet) {
+	flags.StringVar(&server.DataDir, "data", server.DataDir, "directory the server data is stored in")
+}
+
+// dnsFlags registers the flags used to configure the DNS responder
+func dnsFlags(flags *flag.FlagSet) {
+	flags.BoolVar(&server.DnsEnabled, "dns", server.DnsEnabled, "answer DNS queries for the redirector host names")
+	flags.IntVar(&server.DnsPort, "dns-port", server.DnsPort, "port of the DNS responder")
+	flags.StringVar(&server.DnsAddress, "dns-address", server.DnsAddress, "address given for the redirector host names (default the outbound address)")
+	flags.StringVar(&server.DnsUpstream, "dns-upstream", server.DnsUpstream, "host:port of the DNS server other queries are forwarded to such as 1.1.1.1:53 (default refuse them)")
+}
+
+// adminFlag registers the flag used to set the admin token which defaults to
+// the GOMES_ADMIN_TOKEN environment variable
+func adminFlag(flags *flag.FlagSet, token *string) {
+	flags.StringVar(token, "admin-token", os.Getenv("GOMES_ADMIN_TOKEN"), "bearer token for the admin HTTP endpoints, empty to disable them")
+}
+
+// redirectorFlags registers the flags used to configure the redirector
+func redirectorFlags(flags *flag.FlagSet) {
+	flags.IntVar(&server.RedirectorPort, "redirector-port", server.RedirectorPort, "port of the redirector")
+	flags.BoolVar(&server.RedirectorTLS, "redirector-tls", server.RedirectorTLS, "accept TLS connections on the redirector using the generated certificates")
+	flags.StringVar(&server.MainHost, "main-host", server.MainHost, "host name or address of the main server given to clients (default the local address)")
+	flags.IntVar(&server.MainPort, "main-port", server.MainPort, "port of the main server given to clients (default the game port)")
+	dnsFlags(flags)
+	flags.StringVar(&server.RecordDir, "record", server.RecordDir, "directory to record the packets of every connection to")
+}
+
+// ensureCertificates generates certificates in the data directory when
+// there aren't any yet and prints their fingerprints
+func ensureCertificates() error {
+	dir := filepath.Join(server.DataDir, server.CertDir)
+	if !server.CertificatesExist(dir) {
+		if err := server.GenerateCertificates(dir, false); err != nil {
+			return fmt.Errorf("generating certificates: %w", err)
+		}
+		log.Println("Generated certificates in", dir)
+	}
+	server.PrintFingerprints(dir)
+	return nil
+}
+
+func serve(flags *flag.FlagSet, args []string) error {
+	dataFlag(flags)
+	redirectorFlags(flags)
+	withRedirector := flags.Bool("redirector", true, "also run the redirector")
+	generateCerts := flags.Bool("generate-certs", false, "generate certificates in the data directory if they don't exist")
+	flags.IntVar(&server.GamePort, "port", server.GamePort, "port of the main server")
+	flags.IntVar(&server.HttpPort, "http-port", server.HttpPort, "port of the HTTP server")
+	flags.IntVar(&server.TelemetryPort, "telemetry-port", server.TelemetryPort, "port of the telemetry server")
+	flags.IntVar(&server.TickerPort, "ticker-port", server.TickerPort, "port of the ticker server")
+	flags.IntVar(&server.QosHttpPort, "qos-http-port", server.QosHttpPort, "port of the QoS HTTP server")
+	flags.IntVar(&server.QosUdpPort, "qos-udp-port", server.QosUdpPort, "port of the QoS UDP responder")
+	flags.StringVar(&server.Motd, "motd", server.Motd, "message of the day sent to players, empty to disable")
+	flags.Int64Var(&game.RatingDecayPerDay, "gaw-decay", game.RatingDecayPerDay, "amount Galaxy at War ratings drop by each day, zero to disable")
+	flags.Int64Var(&server.PackSeed, "pack-seed", server.PackSeed, "seed used when rolling pack items (default the current time)")
+	flags.BoolVar(&blaze.LintLabels, "lint-labels", blaze.LintLabels, "log labels that don't round trip through a tag")
+	flags.BoolVar(&server.ValidateRequests, "validate", server.ValidateRequests, "log requests that don't match the known schemas")
+	flags.BoolVar(&server.LogPackets, "log-packets", server.LogPackets, "log the command of every packet received")
+	adminFlag(flags, &server.AdminToken)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *generateCerts {
+		if err := ensureCertificates(); err != nil {
+			return err
+		}
+	}
+	if err := server.LoadData(server.DataDir); err != nil {
+		return fmt.Errorf("loading server data: %w", err)
+	}
+	go server.StartMain()
+	if *withRedirector {
+		go server.StartRedirector()
+	}
+	go server.StartTelemetry()
+	go server.StartTicker()
+	go server.StartQos()
+	go server.StartHttp()
+	select {}
+}
+
+func redirector(flags *flag.FlagSet, args []string) error {
+	dataFlag(flags)
+	redirectorFlags(flags)
+	generateCerts := flags.Bool("generate-certs", false, "generate certificates in the data directory if they don't exist")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *generateCerts {
+		if err := ensureCertificates(); err != nil {
+			return err
+		}
+	}
+	server.StartRedirector()
+	return nil
+}
+
+func decode(flags *flag.FlagSet, args []string) error {
+	format := flags.String("format", formatAuto, "input format: auto, hex, base64 or binary")
+	asJson := flags.Bool("json", false, "write the packets as json")
+	asText := flags.Bool("text", false, "write the packet contents in the text format instead of a tree")
+	schemas := flags.Bool("schemas", false, "annotate fields with their meaning and check packets against the known schemas")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var data []byte
+	var err error
+	if flags.NArg() > 0 {
+		data, err = os.ReadFile(flags.Arg(0))
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	raw, err := decodeInput(data, *format)
+	if err != nil {
+		return fmt.Errorf("invalid %s input: %w", *format, err)
+	}
+	packets, trailing := readPackets(raw)
+	if *asJson {
+		if err := writePacketsJson(os.Stdout, packets); err != nil {
+			return err
+		}
+	} else {
+		for _, packet := range packets {
+			printPacket(os.Stdout, packet, formatOptions(*asText), *schemas)
+		}
+	}
+	if trailing > 0 {
+		return fmt.Errorf("%d trailing bytes do not form a complete packet", trailing)
+	}
+	return nil
+}
+
+func encode(flags *flag.FlagSet, args []string) error {
+	format := flags.String("format", formatHex, "output format: hex, base64 or binary")
+	lint := flags.Bool("lint", false, "fail on labels that don't round trip through a tag")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var data []byte
+	var err error
+	if flags.NArg() > 0 {
+		data, err = os.ReadFile(flags.Arg(0))
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	var packets []jsonPacket
+	if err := json.Unmarshal(data, &packets); err != nil {
+		return fmt.Errorf("invalid json: %w", err)
+	}
+	var out []byte
+	for i, packet := range packets {
+		encoded, err := encodePacketJson(packet, *lint)
+		if err != nil {
+			return fmt.Errorf("packet %d: %w", i, err)
+		}
+		out = append(out, encoded...)
+	}
+	switch *format {
+	case formatHex:
+		fmt.Println(hex.EncodeToString(out))
+	case formatBase64:
+		fmt.Println(base64.StdEncoding.EncodeToString(out))
+	case formatBinary:
+		_, err = os.Stdout.Write(out)
+	default:
+		return fmt.Errorf("unknown output format %q", *format)
+	}
+	return err
+}
+
+func pcap(flags *flag.FlagSet, args []string) error {
+	ports := flags.String("ports", joinPorts(capture.DefaultPorts), "comma separated server ports to read connections for")
+	keysFile := flags.String("keys", "", "file of RC4 keys for encrypted connections, one \"<client address|*> <client key> <server key>\" per line")
+	asJson := flags.Bool("json", false, "write the transcript as json")
+	asText := flags.Bool("text", false, "write the packet contents in the text format instead of a tree")
+	schemas := flags.Bool("schemas", false, "annotate fields with their meaning and check packets against the known schemas")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected a capture file")
+	}
+	options := capture.Options{}
+	for _, value := range strings.Split(*ports, ",") {
+		port, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid port %q", value)
+		}
+		options.Ports = append(options.Ports, port)
+	}
+	if *keysFile != "" {
+		file, err := os.Open(*keysFile)
+		if err != nil {
+			return err
+		}
+		options.Keys, err = capture.ParseKeys(file)
+		_ = file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	transcript, err := capture.Read(file, options)
+	if err != nil && transcript == nil {
+		return err
+	}
+	for _, conn := range transcript.Conns {
+		for _, reason := range conn.Errors {
+			fmt.Fprintf(os.Stderr, "Connection #%d %s -> %s: %s\n", conn.Id, &conn.Client, &conn.Server, reason)
+		}
+	}
+	if *asJson {
+		if err := writeTranscriptJson(os.Stdout, transcript); err != nil {
+			return err
+		}
+	} else {
+		printTranscript(os.Stdout, transcript, formatOptions(*asText), *schemas)
+	}
+	return err
+}
+
+func joinPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, port := range ports {
+		parts[i] = strconv.Itoa(port)
+	}
+	return strings.Join(parts, ",")
+}
+
+func replay(flags *flag.FlagSet, args []string) error {
+	data := flags.String("data", "", "data directory used by the server (default a new temporary directory)")
+	printOnly := flags.Bool("print", false, "only print the recorded packets")
+	asJson := flags.Bool("json", false, "print the recorded packets as json")
+	redirector := flags.Bool("redirector", false, "replay against the redirector (default when the file name contains \"-redirector-\")")
+	timeout := flags.Duration("timeout", 5*time.Second, "how long to wait for the responses to each request")
+	ignore := flags.String("ignore", strings.Join(capture.DefaultIgnoredLabels, ","), "comma separated labels of values that are not compared")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected a recording file")
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	records, err := capture.ReadRecording(file)
+	_ = file.Close()
+	if err != nil {
+		return err
+	}
+	if *printOnly || *asJson {
+		transcript := capture.RecordingTranscript(records)
+		if *asJson {
+			return writeTranscriptJson(os.Stdout, transcript)
+		}
+		printTranscript(os.Stdout, transcript, treeOptions, false)
+		return nil
+	}
+
+	if *data == "" {
+		dir, err := os.MkdirTemp("", "gomes-replay")
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+		*data = dir
+	}
+	server.DataDir = *data
+	if err := server.LoadData(server.DataDir); err != nil {
+		return fmt.Errorf("loading server data: %w", err)
+	}
+	options := server.ReplayOptions{
+		Redirector: *redirector || strings.Contains(filepath.Base(flags.Arg(0)), "-redirector-"),
+		Timeout:    *timeout,
+		Ignore:     strings.Split(*ignore, ","),
+	}
+	result, err := server.Replay(records, options)
+	if err != nil {
+		return err
+	}
+	for _, difference := range result.Differences {
+		fmt.Println(difference)
+	}
+	fmt.Printf("Replayed %d requests with %d differences\n", result.Requests, len(result.Differences))
+	if len(result.Differences) > 0 {
+		return errors.New("responses differ from the recording")
+	}
+	return nil
+}
+
+// certs generates new certificates. Existing certificates are only replaced
+// with -force and a running server picks up the new certificates without
+// restarting
+func certs(flags *flag.FlagSet, args []string) error {
+	dataFlag(flags)
+	keySize := flags.Int("key-size", server.CertKeySize, "RSA key size in bits (1024 or 2048)")
+	show := flags.Bool("show", false, "only print the fingerprints of the existing certificates")
+	force := flags.Bool("force", false, "replace the existing CA, certificate and keys")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(server.DataDir, server.CertDir)
+	if !*show {
+		server.CertKeySize = *keySize
+		if err := server.GenerateCertificates(dir, *force); err != nil {
+			if errors.Is(err, server.ErrCertificatesExist) {
+				return fmt.Errorf("%w, use -force to replace them", err)
+			}
+			return fmt.Errorf("generating certificates: %w", err)
+		}
+		log.Println("Generated certificates in", dir)
+	} else if !server.CertificatesExist(dir) {
+		return fmt.Errorf("no certificates in %s", dir)
+	}
+	server.PrintFingerprints(dir)
+	return nil
+}
+
+// openPlayers loads the players from the data directory after checking
+// that the stored data is the current version
+func openPlayers() (*game.Players, error) {
+	s, err := store.Open(server.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := game.CheckSchema(s); err != nil {
+		return nil, err
+	}
+	return game.LoadPlayers(s)
+}
+
+func user(flags *flag.FlagSet, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		flags.Usage()
+		return errors.New("missing user command")
+	}
+	action, args := args[0], args[1:]
+	flags.Init("user "+action, flag.ContinueOnError)
+	dataFlag(flags)
+	switch action {
+	case "create":
+		name := flags.String("name", "", "name of the player")
+		email := flags.String("email", "", "email the player logs in with")
+		password := flags.String("password", "", "password of the player")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("a name is required")
+		}
+		if *password == "" {
+			return errors.New("a password is required")
+		}
+		players, err := openPlayers()
+		if err != nil {
+			return err
+		}
+		player, err := players.Create(*name, *email, *password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created player %s with ID %d\n", player.Name, player.Id)
+	case "list":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		players, err := openPlayers()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tBANNED")
+		for _, player := range players.All() {
+			players.View(player, func(p *game.Player) {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%t\n", p.Id, p.Name, p.Email, p.Banned)
+			})
+		}
+		return w.Flush()
+	case "ban":
+		unban := flags.Bool("unban", false, "lift the ban instead")
+		address := flags.String("server", "", "address of the HTTP server of a running server such as http://127.0.0.1:80 which also disconnects the player (default edit the stopped server's data)")
+		var token string
+		adminFlag(flags, &token)
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return errors.New("expected the name or email of a player")
+		}
+		if *address != "" {
+			form := url.Values{"name": {flags.Arg(0)}, "unban": {strconv.FormatBool(*unban)}}
+			if err := server.AdminRequest(*address, token, "ban", form); err != nil {
+				return fmt.Errorf("changing ban: %w", err)
+			}
+			fmt.Printf("Changed ban of player %s\n", flags.Arg(0))
+			return nil
+		}
+		players, err := openPlayers()
+		if err != nil {
+			return err
+		}
+		player := players.ByName(flags.Arg(0))
+		if player == nil {
+			return fmt.Errorf("no player named %q", flags.Arg(0))
+		}
+		if err := players.Update(player, func(p *game.Player) { p.Banned = !*unban }); err != nil {
+			return err
+		}
+		if *unban {
+			fmt.Printf("Unbanned player %s\n", player.Name)
+		} else {
+			fmt.Printf("Banned player %s\n", player.Name)
+		}
+	default:
+		return fmt.Errorf("unknown user command %q", action)
+	}
+	return nil
+}
+
+// broadcast sends a message to every player through the admin endpoint of
+// a running server
+func broadcast(flags *flag.FlagSet, args []string) error {
+	address := flags.String("server", fmt.Sprintf("http://127.0.0.1:%d", server.HttpPort), "address of the HTTP server")
+	var token string
+	adminFlag(flags, &token)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	message := strings.Join(flags.Args(), " ")
+	if message == "" {
+		return errors.New("expected a message")
+	}
+	if err := server.AdminRequest(*address, token, "broadcast", url.Values{"message": {message}}); err != nil {
+		return fmt.Errorf("broadcasting message: %w", err)
+	}
+	fmt.Println("Sent message to every player")
+	return nil
+}
+
+func migrate(flags *flag.FlagSet, args []string) error {
+	dataFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	s, err := store.Open(server.DataDir)
+	if err != nil {
+		return err
+	}
+	from, err := game.Migrate(s)
+	if err != nil {
+		return err
+	}
+	if from == game.SchemaVersion {
+		fmt.Printf("Data is already at version %d\n", from)
+	} else {
+		fmt.Printf("Migrated data from version %d to %d\n", from, game.SchemaVersion)
+	}
+	return nil
+}
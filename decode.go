@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
+)
+
+// Input formats accepted by the decode command
+const (
+	formatAuto   = "auto"
+	formatHex    = "hex"
+	formatBase64 = "base64"
+	formatBinary = "binary"
+)
+
+// decodeInput turns the raw input into packet bytes using the provided
+// format. The auto format tries hex then base64 and falls back to binary
+func decodeInput(data []byte, format string) ([]byte, error) {
+	switch format {
+	case formatHex:
+		return decodeHex(data)
+	case formatBase64:
+		return decodeBase64(data)
+	case formatBinary:
+		return data, nil
+	case formatAuto:
+		if !isPrintable(data) {
+			return data, nil
+		}
+		if out, err := decodeHex(data); err == nil {
+			return out, nil
+		}
+		if out, err := decodeBase64(data); err == nil {
+			return out, nil
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+}
+
+// decodeHex decodes hex ignoring whitespace along with any 0x prefixes and
+// comma separators so that dumps copied from other tools can be pasted
+func decodeHex(data []byte) ([]byte, error) {
+	text := strings.ReplaceAll(string(data), "0x", "")
+	text = strings.ReplaceAll(text, ",", " ")
+	return hex.DecodeString(strings.Join(strings.Fields(text), ""))
+}
+
+func decodeBase64(data []byte) ([]byte, error) {
+	text := strings.Join(strings.Fields(string(data)), "")
+	out, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return base64.RawStdEncoding.DecodeString(strings.TrimRight(text, "="))
+	}
+	return out, nil
+}
+
+// readPackets splits the data into packets returning any bytes that were
+// left over at the end because they didn't form a complete packet
+func readPackets(data []byte) ([]blaze.Packet, int) {
+	var packets []blaze.Packet
+	for len(data) > 0 {
+		packet, n, err := blaze.DecodePacket(data)
+		if err != nil {
+			return packets, len(data)
+		}
+		packets = append(packets, packet)
+		data = data[n:]
+	}
+	return packets, 0
+}
+
+func messageTypeName(qType uint16) string {
+	switch qType & 0xF000 {
+	case blaze.RequestType:
+		return "Request"
+	case blaze.ResponseType:
+		return "Response"
+	case blaze.NotificationType:
+		return "Notification"
+	case blaze.ErrorType:
+		return "Error"
+	default:
+		return fmt.Sprintf("0x%04X", qType&0xF000)
+	}
+}
+
+// treeOptions print packets as a tree without truncating anything
+var treeOptions = untruncated(blaze.DefaultFormatOptions)
+
+// textOptions print packets in the text format read by blaze.ParseContent
+var textOptions = untruncated(blaze.CompactFormatOptions)
+
+func formatOptions(text bool) blaze.FormatOptions {
+	if text {
+		return textOptions
+	}
+	return treeOptions
+}
+
+func untruncated(options blaze.FormatOptions) blaze.FormatOptions {
+	options.MaxString, options.MaxBlob, options.MaxItems = 0, 0, 0
+	return options
+}
+
+// printPacket writes the packet header followed by its contents. When
+// schemas is set fields are annotated with their meaning and values that
+// don't match the schema of the packet are listed after the contents
+func printPacket(w io.Writer, packet blaze.Packet, options blaze.FormatOptions, schemas bool) {
+	_, _ = fmt.Fprintf(w, "%s %s id=%d error=0x%04X length=%d\n",
+		messageTypeName(packet.QType), packet.ToDescriptor(), packet.Id, packet.Error, len(packet.Content))
+	content := packet.ReadContent()
+	var problems []error
+	if schema, ok := packet.Schema(); ok && schemas {
+		options.Schema = schema
+		problems = schema.Validate(content)
+	}
+	if len(content) > 0 {
+		for _, line := range strings.Split(blaze.FormatContent(content, options), "\n") {
+			_, _ = fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+	for _, problem := range problems {
+		_, _ = fmt.Fprintf(w, "  ! %v\n", problem)
+	}
+}
+
+// jsonPacket is the json form of a decoded packet. The content uses the
+// lossless json form of the values so it can be encoded again
+type jsonPacket struct {
+	Component  uint16          `json:"component"`
+	Command    uint16          `json:"command"`
+	Descriptor string          `json:"descriptor,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	QType      uint16          `json:"qtype"`
+	Id         uint16          `json:"id"`
+	Error      uint16          `json:"error"`
+	Content    json.RawMessage `json:"content"`
+}
+
+func packetJson(packet blaze.Packet) jsonPacket {
+	content, err := blaze.ContentToJSON(packet.ReadContent())
+	if err != nil {
+		content, _ = json.Marshal(err.Error())
+	}
+	return jsonPacket{
+		Component:  packet.Component,
+		Command:    packet.Command,
+		Descriptor: packet.ToDescriptor(),
+		Type:       messageTypeName(packet.QType),
+		QType:      packet.QType,
+		Id:         packet.Id,
+		Error:      packet.Error,
+		Content:    content,
+	}
+}
+
+// encodePacketJson encodes a packet from its json form. When lint is set
+// labels that don't round trip through a tag are an error
+func encodePacketJson(value jsonPacket, lint bool) ([]byte, error) {
+	var content []blaze.Tdf
+	if len(value.Content) > 0 {
+		var err error
+		if content, err = blaze.ContentFromJSON(value.Content); err != nil {
+			return nil, err
+		}
+	}
+	if lint {
+		if errs := blaze.LintValues(content); len(errs) > 0 {
+			return nil, fmt.Errorf("%w (%d bad labels)", errs[0], len(errs))
+		}
+	}
+	buf := blaze.PacketBuff{}
+	return buf.EncodePacket(value.Component, value.Command, value.Error, value.QType, value.Id, content), nil
+}
+
+func writePacketsJson(w io.Writer, packets []blaze.Packet) error {
+	out := make([]jsonPacket, len(packets))
+	for i, packet := range packets {
+		out[i] = packetJson(packet)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// isPrintable checks whether the input is text so that binary input is
+// never mistaken for hex or base64
+func isPrintable(data []byte) bool {
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// printTranscript writes each packet of the transcript prefixed with the
+// time, connection and direction it was sent in
+func printTranscript(w io.Writer, transcript *capture.Transcript, options blaze.FormatOptions, schemas bool) {
+	for _, entry := range transcript.Entries {
+		_, _ = fmt.Fprintf(w, "%s #%d %s ", entry.Time.Format("15:04:05.000000"), entry.Conn.Id, entry.Direction)
+		printPacket(w, entry.Packet, options, schemas)
+	}
+}
+
+// jsonEntry is the json form of a transcript entry
+type jsonEntry struct {
+	Time      time.Time  `json:"time"`
+	Conn      int        `json:"conn"`
+	Client    string     `json:"client"`
+	Server    string     `json:"server"`
+	Direction string     `json:"direction"`
+	Packet    jsonPacket `json:"packet"`
+}
+
+func writeTranscriptJson(w io.Writer, transcript *capture.Transcript) error {
+	out := make([]jsonEntry, len(transcript.Entries))
+	for i, entry := range transcript.Entries {
+		out[i] = jsonEntry{
+			Time:      entry.Time,
+			Conn:      entry.Conn.Id,
+			Client:    entry.Conn.Client.String(),
+			Server:    entry.Conn.Server.String(),
+			Direction: entry.Direction.String(),
+			Packet:    packetJson(entry.Packet),
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
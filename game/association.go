@@ -0,0 +1,258 @@
+package game
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+type ListType int64
+
+const (
+	FriendList       ListType = 1
+	RecentPlayerList ListType = 2
+	BlockList        ListType = 3
+)
+
+// ListConfig describes one of the association lists every player has
+type ListConfig struct {
+	Type ListType
+	Name string
+	// MaxSize is the maximum number of members the list can contain
+	MaxSize int
+	// Rolling lists drop their oldest member instead of rejecting new
+	// members once they are full
+	Rolling bool
+	// Flags are sent to the client as part of the list info
+	Flags int64
+	// Public lists can be read by players other than the owner
+	Public bool
+}
+
+var ListConfigs = []ListConfig{
+	{Type: FriendList, Name: "friendList", MaxSize: 200, Flags: 4, Public: true},
+	{Type: RecentPlayerList, Name: "recentPlayerList", MaxSize: 50, Rolling: true, Flags: 0},
+	{Type: BlockList, Name: "blockList", MaxSize: 100, Flags: 0},
+}
+
+var (
+	ErrUnknownList = errors.New("unknown association list")
+	ErrListFull    = errors.New("association list is full")
+)
+
+// GetListConfig finds the list config with the provided type or name. The
+// type is used when it is non-zero and the name otherwise
+func GetListConfig(t ListType, name string) (ListConfig, error) {
+	for _, config := range ListConfigs {
+		if (t != 0 && config.Type == t) || (t == 0 && config.Name == name) {
+			return config, nil
+		}
+	}
+	return ListConfig{}, ErrUnknownList
+}
+
+// ListMember is a single player within an association list
+type ListMember struct {
+	Id uint32
+	// Added is the unix time the member was added to the list
+	Added int64
+}
+
+// AssociationLists stores the association lists for every player which
+// are persisted to the "associations" file of the store
+type AssociationLists struct {
+	store *store.Store
+	lock  sync.RWMutex
+
+	// Lists maps the owning player ID to each of its lists
+	Lists map[uint32]map[ListType][]ListMember
+}
+
+// LoadAssociationLists loads the association lists from the provided store
+func LoadAssociationLists(s *store.Store) (*AssociationLists, error) {
+	a := &AssociationLists{store: s, Lists: map[uint32]map[ListType][]ListMember{}}
+	if err := s.Load("associations", a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *AssociationLists) save() error {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.store.Save("associations", a)
+}
+
+// Members returns a copy of the members of the owners list
+func (a *AssociationLists) Members(owner uint32, t ListType) []ListMember {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	members := a.Lists[owner][t]
+	out := make([]ListMember, len(members))
+	copy(out, members)
+	return out
+}
+
+// Page returns up to max members of the owners list starting at the provided
+// offset along with the total number of members. A negative max returns
+// every remaining member
+func (a *AssociationLists) Page(owner uint32, t ListType, offset int64, max int64) ([]ListMember, int) {
+	members := a.Members(owner, t)
+	total := len(members)
+	if offset < 0 || offset > int64(total) {
+		offset = int64(total)
+	}
+	members = members[offset:]
+	if max >= 0 && max < int64(len(members)) {
+		members = members[:max]
+	}
+	return members, total
+}
+
+// Contains checks whether the player with the provided ID is a member of
+// the owners list
+func (a *AssociationLists) Contains(owner uint32, t ListType, id uint32) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return indexOfMember(a.Lists[owner][t], id) != -1
+}
+
+// addMembers appends the provided players to a copy of the members of a
+// list returning the new members, the members that were added and the
+// members a rolling list dropped to make room. Players already in the list
+// are ignored. Non-rolling lists stop at the first player that doesn't fit
+func addMembers(owner uint32, config ListConfig, members []ListMember, ids []uint32, now int64) ([]ListMember, []ListMember, []ListMember, error) {
+	members = append([]ListMember(nil), members...)
+	var added, evicted []ListMember
+	for _, id := range ids {
+		if id == owner || indexOfMember(members, id) != -1 {
+			continue
+		}
+		if len(members) >= config.MaxSize {
+			if !config.Rolling {
+				return members, added, evicted, ErrListFull
+			}
+			// Members added by this call that are dropped again were never
+			// seen by the client
+			if index := indexOfMember(added, members[0].Id); index != -1 {
+				added = append(added[:index], added[index+1:]...)
+			} else {
+				evicted = append(evicted, members[0])
+			}
+			members = members[1:]
+		}
+		member := ListMember{Id: id, Added: now}
+		members = append(members, member)
+		added = append(added, member)
+	}
+	return members, added, evicted, nil
+}
+
+// Add adds the provided players to the owners list returning the members
+// that were added and the members dropped from a full rolling list. Players
+// already in the list are ignored. When a list that doesn't roll fills up
+// the players added before it did are kept and ErrListFull is returned
+func (a *AssociationLists) Add(owner uint32, config ListConfig, ids []uint32) ([]ListMember, []ListMember, error) {
+	a.lock.Lock()
+	lists := a.ownerLists(owner)
+	members, added, evicted, err := addMembers(owner, config, lists[config.Type], ids, time.Now().Unix())
+	lists[config.Type] = members
+	a.lock.Unlock()
+	if saveErr := a.save(); err == nil {
+		err = saveErr
+	}
+	return added, evicted, err
+}
+
+// Remove removes the provided players from the owners list returning the
+// members that were removed
+func (a *AssociationLists) Remove(owner uint32, t ListType, ids []uint32) ([]ListMember, error) {
+	a.lock.Lock()
+	lists := a.ownerLists(owner)
+	members := lists[t]
+	var removed []ListMember
+	for _, id := range ids {
+		index := indexOfMember(members, id)
+		if index == -1 {
+			continue
+		}
+		removed = append(removed, members[index])
+		members = append(members[:index], members[index+1:]...)
+	}
+	lists[t] = members
+	a.lock.Unlock()
+	return removed, a.save()
+}
+
+// Set replaces the members of the owners list with the provided players
+// returning the members that were added and removed. The list is left
+// unchanged when the players don't fit
+func (a *AssociationLists) Set(owner uint32, config ListConfig, ids []uint32) ([]ListMember, []ListMember, error) {
+	keep := map[uint32]bool{}
+	for _, id := range ids {
+		keep[id] = true
+	}
+	a.lock.Lock()
+	lists := a.ownerLists(owner)
+	var kept, removed []ListMember
+	for _, member := range lists[config.Type] {
+		if keep[member.Id] {
+			kept = append(kept, member)
+		} else {
+			removed = append(removed, member)
+		}
+	}
+	members, added, evicted, err := addMembers(owner, config, kept, ids, time.Now().Unix())
+	if err != nil {
+		a.lock.Unlock()
+		return nil, nil, err
+	}
+	lists[config.Type] = members
+	a.lock.Unlock()
+	return added, append(removed, evicted...), a.save()
+}
+
+// Clear removes every member from the owners list returning the members
+// that were removed
+func (a *AssociationLists) Clear(owner uint32, t ListType) ([]ListMember, error) {
+	a.lock.Lock()
+	lists := a.ownerLists(owner)
+	removed := lists[t]
+	delete(lists, t)
+	a.lock.Unlock()
+	return removed, a.save()
+}
+
+// ListsContaining returns the IDs of the players that have the player
+// with the provided ID within their list
+func (a *AssociationLists) ListsContaining(t ListType, id uint32) []uint32 {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	var out []uint32
+	for owner, lists := range a.Lists {
+		if indexOfMember(lists[t], id) != -1 {
+			out = append(out, owner)
+		}
+	}
+	return out
+}
+
+func (a *AssociationLists) ownerLists(owner uint32) map[ListType][]ListMember {
+	lists, exists := a.Lists[owner]
+	if !exists {
+		lists = map[ListType][]ListMember{}
+		a.Lists[owner] = lists
+	}
+	return lists
+}
+
+func indexOfMember(members []ListMember, id uint32) int {
+	for i, member := range members {
+		if member.Id == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+func loadTestAssociations(t *testing.T) *AssociationLists {
+	s, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lists, err := LoadAssociationLists(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lists
+}
+
+func memberIds(members []ListMember) []uint32 {
+	out := make([]uint32, len(members))
+	for i, member := range members {
+		out[i] = member.Id
+	}
+	return out
+}
+
+func TestAddRollingListReturnsEvicted(t *testing.T) {
+	lists := loadTestAssociations(t)
+	config := ListConfig{Type: RecentPlayerList, MaxSize: 2, Rolling: true}
+	if _, _, err := lists.Add(1, config, []uint32{2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	added, evicted, err := lists.Add(1, config, []uint32{4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4 is dropped again by 6 so it's neither added nor evicted
+	if ids := memberIds(added); len(ids) != 2 || ids[0] != 5 || ids[1] != 6 {
+		t.Errorf("added %v", ids)
+	}
+	if ids := memberIds(evicted); len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("evicted %v", ids)
+	}
+	if ids := memberIds(lists.Members(1, config.Type)); len(ids) != 2 || ids[0] != 5 || ids[1] != 6 {
+		t.Errorf("members %v", ids)
+	}
+}
+
+func TestSetReplacesAtomically(t *testing.T) {
+	lists := loadTestAssociations(t)
+	config := ListConfig{Type: FriendList, MaxSize: 2}
+	if _, _, err := lists.Add(1, config, []uint32{2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	added, removed, err := lists.Set(1, config, []uint32{3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := memberIds(added); len(ids) != 1 || ids[0] != 4 {
+		t.Errorf("added %v", ids)
+	}
+	if ids := memberIds(removed); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("removed %v", ids)
+	}
+	// Players that don't fit leave the list as it was
+	if _, _, err := lists.Set(1, config, []uint32{5, 6, 7}); err != ErrListFull {
+		t.Errorf("expected a full list got %v", err)
+	}
+	if ids := memberIds(lists.Members(1, config.Type)); len(ids) != 2 || ids[0] != 3 || ids[1] != 4 {
+		t.Errorf("members after a failed set %v", ids)
+	}
+}
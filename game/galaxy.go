@@ -0,0 +1,129 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+// Galaxy at War readiness ratings are kept between the min and max values
+const (
+	MinRating int64 = 5000
+	MaxRating int64 = 10000
+)
+
+// RatingDecayPerDay is the amount each rating drops by every day since it was
+// last increased. Zero disables decay
+var RatingDecayPerDay int64 = 0
+
+// GalaxyRatings are the Galaxy at War readiness ratings for one player
+type GalaxyRatings struct {
+	A int64
+	B int64
+	C int64
+	D int64
+	E int64
+	// Updated is the unix time the ratings were last changed or decayed
+	Updated int64
+}
+
+// Level is the overall readiness percentage shown by the client
+func (r GalaxyRatings) Level() int64 {
+	return (r.A + r.B + r.C + r.D + r.E) / 5 / 100
+}
+
+func clampRating(value int64) int64 {
+	if value < MinRating {
+		return MinRating
+	}
+	if value > MaxRating {
+		return MaxRating
+	}
+	return value
+}
+
+// decay lowers the ratings by the decay for every whole day since they
+// were last updated returning whether anything changed. The days are used
+// up even while decay is disabled so turning it on later doesn't apply them
+func (r *GalaxyRatings) decay(now int64) bool {
+	days := (now - r.Updated) / 86400
+	if days <= 0 {
+		return false
+	}
+	if RatingDecayPerDay > 0 {
+		amount := days * RatingDecayPerDay
+		r.A = clampRating(r.A - amount)
+		r.B = clampRating(r.B - amount)
+		r.C = clampRating(r.C - amount)
+		r.D = clampRating(r.D - amount)
+		r.E = clampRating(r.E - amount)
+	}
+	r.Updated += days * 86400
+	return true
+}
+
+// GalaxyAtWar stores the ratings for every player which are persisted to the
+// "galaxy" file of the store
+type GalaxyAtWar struct {
+	store *store.Store
+	lock  sync.RWMutex
+
+	Ratings map[uint32]*GalaxyRatings
+}
+
+// LoadGalaxyAtWar loads the Galaxy at War ratings from the provided store
+func LoadGalaxyAtWar(s *store.Store) (*GalaxyAtWar, error) {
+	g := &GalaxyAtWar{store: s, Ratings: map[uint32]*GalaxyRatings{}}
+	if err := s.Load("galaxy", g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *GalaxyAtWar) save() error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.store.Save("galaxy", g)
+}
+
+// ratings finds the ratings for the player after applying any decay and
+// reports whether they were created or decayed
+func (g *GalaxyAtWar) ratings(player uint32, now int64) (*GalaxyRatings, bool) {
+	ratings, exists := g.Ratings[player]
+	if !exists {
+		ratings = &GalaxyRatings{A: MinRating, B: MinRating, C: MinRating, D: MinRating, E: MinRating, Updated: now}
+		g.Ratings[player] = ratings
+	}
+	decayed := ratings.decay(now)
+	return ratings, decayed || !exists
+}
+
+// Get returns the ratings for the player after applying any decay. The
+// ratings are only saved when they were created or decayed
+func (g *GalaxyAtWar) Get(player uint32) (GalaxyRatings, error) {
+	g.lock.Lock()
+	ratings, changed := g.ratings(player, time.Now().Unix())
+	out := *ratings
+	g.lock.Unlock()
+	if !changed {
+		return out, nil
+	}
+	return out, g.save()
+}
+
+// Increase adds the provided amounts to the ratings for the player
+func (g *GalaxyAtWar) Increase(player uint32, increase GalaxyRatings) (GalaxyRatings, error) {
+	g.lock.Lock()
+	now := time.Now().Unix()
+	ratings, _ := g.ratings(player, now)
+	ratings.A = clampRating(ratings.A + increase.A)
+	ratings.B = clampRating(ratings.B + increase.B)
+	ratings.C = clampRating(ratings.C + increase.C)
+	ratings.D = clampRating(ratings.D + increase.D)
+	ratings.E = clampRating(ratings.E + increase.E)
+	ratings.Updated = now
+	out := *ratings
+	g.lock.Unlock()
+	return out, g.save()
+}
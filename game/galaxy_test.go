@@ -0,0 +1,67 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+func TestGalaxyGetOnlySavesChanges(t *testing.T) {
+	s, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	galaxy, err := LoadGalaxyAtWar(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := galaxy.Get(1); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Exists("galaxy") {
+		t.Fatal("new ratings weren't saved")
+	}
+	if err := os.Remove(filepath.Join(s.Dir, "galaxy.json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := galaxy.Get(1); err != nil {
+		t.Fatal(err)
+	}
+	if s.Exists("galaxy") {
+		t.Error("unchanged ratings were saved")
+	}
+}
+
+func TestGalaxyDecay(t *testing.T) {
+	defer func(decay int64) { RatingDecayPerDay = decay }(RatingDecayPerDay)
+	RatingDecayPerDay = 100
+	ratings := GalaxyRatings{A: 6000, B: 5050, C: MaxRating, D: MinRating, E: 7000, Updated: 0}
+	if !ratings.decay(2*86400 + 10) {
+		t.Fatal("ratings didn't decay")
+	}
+	expected := GalaxyRatings{A: 5800, B: MinRating, C: MaxRating - 200, D: MinRating, E: 6800, Updated: 2 * 86400}
+	if ratings != expected {
+		t.Errorf("decayed to %+v not %+v", ratings, expected)
+	}
+	if ratings.decay(2*86400 + 20) {
+		t.Error("ratings decayed twice in one day")
+	}
+}
+
+func TestGalaxyDecayDisabledUsesUpDays(t *testing.T) {
+	defer func(decay int64) { RatingDecayPerDay = decay }(RatingDecayPerDay)
+	RatingDecayPerDay = 0
+	ratings := GalaxyRatings{A: 6000, B: 6000, C: 6000, D: 6000, E: 6000, Updated: 0}
+	ratings.decay(5*86400 + 10)
+	if ratings.A != 6000 || ratings.Updated != 5*86400 {
+		t.Errorf("disabled decay gave %+v", ratings)
+	}
+	// Days that passed while decay was disabled aren't applied once enabled
+	RatingDecayPerDay = 100
+	ratings.decay(6*86400 + 10)
+	if ratings.A != 5900 || ratings.Updated != 6*86400 {
+		t.Errorf("enabled decay gave %+v", ratings)
+	}
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+// Message attribute keys used by the client
+const (
+	MessageSubjectAttr int64 = 0x1
+	MessageBodyAttr    int64 = 0x2
+)
+
+// Message is a message sent to a player either by another player or by the
+// server when Sender is zero
+type Message struct {
+	Id        uint32
+	Sender    uint32
+	Recipient uint32
+	Type      int64
+	Tag       int64
+	Status    int64
+	Flags     int64
+	// Attributes hold the message content keyed by the attribute keys
+	Attributes map[int64]string
+	Time       int64
+	Read       bool
+}
+
+// Messages stores the messages for every player which are persisted to the
+// "messages" file of the store
+type Messages struct {
+	store *store.Store
+	lock  sync.RWMutex
+
+	NextId   uint32
+	Messages map[uint32]*Message
+}
+
+// LoadMessages loads the messages from the provided store
+func LoadMessages(s *store.Store) (*Messages, error) {
+	m := &Messages{store: s, NextId: 1, Messages: map[uint32]*Message{}}
+	if err := s.Load("messages", m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Messages) save() error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.store.Save("messages", m)
+}
+
+// Add stores a copy of the provided message for each of the recipients
+// assigning each an ID and returning the stored messages
+func (m *Messages) Add(message Message, recipients []uint32) ([]Message, error) {
+	m.lock.Lock()
+	out := make([]Message, 0, len(recipients))
+	message.Time = time.Now().Unix()
+	for _, recipient := range recipients {
+		stored := message
+		stored.Id = m.NextId
+		stored.Recipient = recipient
+		stored.Read = false
+		m.NextId++
+		m.Messages[stored.Id] = &stored
+		out = append(out, stored)
+	}
+	m.lock.Unlock()
+	return out, m.save()
+}
+
+// MessageFilter narrows down the messages of a recipient. Zero values
+// match every message
+type MessageFilter struct {
+	Id     uint32
+	Sender uint32
+	Type   int64
+}
+
+func (f MessageFilter) matches(message *Message) bool {
+	return (f.Id == 0 || message.Id == f.Id) &&
+		(f.Sender == 0 || message.Sender == f.Sender) &&
+		(f.Type == 0 || message.Type == f.Type)
+}
+
+// Find returns the messages of the recipient that match the filter ordered
+// by the time they were sent
+func (m *Messages) Find(recipient uint32, filter MessageFilter) []Message {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var out []Message
+	for _, message := range m.Messages {
+		if message.Recipient == recipient && filter.matches(message) {
+			out = append(out, *message)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	return out
+}
+
+// Get returns the messages of the recipient with the provided IDs
+func (m *Messages) Get(recipient uint32, ids []uint32) []Message {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var out []Message
+	for _, id := range ids {
+		message, exists := m.Messages[id]
+		if exists && message.Recipient == recipient {
+			out = append(out, *message)
+		}
+	}
+	return out
+}
+
+// MarkRead marks the messages of the recipient matching the filter as read
+// returning the number of messages that changed
+func (m *Messages) MarkRead(recipient uint32, filter MessageFilter) (int, error) {
+	m.lock.Lock()
+	count := 0
+	for _, message := range m.Messages {
+		if message.Recipient == recipient && !message.Read && filter.matches(message) {
+			message.Read = true
+			count++
+		}
+	}
+	m.lock.Unlock()
+	if count == 0 {
+		return 0, nil
+	}
+	return count, m.save()
+}
+
+// Purge deletes the messages of the recipient matching the filter returning
+// the number of messages deleted
+func (m *Messages) Purge(recipient uint32, filter MessageFilter) (int, error) {
+	m.lock.Lock()
+	count := 0
+	for id, message := range m.Messages {
+		if message.Recipient == recipient && filter.matches(message) {
+			delete(m.Messages, id)
+			count++
+		}
+	}
+	m.lock.Unlock()
+	if count == 0 {
+		return 0, nil
+	}
+	return count, m.save()
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+// SchemaVersion is the version of the stored data used by this server
+const SchemaVersion = 2
+
+var ErrMigrationRequired = errors.New("stored data is from an older version, run the migrate command")
+
+type schema struct {
+	Version int
+}
+
+// migrations upgrade the stored data by one version each. The migration at
+// index i upgrades from version i to i + 1
+var migrations = []func(s *store.Store) error{
+	// Players created before settings were stored have no settings map
+	func(s *store.Store) error {
+		players, err := LoadPlayers(s)
+		if err != nil {
+			return err
+		}
+		for _, player := range players.Players {
+			if player.Settings == nil {
+				player.Settings = map[string]string{}
+			}
+		}
+		return players.Save()
+	},
+	// Players from before passwords were hashed have plain text passwords
+	func(s *store.Store) error {
+		players, err := LoadPlayers(s)
+		if err != nil {
+			return err
+		}
+		for _, player := range players.Players {
+			if player.Password != "" && !IsPasswordHash(player.Password) {
+				player.Password = HashPassword(player.Password)
+			}
+		}
+		return players.Save()
+	},
+}
+
+// StoredVersion returns the schema version of the data in the store. Stores
+// without any data are treated as being the current version
+func StoredVersion(s *store.Store) (int, error) {
+	if !s.Exists("schema") && !s.Exists("players") {
+		return SchemaVersion, nil
+	}
+	var value schema
+	if err := s.Load("schema", &value); err != nil {
+		return 0, err
+	}
+	return value.Version, nil
+}
+
+// CheckSchema ensures the data in the store can be used by this server
+// recording the schema version for new stores
+func CheckSchema(s *store.Store) error {
+	version, err := StoredVersion(s)
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion {
+		return fmt.Errorf("stored data version %d is newer than the supported version %d", version, SchemaVersion)
+	}
+	if version < SchemaVersion {
+		return ErrMigrationRequired
+	}
+	return s.Save("schema", schema{Version: SchemaVersion})
+}
+
+// Migrate upgrades the data in the store to the current schema version
+// returning the version it was upgraded from
+func Migrate(s *store.Store) (int, error) {
+	version, err := StoredVersion(s)
+	if err != nil {
+		return 0, err
+	}
+	if version > SchemaVersion {
+		return version, fmt.Errorf("stored data version %d is newer than the supported version %d", version, SchemaVersion)
+	}
+	for v := version; v < SchemaVersion; v++ {
+		if err := migrations[v](s); err != nil {
+			return version, fmt.Errorf("migrating from version %d: %w", v, err)
+		}
+		if err := s.Save("schema", schema{Version: v + 1}); err != nil {
+			return version, err
+		}
+	}
+	return version, nil
+}
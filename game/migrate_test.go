@@ -0,0 +1,66 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+func TestMigrateHashesPasswords(t *testing.T) {
+	s, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy := legacyHash("0011", "hunter2")
+	err = s.Save("players", map[string]any{
+		"NextId": 3,
+		"Players": map[uint32]*Player{
+			1: {Id: 1, Name: "Shepard", Password: "plain text"},
+			2: {Id: 2, Name: "Garrus", Password: legacy},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSchema(s); err != ErrMigrationRequired {
+		t.Fatalf("unmigrated store gave %v", err)
+	}
+	from, err := Migrate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != 0 {
+		t.Errorf("migrated from version %d", from)
+	}
+	players, err := LoadPlayers(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shepard := players.Get(1)
+	if !IsPasswordHash(shepard.Password) || !shepard.CheckPassword("plain text") {
+		t.Errorf("plain text password became %q", shepard.Password)
+	}
+	if shepard.Settings == nil {
+		t.Error("settings weren't added")
+	}
+	if garrus := players.Get(2); garrus.Password != legacy {
+		t.Errorf("hashed password became %q", garrus.Password)
+	}
+	if err := CheckSchema(s); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateRejectsEmptyPassword(t *testing.T) {
+	s, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, err := LoadPlayers(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := players.Create("Shepard", "", ""); err != ErrEmptyPassword {
+		t.Errorf("empty password gave %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+var (
+	ErrUnknownPack       = errors.New("unknown pack")
+	ErrNotEnoughCredits  = errors.New("not enough credits")
+	ErrUnknownPackItem   = errors.New("pack references an unknown item")
+	ErrNoItemsAvailable  = errors.New("no items are available to roll")
+	ErrInvalidPackConfig = errors.New("invalid pack config")
+)
+
+// PackItem is an item that can be given by a pack. Items either increase
+// the count of an inventory slot in the Base setting or set a player
+// setting such as a character unlock
+type PackItem struct {
+	Name   string
+	Rarity string
+	// Index is the inventory slot of the item or -1 for setting items
+	Index int
+	// Max is the maximum count of the item. Maxed out items are not rolled
+	Max int
+	// Setting and Value are the player setting changed by setting items
+	Setting string
+	Value   string
+}
+
+// Pack is a purchasable pack of random items
+type Pack struct {
+	Name  string
+	Price int64
+	// Rolls is the number of random items given in addition to the guaranteed items
+	Rolls int
+	// Rarities are the weights used to pick the rarity of each roll
+	Rarities map[string]int
+	// Guaranteed are the names of the items the pack always gives. Guaranteed
+	// items the player has the max of are replaced by an extra roll
+	Guaranteed []string
+}
+
+// PackConfig is the pack and item definitions loaded from the "packs" file
+// of the store
+type PackConfig struct {
+	Packs []Pack
+	Items []PackItem
+}
+
+// DefaultPackConfig is used when the store doesn't have a pack config
+var DefaultPackConfig = PackConfig{
+	Packs: []Pack{
+		{Name: "RecruitPack", Price: 5000, Rolls: 5, Rarities: map[string]int{"common": 90, "uncommon": 10}},
+		{Name: "VeteranPack", Price: 33000, Rolls: 5, Rarities: map[string]int{"common": 40, "uncommon": 50, "rare": 10}},
+		{Name: "SpectrePack", Price: 99000, Rolls: 5, Rarities: map[string]int{"uncommon": 40, "rare": 50, "ultrarare": 10}, Guaranteed: []string{"MedigelCapacity"}},
+	},
+	Items: []PackItem{
+		{Name: "Medigel", Rarity: "common", Index: 0, Max: 255},
+		{Name: "OpsSurvivalPack", Rarity: "common", Index: 1, Max: 255},
+		{Name: "ThermalClipPack", Rarity: "common", Index: 2, Max: 255},
+		{Name: "CobraMissileLauncher", Rarity: "uncommon", Index: 3, Max: 255},
+		{Name: "MedigelCapacity", Rarity: "rare", Index: 4, Max: 5},
+		{Name: "AssaultRifleAmp", Rarity: "uncommon", Index: 5, Max: 255},
+		{Name: "Revenant", Rarity: "rare", Index: 6, Max: 10},
+		{Name: "BlackWidow", Rarity: "ultrarare", Index: 7, Max: 10},
+		{Name: "KrogansBattlemaster", Rarity: "ultrarare", Index: -1, Setting: "char60", Value: "20;4;KroganBattlemaster;;0;0;0;0;True"},
+	},
+}
+
+// PackItemResult is an item given by a purchase along with the count owned
+// after the purchase
+type PackItemResult struct {
+	Name  string
+	Count int
+	// Replaces is the name of the guaranteed item this item was rolled in
+	// place of because the player already had the max of it
+	Replaces string
+}
+
+// PurchaseResult describes the outcome of a pack purchase
+type PurchaseResult struct {
+	Pack    string
+	Credits int64
+	Items   []PackItemResult
+}
+
+// PackStore rolls and applies pack purchases
+type PackStore struct {
+	Config PackConfig
+
+	lock   sync.Mutex
+	random *rand.Rand
+}
+
+// NewPackStore creates a pack store using the provided seed for rolling
+// items. A zero seed uses the current time
+func NewPackStore(config PackConfig, seed int64) (*PackStore, error) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	for _, pack := range config.Packs {
+		for _, name := range pack.Guaranteed {
+			if config.item(name) == nil {
+				return nil, ErrUnknownPackItem
+			}
+		}
+		if pack.Price < 0 || pack.Rolls < 0 {
+			return nil, ErrInvalidPackConfig
+		}
+	}
+	return &PackStore{Config: config, random: rand.New(rand.NewSource(seed))}, nil
+}
+
+// LoadPackStore loads the pack config from the provided store falling back
+// to the default config
+func LoadPackStore(s *store.Store, seed int64) (*PackStore, error) {
+	config := DefaultPackConfig
+	if err := s.Load("packs", &config); err != nil {
+		return nil, err
+	}
+	return NewPackStore(config, seed)
+}
+
+func (c PackConfig) item(name string) *PackItem {
+	for i := range c.Items {
+		if c.Items[i].Name == name {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
+
+// Pack finds the pack with the provided name
+func (s *PackStore) Pack(name string) *Pack {
+	for i := range s.Config.Packs {
+		if s.Config.Packs[i].Name == name {
+			return &s.Config.Packs[i]
+		}
+	}
+	return nil
+}
+
+// available checks whether the player can still receive the item
+func available(item *PackItem, base *BaseSettings, settings map[string]string) bool {
+	if item.Index < 0 {
+		return settings[item.Setting] != item.Value
+	}
+	return base.Count(item.Index) < item.Max
+}
+
+// give applies the item to the player settings
+func give(item *PackItem, base *BaseSettings, settings map[string]string) {
+	if item.Index < 0 {
+		settings[item.Setting] = item.Value
+		return
+	}
+	count := base.Count(item.Index) + 1
+	if count > item.Max {
+		count = item.Max
+	}
+	base.SetCount(item.Index, count)
+}
+
+// roll picks a random available item for the pack
+func (s *PackStore) roll(pack *Pack, base *BaseSettings, settings map[string]string) *PackItem {
+	rarities := make([]string, 0, len(pack.Rarities))
+	for rarity := range pack.Rarities {
+		rarities = append(rarities, rarity)
+	}
+	// Map iteration order is random so the rarities are sorted to keep
+	// the results for a seed stable
+	sort.Strings(rarities)
+
+	for len(rarities) > 0 {
+		total := 0
+		for _, rarity := range rarities {
+			total += pack.Rarities[rarity]
+		}
+		if total <= 0 {
+			return nil
+		}
+		pick := s.random.Intn(total)
+		index := 0
+		for i, rarity := range rarities {
+			pick -= pack.Rarities[rarity]
+			if pick < 0 {
+				index = i
+				break
+			}
+		}
+		var items []*PackItem
+		for i := range s.Config.Items {
+			item := &s.Config.Items[i]
+			if item.Rarity == rarities[index] && available(item, base, settings) {
+				items = append(items, item)
+			}
+		}
+		if len(items) > 0 {
+			return items[s.random.Intn(len(items))]
+		}
+		// Nothing is left of this rarity so try the remaining rarities
+		rarities = append(rarities[:index], rarities[index+1:]...)
+	}
+	return nil
+}
+
+// Purchase buys the pack with the provided name for the player deducting the
+// price from their credits and adding the rolled items. All the changes are
+// applied to the player at once and saved
+func (s *PackStore) Purchase(players *Players, player *Player, name string) (PurchaseResult, error) {
+	pack := s.Pack(name)
+	if pack == nil {
+		return PurchaseResult{}, ErrUnknownPack
+	}
+
+	var result PurchaseResult
+	var err error
+	saveErr := players.Update(player, func(player *Player) {
+		var base BaseSettings
+		base, err = player.BaseSettings()
+		if err != nil {
+			return
+		}
+		if base.Credits < pack.Price {
+			err = ErrNotEnoughCredits
+			return
+		}
+
+		// Changes are made to copies so nothing is applied on failure
+		settings := map[string]string{}
+		for key, value := range player.Settings {
+			settings[key] = value
+		}
+		base.Inventory = append([]byte(nil), base.Inventory...)
+		base.Credits -= pack.Price
+		base.CreditsSpent += pack.Price
+		result = PurchaseResult{Pack: pack.Name}
+
+		items := make([]*PackItem, 0, len(pack.Guaranteed)+pack.Rolls)
+		replaces := make([]string, 0, cap(items))
+		// Guaranteed items the player already has the max of are converted
+		// into extra rolls
+		var converted []string
+		for _, name := range pack.Guaranteed {
+			item := s.Config.item(name)
+			if !available(item, &base, settings) {
+				converted = append(converted, name)
+				continue
+			}
+			give(item, &base, settings)
+			items = append(items, item)
+			replaces = append(replaces, "")
+		}
+		s.lock.Lock()
+		for i := 0; i < pack.Rolls+len(converted); i++ {
+			item := s.roll(pack, &base, settings)
+			if item == nil {
+				break
+			}
+			give(item, &base, settings)
+			items = append(items, item)
+			if i >= pack.Rolls {
+				replaces = append(replaces, converted[i-pack.Rolls])
+			} else {
+				replaces = append(replaces, "")
+			}
+		}
+		s.lock.Unlock()
+
+		if len(items) == 0 {
+			err = ErrNoItemsAvailable
+			return
+		}
+		for i, item := range items {
+			count := base.Count(item.Index)
+			if item.Index < 0 {
+				count = 1
+			}
+			result.Items = append(result.Items, PackItemResult{Name: item.Name, Count: count, Replaces: replaces[i]})
+		}
+		result.Credits = base.Credits
+		settings[BaseSettingKey] = base.String()
+		player.Settings = settings
+	})
+	if err != nil {
+		return PurchaseResult{}, err
+	}
+	return result, saveErr
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+func TestPurchaseConvertsMaxedGuaranteedItems(t *testing.T) {
+	s, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, err := LoadPlayers(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, err := players.Create("Shepard", "", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := ParseBaseSettings(DefaultBaseSetting)
+	base.Credits = 99000
+	base.SetCount(4, 5)
+	player.Settings[BaseSettingKey] = base.String()
+
+	packs, err := NewPackStore(DefaultPackConfig, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := packs.Purchase(players, player, "SpectrePack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != 6 {
+		t.Fatalf("received %d items", len(result.Items))
+	}
+	replaced := 0
+	for _, item := range result.Items {
+		if item.Replaces == "MedigelCapacity" {
+			replaced++
+		} else if item.Name == "MedigelCapacity" {
+			t.Errorf("maxed guaranteed item was reported as received %+v", item)
+		}
+	}
+	if replaced != 1 {
+		t.Errorf("%d items replaced the maxed guaranteed item", replaced)
+	}
+	base, _ = player.BaseSettings()
+	if base.Count(4) != 5 || base.Credits != 0 {
+		t.Errorf("capacity %d credits %d after purchase", base.Count(4), base.Credits)
+	}
+}
@@ -1 +1,139 @@
 package game
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPlayerBanned  = errors.New("player is banned")
+	ErrEmptyPassword = errors.New("password must not be empty")
+)
+
+// PasswordIterations is the number of PBKDF2 iterations used when hashing
+// new passwords. Hashes store their own iteration count so changing it
+// only affects new hashes
+var PasswordIterations = 600000
+
+// passwordScheme prefixes password hashes created by HashPassword
+const passwordScheme = "pbkdf2-sha256"
+
+// Player is a registered player account
+type Player struct {
+	Id       uint32
+	Name     string
+	Email    string
+	Password string
+	Banned   bool
+	// Settings are the key value settings stored by the client using
+	// userSettingsSave
+	Settings map[string]string
+}
+
+// HashPassword creates a salted PBKDF2-HMAC-SHA256 hash of the password in
+// the form pbkdf2-sha256$iterations$salt$hash
+func HashPassword(password string) string {
+	salt := make([]byte, 16)
+	_, _ = rand.Read(salt)
+	hash := pbkdf2([]byte(password), salt, PasswordIterations, sha256.Size)
+	return fmt.Sprintf("%s$%d$%s$%s", passwordScheme, PasswordIterations, hex.EncodeToString(salt), hex.EncodeToString(hash))
+}
+
+// pbkdf2 derives a key of the provided length from the password using
+// HMAC-SHA256 as described by RFC 8018
+func pbkdf2(password []byte, salt []byte, iterations int, length int) []byte {
+	mac := hmac.New(sha256.New, password)
+	out := make([]byte, 0, length)
+	block := make([]byte, 4)
+	for i := uint32(1); len(out) < length; i++ {
+		binary.BigEndian.PutUint32(block, i)
+		mac.Reset()
+		mac.Write(salt)
+		mac.Write(block)
+		u := mac.Sum(nil)
+		t := append([]byte(nil), u...)
+		for n := 1; n < iterations; n++ {
+			mac.Reset()
+			mac.Write(u)
+			u = mac.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		out = append(out, t...)
+	}
+	return out[:length]
+}
+
+// legacyHash is the salted SHA-256 hash in the form salt:hash used before
+// passwords were hashed with PBKDF2
+func legacyHash(salt string, password string) string {
+	sum := sha256.Sum256([]byte(salt + password))
+	return salt + ":" + hex.EncodeToString(sum[:])
+}
+
+// parsePasswordHash splits a hash created by HashPassword
+func parsePasswordHash(value string) (iterations int, salt []byte, hash []byte, ok bool) {
+	parts := strings.Split(value, "$")
+	if len(parts) != 4 || parts[0] != passwordScheme {
+		return 0, nil, nil, false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return 0, nil, nil, false
+	}
+	salt, saltErr := hex.DecodeString(parts[2])
+	hash, hashErr := hex.DecodeString(parts[3])
+	if saltErr != nil || hashErr != nil || len(hash) == 0 {
+		return 0, nil, nil, false
+	}
+	return iterations, salt, hash, true
+}
+
+// isLegacyHash checks whether the value is a salt:hash legacy hash
+func isLegacyHash(value string) bool {
+	salt, hash, found := strings.Cut(value, ":")
+	if !found || len(hash) != sha256.Size*2 || salt == "" {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// IsPasswordHash checks whether the value is a password hash rather than a
+// plain text password
+func IsPasswordHash(value string) bool {
+	_, _, _, ok := parsePasswordHash(value)
+	return ok || isLegacyHash(value)
+}
+
+// CheckPassword checks whether the password matches the players password hash
+func (p *Player) CheckPassword(password string) bool {
+	if password == "" {
+		return false
+	}
+	if iterations, salt, hash, ok := parsePasswordHash(p.Password); ok {
+		return subtle.ConstantTimeCompare(pbkdf2([]byte(password), salt, iterations, len(hash)), hash) == 1
+	}
+	if !isLegacyHash(p.Password) {
+		return false
+	}
+	salt, _, _ := strings.Cut(p.Password, ":")
+	return subtle.ConstantTimeCompare([]byte(legacyHash(salt, password)), []byte(p.Password)) == 1
+}
+
+// NeedsRehash checks whether the password hash was created by an older
+// scheme or with fewer iterations than PasswordIterations so that it can be
+// replaced the next time the player logs in
+func (p *Player) NeedsRehash() bool {
+	iterations, _, _, ok := parsePasswordHash(p.Password)
+	return !ok || iterations < PasswordIterations
+}
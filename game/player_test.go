@@ -0,0 +1,51 @@
+package game
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestPbkdf2(t *testing.T) {
+	// Test vectors from RFC 7914 section 11
+	tests := []struct {
+		password, salt string
+		iterations     int
+		expected       string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+	}
+	for _, test := range tests {
+		out := hex.EncodeToString(pbkdf2([]byte(test.password), []byte(test.salt), test.iterations, 64))
+		if out != test.expected {
+			t.Errorf("pbkdf2(%q, %q, %d) = %s", test.password, test.salt, test.iterations, out)
+		}
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	player := &Player{Password: HashPassword("hunter2")}
+	if !strings.HasPrefix(player.Password, passwordScheme+"$") {
+		t.Fatalf("unexpected hash %s", player.Password)
+	}
+	if !player.CheckPassword("hunter2") || player.CheckPassword("hunter3") || player.CheckPassword("") {
+		t.Error("hash didn't check the password")
+	}
+	if player.NeedsRehash() {
+		t.Error("new hash needs rehashing")
+	}
+
+	legacy := &Player{Password: legacyHash("0011", "hunter2")}
+	if !legacy.CheckPassword("hunter2") || legacy.CheckPassword("hunter3") {
+		t.Error("legacy hash didn't check the password")
+	}
+	if !legacy.NeedsRehash() {
+		t.Error("legacy hash doesn't need rehashing")
+	}
+
+	plain := &Player{Password: "hunter2"}
+	if plain.CheckPassword("hunter2") || IsPasswordHash(plain.Password) {
+		t.Error("plain text password was accepted")
+	}
+}
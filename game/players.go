@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+var ErrPlayerExists = errors.New("a player with that name or email already exists")
+
+// Players is the collection of all registered players which is persisted
+// to the "players" file of the store
+type Players struct {
+	store *store.Store
+	lock  sync.RWMutex
+
+	NextId  uint32
+	Players map[uint32]*Player
+}
+
+// LoadPlayers loads the players collection from the provided store
+func LoadPlayers(s *store.Store) (*Players, error) {
+	p := &Players{store: s, NextId: 1, Players: map[uint32]*Player{}}
+	if err := s.Load("players", p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Save writes the players collection to the store
+func (p *Players) Save() error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.store.Save("players", p)
+}
+
+// Get returns the player with the provided ID or nil if there is none
+func (p *Players) Get(id uint32) *Player {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.Players[id]
+}
+
+// ByName finds a player by its name or email ignoring case
+func (p *Players) ByName(name string) *Player {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.byName(name)
+}
+
+// byName is ByName for callers already holding the collection lock
+func (p *Players) byName(name string) *Player {
+	for _, player := range p.Players {
+		if strings.EqualFold(player.Name, name) || strings.EqualFold(player.Email, name) {
+			return player
+		}
+	}
+	return nil
+}
+
+// All returns every player ordered by ID
+func (p *Players) All() []*Player {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	out := make([]*Player, 0, len(p.Players))
+	for _, player := range p.Players {
+		out = append(out, player)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	return out
+}
+
+// Create registers a new player and saves the collection. The password is
+// stored as a hash created by HashPassword and must not be empty
+func (p *Players) Create(name string, email string, password string) (*Player, error) {
+	if password == "" {
+		return nil, ErrEmptyPassword
+	}
+	p.lock.Lock()
+	if p.byName(name) != nil || (email != "" && p.byName(email) != nil) {
+		p.lock.Unlock()
+		return nil, ErrPlayerExists
+	}
+	player := &Player{
+		Id:       p.NextId,
+		Name:     name,
+		Email:    email,
+		Password: HashPassword(password),
+		Settings: map[string]string{},
+	}
+	p.Players[player.Id] = player
+	p.NextId++
+	p.lock.Unlock()
+	return player, p.Save()
+}
+
+// Update runs the provided function while holding the collection lock
+// and then saves the collection
+func (p *Players) Update(player *Player, update func(player *Player)) error {
+	p.lock.Lock()
+	update(player)
+	p.lock.Unlock()
+	return p.Save()
+}
+
+// View runs the provided function while holding the collection read lock
+// so that the player can be read safely
+func (p *Players) View(player *Player, view func(player *Player)) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	view(player)
+}
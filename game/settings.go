@@ -0,0 +1,124 @@
+package game
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BaseSettingKey is the key of the player setting that stores credits, play
+// statistics and the inventory
+const BaseSettingKey = "Base"
+
+// DefaultBaseSetting is used for players that have never saved a Base setting
+const DefaultBaseSetting = "20;4;0;-1;0;0;0;0;0;0;"
+
+// Indexes of the fields within the Base setting
+const (
+	baseCredits       = 2
+	baseCreditsSpent  = 5
+	baseGamesPlayed   = 7
+	baseSecondsPlayed = 8
+	baseInventory     = 10
+	baseFieldCount    = 11
+)
+
+// BaseSettings is the decoded form of the Base setting. The setting is a
+// semicolon separated list where the last field is a hex string with one
+// byte per inventory item holding the number of that item owned. Fields
+// that aren't understood are kept as is
+type BaseSettings struct {
+	fields []string
+
+	Credits       int64
+	CreditsSpent  int64
+	GamesPlayed   int64
+	SecondsPlayed int64
+	Inventory     []byte
+}
+
+// ParseBaseSettings decodes a Base setting value
+func ParseBaseSettings(value string) (BaseSettings, error) {
+	fields := strings.Split(value, ";")
+	if len(fields) < baseFieldCount {
+		return BaseSettings{}, fmt.Errorf("base setting has %d fields expected %d", len(fields), baseFieldCount)
+	}
+	out := BaseSettings{fields: fields}
+	var err error
+	if out.Credits, err = parseBaseField(fields, baseCredits); err != nil {
+		return out, err
+	}
+	if out.CreditsSpent, err = parseBaseField(fields, baseCreditsSpent); err != nil {
+		return out, err
+	}
+	if out.GamesPlayed, err = parseBaseField(fields, baseGamesPlayed); err != nil {
+		return out, err
+	}
+	if out.SecondsPlayed, err = parseBaseField(fields, baseSecondsPlayed); err != nil {
+		return out, err
+	}
+	if out.Inventory, err = hex.DecodeString(fields[baseInventory]); err != nil {
+		return out, fmt.Errorf("base setting inventory: %w", err)
+	}
+	return out, nil
+}
+
+func parseBaseField(fields []string, index int) (int64, error) {
+	value, err := strconv.ParseInt(fields[index], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("base setting field %d: %w", index, err)
+	}
+	return value, nil
+}
+
+// String encodes the settings back into the Base setting format
+func (b BaseSettings) String() string {
+	fields := make([]string, len(b.fields))
+	copy(fields, b.fields)
+	if len(fields) < baseFieldCount {
+		fields = strings.Split(DefaultBaseSetting, ";")
+	}
+	fields[baseCredits] = strconv.FormatInt(b.Credits, 10)
+	fields[baseCreditsSpent] = strconv.FormatInt(b.CreditsSpent, 10)
+	fields[baseGamesPlayed] = strconv.FormatInt(b.GamesPlayed, 10)
+	fields[baseSecondsPlayed] = strconv.FormatInt(b.SecondsPlayed, 10)
+	fields[baseInventory] = hex.EncodeToString(b.Inventory)
+	return strings.Join(fields, ";")
+}
+
+// Count returns the number of the inventory item at the provided index
+func (b BaseSettings) Count(index int) int {
+	if index < 0 || index >= len(b.Inventory) {
+		return 0
+	}
+	return int(b.Inventory[index])
+}
+
+// SetCount sets the number of the inventory item at the provided index
+// growing the inventory if needed. Counts are limited to a single byte
+func (b *BaseSettings) SetCount(index int, count int) {
+	if index < 0 {
+		return
+	}
+	if count > 0xFF {
+		count = 0xFF
+	}
+	if count < 0 {
+		count = 0
+	}
+	for len(b.Inventory) <= index {
+		b.Inventory = append(b.Inventory, 0)
+	}
+	b.Inventory[index] = byte(count)
+}
+
+// BaseSettings decodes the players Base setting using the default when the
+// player doesn't have one yet
+func (p *Player) BaseSettings() (BaseSettings, error) {
+	value, exists := p.Settings[BaseSettingKey]
+	if !exists {
+		value = DefaultBaseSetting
+	}
+	return ParseBaseSettings(value)
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jacobtread/gomes/store"
+)
+
+func TestParseBaseSettings(t *testing.T) {
+	base, err := ParseBaseSettings("20;4;21474;-1;0;3300;0;50;180000;0;0a0b00ff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Credits != 21474 || base.CreditsSpent != 3300 || base.GamesPlayed != 50 || base.SecondsPlayed != 180000 {
+		t.Errorf("unexpected fields %+v", base)
+	}
+	if !bytes.Equal(base.Inventory, []byte{0x0a, 0x0b, 0x00, 0xff}) {
+		t.Errorf("unexpected inventory %x", base.Inventory)
+	}
+}
+
+func TestBaseSettingsRoundTrip(t *testing.T) {
+	values := []string{
+		DefaultBaseSetting,
+		"20;4;21474;-1;0;3300;0;50;180000;0;0a0b00ff",
+		"20;4;0;-1;0;0;0;0;0;0;00;extra;fields",
+	}
+	for _, value := range values {
+		base, err := ParseBaseSettings(value)
+		if err != nil {
+			t.Fatalf("%q: %v", value, err)
+		}
+		if out := base.String(); out != value {
+			t.Errorf("round trip of %q produced %q", value, out)
+		}
+	}
+}
+
+func TestBaseSettingsModify(t *testing.T) {
+	base, err := ParseBaseSettings(DefaultBaseSetting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base.Credits = 500
+	base.SetCount(3, 2)
+	base.SetCount(1, 300)
+	if out := base.String(); out != "20;4;500;-1;0;0;0;0;0;0;00ff0002" {
+		t.Errorf("unexpected setting %q", out)
+	}
+	if base.Count(3) != 2 || base.Count(1) != 0xFF || base.Count(10) != 0 || base.Count(-1) != 0 {
+		t.Errorf("unexpected counts %x", base.Inventory)
+	}
+}
+
+func TestParseBaseSettingsInvalid(t *testing.T) {
+	values := []string{
+		"",
+		"20;4;0",
+		"20;4;abc;-1;0;0;0;0;0;0;",
+		"20;4;0;-1;0;0;0;0;0;0;zz",
+		"20;4;0;-1;0;0;0;0;0;0;abc",
+	}
+	for _, value := range values {
+		if _, err := ParseBaseSettings(value); err == nil {
+			t.Errorf("expected error parsing %q", value)
+		}
+	}
+}
+
+func newTestPlayers(t *testing.T) *Players {
+	s, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, err := LoadPlayers(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return players
+}
+
+func TestPurchase(t *testing.T) {
+	players := newTestPlayers(t)
+	player, err := players.Create("Shepard", "shepard@example.com", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	player.Settings[BaseSettingKey] = "20;4;40000;-1;0;0;0;0;0;0;"
+
+	packs, err := NewPackStore(DefaultPackConfig, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := packs.Purchase(players, player, "VeteranPack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Credits != 7000 || len(result.Items) != 5 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	base, err := player.BaseSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Credits != 7000 || base.CreditsSpent != 33000 {
+		t.Errorf("unexpected credits %+v", base)
+	}
+
+	if _, err := packs.Purchase(players, player, "VeteranPack"); err != ErrNotEnoughCredits {
+		t.Errorf("expected not enough credits got %v", err)
+	}
+	if _, err := packs.Purchase(players, player, "MissingPack"); err != ErrUnknownPack {
+		t.Errorf("expected unknown pack got %v", err)
+	}
+	after, _ := player.BaseSettings()
+	if after.String() != base.String() {
+		t.Errorf("failed purchase changed settings %q", after.String())
+	}
+}
+
+func TestPurchaseSeeded(t *testing.T) {
+	roll := func() PurchaseResult {
+		players := newTestPlayers(t)
+		player, _ := players.Create("Shepard", "", "password")
+		player.Settings[BaseSettingKey] = "20;4;99000;-1;0;0;0;0;0;0;"
+		packs, _ := NewPackStore(DefaultPackConfig, 42)
+		result, err := packs.Purchase(players, player, "SpectrePack")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	a := roll()
+	b := roll()
+	if len(a.Items) != len(b.Items) {
+		t.Fatalf("seeded purchases differ %+v %+v", a, b)
+	}
+	for i := range a.Items {
+		if a.Items[i] != b.Items[i] {
+			t.Fatalf("seeded purchases differ %+v %+v", a, b)
+		}
+	}
+	if a.Items[0].Name != "MedigelCapacity" {
+		t.Errorf("guaranteed item missing %+v", a)
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jacobtread/gomes/game"
+)
+
+// AdminToken is the bearer token the admin endpoints require. The admin
+// endpoints are disabled when it is empty
+var AdminToken = ""
+
+// adminPath is the path that the admin endpoints are served under
+const adminPath = "/admin/"
+
+// registerAdmin adds the admin endpoints to the mux
+func registerAdmin(mux *http.ServeMux) {
+	mux.HandleFunc(adminPath+"broadcast", requireAdmin(handleBroadcast))
+	mux.HandleFunc(adminPath+"ban", requireAdmin(handleBan))
+}
+
+// requireAdmin only passes POST requests with the admin token on to the
+// handler
+func requireAdmin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if AdminToken == "" {
+			http.NotFound(w, r)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(AdminToken)) != 1 {
+			http.Error(w, "invalid admin token", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "admin requests must be POST", http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handleBroadcast sends the "message" form value to every player
+func handleBroadcast(w http.ResponseWriter, r *http.Request) {
+	text := r.FormValue("message")
+	if text == "" {
+		http.Error(w, "missing message", http.StatusBadRequest)
+		return
+	}
+	if err := BroadcastMessage(text); err != nil {
+		log.Println("Failed to save messages", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	log.Println("Broadcast message", text)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BanPlayer changes whether the player is banned and saves the players.
+// Banning also revokes the tokens of the player and disconnects them
+func BanPlayer(player *game.Player, banned bool) error {
+	err := Players.Update(player, func(player *game.Player) { player.Banned = banned })
+	if banned {
+		RevokeTokens(player.Id)
+		for _, session := range playerSessions(player.Id) {
+			session.Disconnect()
+		}
+	}
+	return err
+}
+
+// handleBan bans the player with the "name" form value or lifts the ban
+// when the "unban" form value is true
+func handleBan(w http.ResponseWriter, r *http.Request) {
+	player := Players.ByName(r.FormValue("name"))
+	if player == nil {
+		http.Error(w, "unknown player", http.StatusNotFound)
+		return
+	}
+	unban, _ := strconv.ParseBool(r.FormValue("unban"))
+	if err := BanPlayer(player, !unban); err != nil {
+		log.Println("Failed to save players", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	log.Println("Changed ban of player", player.Name, "banned", !unban)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AdminRequest posts the form to the admin endpoint of the HTTP server at
+// the provided address such as "http://127.0.0.1:80"
+func AdminRequest(address string, token string, endpoint string, form url.Values) error {
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(address, "/")+adminPath+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("%s: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
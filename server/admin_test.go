@@ -0,0 +1,132 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+)
+
+func TestBroadcast(t *testing.T) {
+	loadTestData(t)
+	online := createPlayer(t, "Shepard")
+	offline := createPlayer(t, "Wrex")
+	c := loginAs(t, online)
+	messages := c.Subscribe(MessagingComponent, NotifyMessage)
+	defer messages.Close()
+
+	AdminToken = "secret"
+	defer func() { AdminToken = "" }()
+	httpServer := httptest.NewServer(newHttpMux())
+	defer httpServer.Close()
+
+	if err := AdminRequest(httpServer.URL, "wrong", "broadcast", url.Values{"message": {"hi"}}); err == nil {
+		t.Error("broadcast with the wrong token succeeded")
+	}
+	response, err := http.Get(httpServer.URL + adminPath + "broadcast?message=hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET without a token gave %s", response.Status)
+	}
+
+	if err := AdminRequest(httpServer.URL, "secret", "broadcast", url.Values{"message": {"Server restarting"}}); err != nil {
+		t.Fatal(err)
+	}
+	payload, _ := blaze.Find[blaze.StructTdf](receive(t, messages), "PYLD")
+	if body := readAttributes(payload.Values)[game.MessageBodyAttr]; body != "Server restarting" {
+		t.Errorf("online player received %q", body)
+	}
+	if stored := Messages.Find(offline.Id, game.MessageFilter{}); len(stored) != 1 {
+		t.Errorf("offline player has %d messages", len(stored))
+	}
+}
+
+func TestMessagingRequiresLogin(t *testing.T) {
+	loadTestData(t)
+	c := connect(t)
+	for command := uint16(0x1); command <= 0x5; command++ {
+		_, err := call(t, c, MessagingComponent, command)
+		if code := errorCode(t, err); code != MsgErrAuthRequired {
+			t.Errorf("command 0x%X gave error 0x%X", command, code)
+		}
+	}
+}
+
+func TestFetchMessagesNotifiesRequestingSession(t *testing.T) {
+	loadTestData(t)
+	motd := Motd
+	Motd = ""
+	defer func() { Motd = motd }()
+	player := createPlayer(t, "Garrus")
+	if _, err := Messages.Add(game.Message{Attributes: map[int64]string{game.MessageBodyAttr: "Calibrating"}}, []uint32{player.Id}); err != nil {
+		t.Fatal(err)
+	}
+	first := loginAs(t, player)
+	second := loginAs(t, player)
+	firstMessages := first.Subscribe(MessagingComponent, NotifyMessage)
+	defer firstMessages.Close()
+	secondMessages := second.Subscribe(MessagingComponent, NotifyMessage)
+	defer secondMessages.Close()
+
+	if _, err := call(t, second, MessagingComponent, 0x2); err != nil {
+		t.Fatal(err)
+	}
+	payload, _ := blaze.Find[blaze.StructTdf](receive(t, secondMessages), "PYLD")
+	if body := readAttributes(payload.Values)[game.MessageBodyAttr]; body != "Calibrating" {
+		t.Errorf("requesting session received %q", body)
+	}
+	select {
+	case <-firstMessages.C:
+		t.Error("messages were sent to the other session")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBanDisconnects(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Morinth")
+	c := loginAs(t, player)
+	response, err := call(t, c, AuthenticationComponent, 0x24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := response.StringOr("AUTH", "")
+
+	AdminToken = "secret"
+	defer func() { AdminToken = "" }()
+	httpServer := httptest.NewServer(newHttpMux())
+	defer httpServer.Close()
+	if err := AdminRequest(httpServer.URL, "secret", "ban", url.Values{"name": {player.Name}}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("banned player wasn't disconnected")
+	}
+	if OnlineSession(player.Id) != nil {
+		t.Error("banned player is still online")
+	}
+	if TokenPlayer(token) != nil {
+		t.Error("token of the banned player still works")
+	}
+	_, err = call(t, connect(t), AuthenticationComponent, 0x28,
+		blaze.NewString("MAIL", player.Email),
+		blaze.NewString("PASS", "password"),
+	)
+	if code := errorCode(t, err); code != AuthErrBanned {
+		t.Errorf("banned login gave error 0x%X", code)
+	}
+
+	if err := AdminRequest(httpServer.URL, "secret", "ban", url.Values{"name": {player.Name}, "unban": {"true"}}); err != nil {
+		t.Fatal(err)
+	}
+	loginAs(t, player)
+}
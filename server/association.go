@@ -0,0 +1,375 @@
+package server
+
+import (
+	"log"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+	"github.com/jacobtread/gomes/types"
+)
+
+const AssociationComponent uint16 = 0x19
+
+const (
+	NotifyUpdateListMembership uint16 = 0x1
+
+	UserSessionsComponent uint16 = 0x7802
+	NotifyUserAdded       uint16 = 0x2
+	NotifyUserRemoved     uint16 = 0x3
+)
+
+// Association list error codes
+const (
+	AssocErrAuthRequired uint16 = 0x1
+	AssocErrInvalidList  uint16 = 0x2
+	AssocErrListFull     uint16 = 0x3
+	AssocErrUserNotFound uint16 = 0x4
+	AssocErrListHidden   uint16 = 0x5
+)
+
+// List membership operations sent in NotifyUpdateListMembership
+const (
+	memberAdded   int64 = 0
+	memberRemoved int64 = 1
+)
+
+func init() {
+	RegisterHandlers(AssociationComponent, map[uint16]Handler{
+		0x01: handleAddUsersToList,
+		0x02: handleRemoveUsersFromList,
+		0x03: handleClearLists,
+		0x04: handleSetUsersToList,
+		0x05: handleGetListForUser,
+		0x06: handleGetLists,
+		0x07: handleSubscribeToLists,
+		0x08: handleUnsubscribeFromLists,
+		0x09: handleGetConfigListsInfo,
+	})
+}
+
+// readListId reads the list identification struct with the provided label
+func readListId(values blaze.Values, label string) (game.ListConfig, bool) {
+	lid, ok := blaze.Find[blaze.StructTdf](values, label)
+	if !ok {
+		return game.ListConfig{}, false
+	}
+	return readListIdValues(lid.Values)
+}
+
+func readListIdValues(values blaze.Values) (game.ListConfig, bool) {
+	config, err := game.GetListConfig(game.ListType(values.IntOr("TYPE", 0)), values.StringOr("LNM", ""))
+	return config, err == nil
+}
+
+// readListIds reads the list identification structs in the LIDS list
+func readListIds(values blaze.Values) []game.ListConfig {
+	var out []game.ListConfig
+	lids, ok := blaze.Find[blaze.List[blaze.StructTdf]](values, "LIDS")
+	if !ok {
+		return game.ListConfigs
+	}
+	for _, lid := range lids.Values {
+		if config, ok := readListIdValues(lid.Values); ok {
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+// readUserIds reads the player IDs from the user identification structs
+// in the ULST list. Users can be identified by either ID or NAME
+func readUserIds(values blaze.Values) ([]uint32, bool) {
+	users, ok := blaze.Find[blaze.List[blaze.StructTdf]](values, "ULST")
+	if !ok {
+		return nil, false
+	}
+	var out []uint32
+	for _, user := range users.Values {
+		var player *game.Player
+		if id := user.Values.IntOr("ID", 0); id != 0 {
+			player = Players.Get(uint32(id))
+		} else if name := user.Values.StringOr("NAME", ""); name != "" {
+			player = Players.ByName(name)
+		}
+		if player == nil {
+			return nil, false
+		}
+		out = append(out, player.Id)
+	}
+	return out, true
+}
+
+func listIdTdf(label string, config game.ListConfig) blaze.StructTdf {
+	return blaze.NewStruct(label,
+		blaze.NewString("LNM", config.Name),
+		blaze.NewInt64("TYPE", int64(config.Type)),
+	)
+}
+
+func listInfoTdf(label string, config game.ListConfig, owner uint32) blaze.StructTdf {
+	return blaze.NewStruct(label,
+		blaze.NewTriple("BOID", types.Triple{A: int64(AssociationComponent), B: 1, C: int64(owner)}),
+		blaze.NewInt64("FLGS", config.Flags),
+		listIdTdf("LID", config),
+		blaze.NewInt64("LMS", int64(config.MaxSize)),
+		blaze.NewInt64("PRID", 0),
+	)
+}
+
+func userTdf(label string, id uint32) blaze.StructTdf {
+	name := ""
+	if player := Players.Get(id); player != nil {
+		name = player.Name
+	}
+	return blaze.NewStruct(label,
+		blaze.NewInt64("ID", int64(id)),
+		blaze.NewString("NAME", name),
+	)
+}
+
+func memberTdf(member game.ListMember) blaze.StructTdf {
+	return blaze.NewStructStub([]blaze.Tdf{
+		blaze.NewStruct("LMID", userTdf("USER", member.Id)),
+		blaze.NewInt64("TIME", member.Added),
+	}, false)
+}
+
+func memberListTdf(label string, members []game.ListMember) blaze.List[blaze.StructTdf] {
+	out := make([]blaze.StructTdf, 0, len(members))
+	for _, member := range members {
+		out = append(out, memberTdf(member))
+	}
+	return blaze.NewList(label, out)
+}
+
+// listMembersTdf creates the struct describing a list and the members within
+// it starting at the provided offset and containing up to max members
+func listMembersTdf(config game.ListConfig, owner uint32, offset int64, max int64) blaze.StructTdf {
+	members, total := Associations.Page(owner, config.Type, offset, max)
+	return blaze.NewStructStub([]blaze.Tdf{
+		listInfoTdf("INFO", config, owner),
+		memberListTdf("MEML", members),
+		blaze.NewInt64("OFRC", offset),
+		blaze.NewInt64("TOCT", int64(total)),
+	}, false)
+}
+
+// notifyMembership lets the owner of a list know that its members have
+// changed if they are online and subscribed to the list
+func notifyMembership(owner uint32, config game.ListConfig, members []game.ListMember, operation int64) {
+	session := OnlineSession(owner)
+	if session == nil || !session.Subscribed(config.Type) {
+		return
+	}
+	for _, member := range members {
+		session.Notify(AssociationComponent, NotifyUpdateListMembership, []blaze.Tdf{
+			listIdTdf("LID", config),
+			blaze.NewStruct("MEMB", memberTdf(member).Values...),
+			blaze.NewInt64("OPER", operation),
+		})
+	}
+}
+
+// presenceContent creates the content of the presence notifications sent
+// for the provided player
+func presenceContent(player *game.Player, online bool) []blaze.Tdf {
+	if online {
+		return []blaze.Tdf{userTdf("USER", player.Id)}
+	}
+	return []blaze.Tdf{blaze.NewInt64("BUID", int64(player.Id))}
+}
+
+// notifyPresence lets every online player with the provided player in their
+// friends list know that the player has come online or gone offline
+func notifyPresence(player *game.Player, online bool) {
+	command := NotifyUserRemoved
+	if online {
+		command = NotifyUserAdded
+	}
+	for _, owner := range Associations.ListsContaining(game.FriendList, player.Id) {
+		session := OnlineSession(owner)
+		if session != nil && session.Subscribed(game.FriendList) {
+			session.Notify(UserSessionsComponent, command, presenceContent(player, online))
+		}
+	}
+}
+
+// readMembershipRequest reads the owner, list and users from an add, remove
+// or set request
+func readMembershipRequest(session *Session, packet *blaze.Packet) (uint32, game.ListConfig, []uint32, bool) {
+	player, ok := requirePlayer(session, packet, AssocErrAuthRequired)
+	if !ok {
+		return 0, game.ListConfig{}, nil, false
+	}
+	content := packet.ReadContent()
+	config, ok := readListId(content, "LID")
+	if !ok {
+		session.RespondError(packet, AssocErrInvalidList)
+		return 0, config, nil, false
+	}
+	ids, ok := readUserIds(content)
+	if !ok {
+		session.RespondError(packet, AssocErrUserNotFound)
+		return 0, config, nil, false
+	}
+	return player.Id, config, ids, true
+}
+
+func respondMembers(session *Session, packet *blaze.Packet, members []game.ListMember) {
+	session.Respond(packet, []blaze.Tdf{memberListTdf("LMID", members)})
+}
+
+func handleAddUsersToList(session *Session, packet *blaze.Packet) {
+	owner, config, ids, ok := readMembershipRequest(session, packet)
+	if !ok {
+		return
+	}
+	added, evicted, err := Associations.Add(owner, config, ids)
+	notifyMembership(owner, config, evicted, memberRemoved)
+	notifyMembership(owner, config, added, memberAdded)
+	if err == game.ErrListFull {
+		session.RespondError(packet, AssocErrListFull)
+		return
+	} else if err != nil {
+		log.Println("Failed to save association lists", err)
+	}
+	respondMembers(session, packet, added)
+}
+
+func handleRemoveUsersFromList(session *Session, packet *blaze.Packet) {
+	owner, config, ids, ok := readMembershipRequest(session, packet)
+	if !ok {
+		return
+	}
+	removed, err := Associations.Remove(owner, config.Type, ids)
+	if err != nil {
+		log.Println("Failed to save association lists", err)
+	}
+	notifyMembership(owner, config, removed, memberRemoved)
+	respondMembers(session, packet, removed)
+}
+
+func handleSetUsersToList(session *Session, packet *blaze.Packet) {
+	owner, config, ids, ok := readMembershipRequest(session, packet)
+	if !ok {
+		return
+	}
+	added, removed, err := Associations.Set(owner, config, ids)
+	notifyMembership(owner, config, removed, memberRemoved)
+	notifyMembership(owner, config, added, memberAdded)
+	if err == game.ErrListFull {
+		session.RespondError(packet, AssocErrListFull)
+		return
+	} else if err != nil {
+		log.Println("Failed to save association lists", err)
+	}
+	respondMembers(session, packet, added)
+}
+
+func handleClearLists(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, AssocErrAuthRequired)
+	if !ok {
+		return
+	}
+	for _, config := range readListIds(packet.ReadContent()) {
+		removed, err := Associations.Clear(player.Id, config.Type)
+		if err != nil {
+			log.Println("Failed to save association lists", err)
+		}
+		notifyMembership(player.Id, config, removed, memberRemoved)
+	}
+	session.RespondEmpty(packet)
+}
+
+func handleGetListForUser(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, AssocErrAuthRequired)
+	if !ok {
+		return
+	}
+	content := packet.ReadContent()
+	config, ok := readListId(content, "LID")
+	if !ok {
+		session.RespondError(packet, AssocErrInvalidList)
+		return
+	}
+	owner := player.Id
+	if id := content.IntOr("BID", 0); id != 0 {
+		if Players.Get(uint32(id)) == nil {
+			session.RespondError(packet, AssocErrUserNotFound)
+			return
+		}
+		owner = uint32(id)
+	}
+	// Lists such as the block list are only shown to their owner
+	if owner != player.Id && !config.Public {
+		session.RespondError(packet, AssocErrListHidden)
+		return
+	}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewStruct("LMEM", listMembersTdf(config, owner, 0, -1).Values...),
+	})
+}
+
+func handleGetLists(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, AssocErrAuthRequired)
+	if !ok {
+		return
+	}
+	content := packet.ReadContent()
+	offset := content.IntOr("OFRC", 0)
+	max := content.IntOr("MXRC", -1)
+	var out []blaze.StructTdf
+	for _, config := range readListIds(content) {
+		out = append(out, listMembersTdf(config, player.Id, offset, max))
+	}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewList("LMAP", out),
+	})
+}
+
+func handleSubscribeToLists(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, AssocErrAuthRequired)
+	if !ok {
+		return
+	}
+	configs := readListIds(packet.ReadContent())
+	for _, config := range configs {
+		session.SetSubscribed(config.Type, true)
+	}
+	session.RespondEmpty(packet)
+
+	// Let the subscriber know which of its friends are already online
+	for _, config := range configs {
+		if config.Type != game.FriendList {
+			continue
+		}
+		for _, member := range Associations.Members(player.Id, config.Type) {
+			if other := OnlineSession(member.Id); other != nil {
+				if friend := other.Player(); friend != nil {
+					session.Notify(UserSessionsComponent, NotifyUserAdded, presenceContent(friend, true))
+				}
+			}
+		}
+	}
+}
+
+func handleUnsubscribeFromLists(session *Session, packet *blaze.Packet) {
+	for _, config := range readListIds(packet.ReadContent()) {
+		session.SetSubscribed(config.Type, false)
+	}
+	session.RespondEmpty(packet)
+}
+
+func handleGetConfigListsInfo(session *Session, packet *blaze.Packet) {
+	var owner uint32
+	if player := session.Player(); player != nil {
+		owner = player.Id
+	}
+	out := make([]blaze.StructTdf, 0, len(game.ListConfigs))
+	for _, config := range game.ListConfigs {
+		out = append(out, blaze.NewStructStub(listInfoTdf("INFO", config, owner).Values, false))
+	}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewList("CFGS", out),
+	})
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+)
+
+func friendListId() blaze.StructTdf {
+	config, _ := game.GetListConfig(game.FriendList, "")
+	return listIdTdf("LID", config)
+}
+
+func usersTdf(players ...*game.Player) blaze.List[blaze.StructTdf] {
+	out := make([]blaze.StructTdf, len(players))
+	for i, player := range players {
+		out[i] = blaze.NewStructStub([]blaze.Tdf{blaze.NewInt64("ID", int64(player.Id))}, false)
+	}
+	return blaze.NewList("ULST", out)
+}
+
+// memberIds reads the IDs of the members in the LMID list of a response
+func memberIds(values blaze.Values, label string) []int64 {
+	var out []int64
+	members, _ := blaze.Find[blaze.List[blaze.StructTdf]](values, label)
+	for _, member := range members.Values {
+		id, _ := blaze.Find[blaze.StructTdf](member.Values, "LMID")
+		user, _ := blaze.Find[blaze.StructTdf](id.Values, "USER")
+		out = append(out, user.Values.IntOr("ID", 0))
+	}
+	return out
+}
+
+func TestAssociationsRequireLogin(t *testing.T) {
+	loadTestData(t)
+	friend := createPlayer(t, "Garrus")
+	c := connect(t)
+	_, err := call(t, c, AssociationComponent, 0x1, friendListId(), usersTdf(friend))
+	if code := errorCode(t, err); code != AssocErrAuthRequired {
+		t.Errorf("unauthenticated add gave error 0x%X", code)
+	}
+}
+
+func TestAssociationLists(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	garrus := createPlayer(t, "Garrus")
+	tali := createPlayer(t, "Tali")
+	c := loginAs(t, player)
+
+	_, err := call(t, c, AssociationComponent, 0x7, blaze.NewList("LIDS", []blaze.StructTdf{
+		blaze.NewStructStub(friendListId().Values, false),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates := c.Subscribe(AssociationComponent, NotifyUpdateListMembership)
+	defer updates.Close()
+
+	response, err := call(t, c, AssociationComponent, 0x1, friendListId(), usersTdf(garrus, tali))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := memberIds(response, "LMID"); len(ids) != 2 {
+		t.Errorf("added members %v", ids)
+	}
+	for range []int{0, 1} {
+		update := receive(t, updates)
+		if update.IntOr("OPER", -1) != memberAdded {
+			t.Errorf("unexpected update %v", update)
+		}
+	}
+	if !Associations.Contains(player.Id, game.FriendList, garrus.Id) {
+		t.Error("friend wasn't stored")
+	}
+
+	_, err = call(t, c, AssociationComponent, 0x2, friendListId(), usersTdf(tali))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update := receive(t, updates); update.IntOr("OPER", -1) != memberRemoved {
+		t.Errorf("unexpected update %v", update)
+	}
+
+	response, err = call(t, c, AssociationComponent, 0x5, friendListId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, _ := blaze.Find[blaze.StructTdf](response, "LMEM")
+	if ids := memberIds(list.Values, "MEML"); len(ids) != 1 || ids[0] != int64(garrus.Id) {
+		t.Errorf("friends list is %v", ids)
+	}
+}
+
+func TestFriendPresence(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	friend := createPlayer(t, "Liara")
+	if _, _, err := Associations.Add(player.Id, game.ListConfigs[0], []uint32{friend.Id}); err != nil {
+		t.Fatal(err)
+	}
+	c := loginAs(t, player)
+	online := c.Subscribe(UserSessionsComponent, NotifyUserAdded)
+	defer online.Close()
+	offline := c.Subscribe(UserSessionsComponent, NotifyUserRemoved)
+	defer offline.Close()
+	_, err := call(t, c, AssociationComponent, 0x7, blaze.NewList("LIDS", []blaze.StructTdf{
+		blaze.NewStructStub(friendListId().Values, false),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := loginAs(t, friend)
+	user, _ := blaze.Find[blaze.StructTdf](receive(t, online), "USER")
+	if user.Values.IntOr("ID", 0) != int64(friend.Id) {
+		t.Errorf("online notification for %v", user.Values)
+	}
+	if _, err := call(t, other, AuthenticationComponent, 0x46); err != nil {
+		t.Fatal(err)
+	}
+	if id := receive(t, offline).IntOr("BUID", 0); id != int64(friend.Id) {
+		t.Errorf("offline notification for %d", id)
+	}
+}
+
+func TestRollingListNotifiesEvicted(t *testing.T) {
+	loadTestData(t)
+	game.ListConfigs[1].MaxSize = 1
+	defer func() { game.ListConfigs[1].MaxSize = 50 }()
+	player := createPlayer(t, "Shepard")
+	first := createPlayer(t, "Jack")
+	second := createPlayer(t, "Miranda")
+	config, _ := game.GetListConfig(game.RecentPlayerList, "")
+	c := loginAs(t, player)
+	_, err := call(t, c, AssociationComponent, 0x7, blaze.NewList("LIDS", []blaze.StructTdf{
+		blaze.NewStructStub(listIdTdf("LID", config).Values, false),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates := c.Subscribe(AssociationComponent, NotifyUpdateListMembership)
+	defer updates.Close()
+
+	if _, err := call(t, c, AssociationComponent, 0x1, listIdTdf("LID", config), usersTdf(first)); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, updates)
+	if _, err := call(t, c, AssociationComponent, 0x1, listIdTdf("LID", config), usersTdf(second)); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[int64]int64{memberRemoved: int64(first.Id), memberAdded: int64(second.Id)}
+	for range []int{0, 1} {
+		update := receive(t, updates)
+		member, _ := blaze.Find[blaze.StructTdf](update, "MEMB")
+		id, _ := blaze.Find[blaze.StructTdf](member.Values, "LMID")
+		user, _ := blaze.Find[blaze.StructTdf](id.Values, "USER")
+		operation := update.IntOr("OPER", -1)
+		if user.Values.IntOr("ID", 0) != expected[operation] {
+			t.Errorf("operation %d for %v", operation, user.Values)
+		}
+		delete(expected, operation)
+	}
+}
+
+func TestGetListForUserHidesPrivateLists(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	other := createPlayer(t, "Kai Leng")
+	blocked := createPlayer(t, "Udina")
+	blockList, _ := game.GetListConfig(game.BlockList, "")
+	if _, _, err := Associations.Add(other.Id, blockList, []uint32{blocked.Id}); err != nil {
+		t.Fatal(err)
+	}
+	c := loginAs(t, player)
+	_, err := call(t, c, AssociationComponent, 0x5, blaze.NewInt64("BID", int64(other.Id)), listIdTdf("LID", blockList))
+	if code := errorCode(t, err); code != AssocErrListHidden {
+		t.Errorf("reading another block list gave error 0x%X", code)
+	}
+	if _, err := call(t, c, AssociationComponent, 0x5, blaze.NewInt64("BID", int64(other.Id)), friendListId()); err != nil {
+		t.Errorf("reading another friend list failed %v", err)
+	}
+	if _, err := call(t, c, AssociationComponent, 0x5, blaze.NewInt64("BID", int64(player.Id)), listIdTdf("LID", blockList)); err != nil {
+		t.Errorf("reading the own block list failed %v", err)
+	}
+}
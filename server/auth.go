@@ -0,0 +1,218 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+)
+
+const AuthenticationComponent uint16 = 0x1
+
+// Authentication error codes
+const (
+	AuthErrInvalidUser     uint16 = 0xB
+	AuthErrInvalidPassword uint16 = 0xC
+	AuthErrInvalidToken    uint16 = 0xD
+	AuthErrBanned          uint16 = 0x2B
+)
+
+// TokenLifetime is how long the session keys given to players on login
+// can be used to log in again
+var TokenLifetime = 24 * time.Hour
+
+func init() {
+	RegisterHandlers(AuthenticationComponent, map[uint16]Handler{
+		0x24: handleGetAuthToken,
+		0x28: handleLogin,
+		0x32: handleSilentLogin,
+		0x46: handleLogout,
+		0x6E: handleLoginPersona,
+	})
+}
+
+type authToken struct {
+	player  uint32
+	expires time.Time
+}
+
+var (
+	tokens     = map[string]authToken{}
+	tokensLock sync.Mutex
+)
+
+// IssueToken creates a new random token that identifies the player with
+// the provided ID until it expires or is revoked
+func IssueToken(playerId uint32) string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data)
+	token := hex.EncodeToString(data)
+	tokensLock.Lock()
+	defer tokensLock.Unlock()
+	tokens[token] = authToken{player: playerId, expires: time.Now().Add(TokenLifetime)}
+	return token
+}
+
+// TokenPlayer returns the player the token was issued to or nil when the
+// token is unknown or has expired
+func TokenPlayer(token string) *game.Player {
+	tokensLock.Lock()
+	issued, ok := tokens[token]
+	if ok && time.Now().After(issued.expires) {
+		delete(tokens, token)
+		ok = false
+	}
+	tokensLock.Unlock()
+	if !ok {
+		return nil
+	}
+	return Players.Get(issued.player)
+}
+
+// RevokeTokens removes every token issued to the player with the provided ID
+func RevokeTokens(playerId uint32) {
+	tokensLock.Lock()
+	defer tokensLock.Unlock()
+	for token, issued := range tokens {
+		if issued.player == playerId {
+			delete(tokens, token)
+		}
+	}
+}
+
+// personaValues creates the persona details of the player. Each player has
+// a single persona with the same ID and name as the player
+func personaValues(player *game.Player, lastLogin int64) []blaze.Tdf {
+	return []blaze.Tdf{
+		blaze.NewString("DSNM", player.Name),
+		blaze.NewInt64("LAST", lastLogin),
+		blaze.NewInt64("PID", int64(player.Id)),
+		blaze.NewInt64("STAS", 0),
+		blaze.NewInt64("XREF", 0),
+		blaze.NewInt64("XTYP", 0),
+	}
+}
+
+// sessionDetails creates the values describing the session of a player
+// that has logged into the persona
+func sessionDetails(player *game.Player, key string) []blaze.Tdf {
+	now := time.Now().Unix()
+	return []blaze.Tdf{
+		blaze.NewInt64("BUID", int64(player.Id)),
+		blaze.NewInt64("FRST", 0),
+		blaze.NewString("KEY", key),
+		blaze.NewInt64("LLOG", now),
+		blaze.NewString("MAIL", player.Email),
+		blaze.NewStruct("PDTL", personaValues(player, now)...),
+		blaze.NewInt64("UID", int64(player.Id)),
+	}
+}
+
+// authenticate associates the session with the player responding with an
+// error when the player is banned
+func authenticate(session *Session, packet *blaze.Packet, player *game.Player) bool {
+	if err := session.SetPlayer(player); err != nil {
+		session.RespondError(packet, AuthErrBanned)
+		return false
+	}
+	return true
+}
+
+func handleLogin(session *Session, packet *blaze.Packet) {
+	content := packet.ReadContent()
+	player := Players.ByName(content.StringOr("MAIL", ""))
+	if player == nil {
+		session.RespondError(packet, AuthErrInvalidUser)
+		return
+	}
+	password := content.StringOr("PASS", "")
+	var valid, rehash bool
+	Players.View(player, func(player *game.Player) {
+		valid = player.CheckPassword(password)
+		rehash = player.NeedsRehash()
+	})
+	if !valid {
+		session.RespondError(packet, AuthErrInvalidPassword)
+		return
+	}
+	// Hashes from older schemes are replaced now that the password is known
+	if rehash {
+		hash := game.HashPassword(password)
+		if err := Players.Update(player, func(player *game.Player) { player.Password = hash }); err != nil {
+			log.Println("Failed to save players", err)
+		}
+	}
+	if !authenticate(session, packet, player) {
+		return
+	}
+	key := IssueToken(player.Id)
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewString("LDHT", ""),
+		blaze.NewInt64("NTOS", 0),
+		blaze.NewString("PCTK", key),
+		blaze.NewList("PLST", []blaze.StructTdf{
+			blaze.NewStructStub(personaValues(player, time.Now().Unix()), false),
+		}),
+		blaze.NewString("PRIV", ""),
+		blaze.NewString("SKEY", key),
+		blaze.NewInt64("SPAM", 0),
+		blaze.NewString("THST", ""),
+		blaze.NewString("TSUI", ""),
+		blaze.NewString("TURI", ""),
+		blaze.NewInt64("UID", int64(player.Id)),
+	})
+}
+
+func handleLoginPersona(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, AuthErrInvalidUser)
+	if !ok {
+		return
+	}
+	session.Respond(packet, sessionDetails(player, IssueToken(player.Id)))
+}
+
+func handleSilentLogin(session *Session, packet *blaze.Packet) {
+	content := packet.ReadContent()
+	player := TokenPlayer(content.StringOr("AUTH", ""))
+	if player == nil || player.Id != uint32(content.IntOr("PID", int64(player.Id))) {
+		session.RespondError(packet, AuthErrInvalidToken)
+		return
+	}
+	if !authenticate(session, packet, player) {
+		return
+	}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewInt64("AGUP", 0),
+		blaze.NewString("LDHT", ""),
+		blaze.NewInt64("NTOS", 0),
+		blaze.NewString("PCTK", content.StringOr("AUTH", "")),
+		blaze.NewString("PRIV", ""),
+		blaze.NewStruct("SESS", sessionDetails(player, IssueToken(player.Id))...),
+		blaze.NewInt64("SPAM", 0),
+		blaze.NewString("THST", ""),
+		blaze.NewString("TSUI", ""),
+		blaze.NewString("TURI", ""),
+	})
+}
+
+// handleGetAuthToken gives the token the client uses to log into the HTTP
+// services such as Galaxy at War
+func handleGetAuthToken(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, AuthErrInvalidUser)
+	if !ok {
+		return
+	}
+	session.Respond(packet, []blaze.Tdf{blaze.NewString("AUTH", IssueToken(player.Id))})
+}
+
+func handleLogout(session *Session, packet *blaze.Packet) {
+	if player := session.Player(); player != nil {
+		log.Println("Player logged out", player.Name)
+	}
+	session.ClearPlayer()
+	session.RespondEmpty(packet)
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+)
+
+func TestLogin(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	c := connect(t)
+
+	_, err := call(t, c, AuthenticationComponent, 0x28,
+		blaze.NewString("MAIL", "nobody@example.com"),
+		blaze.NewString("PASS", "password"),
+	)
+	if code := errorCode(t, err); code != AuthErrInvalidUser {
+		t.Errorf("unknown user gave error 0x%X", code)
+	}
+	_, err = call(t, c, AuthenticationComponent, 0x28,
+		blaze.NewString("MAIL", player.Email),
+		blaze.NewString("PASS", "wrong"),
+	)
+	if code := errorCode(t, err); code != AuthErrInvalidPassword {
+		t.Errorf("wrong password gave error 0x%X", code)
+	}
+	if OnlineSession(player.Id) != nil {
+		t.Fatal("player is online before logging in")
+	}
+
+	response, err := call(t, c, AuthenticationComponent, 0x28,
+		blaze.NewString("MAIL", player.Email),
+		blaze.NewString("PASS", "password"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := response.IntOr("UID", 0); id != int64(player.Id) {
+		t.Errorf("logged in as %d not %d", id, player.Id)
+	}
+	key := response.StringOr("SKEY", "")
+	if key == "" || TokenPlayer(key) != player {
+		t.Errorf("session key %q doesn't identify the player", key)
+	}
+	if OnlineSession(player.Id) == nil {
+		t.Fatal("player isn't online after logging in")
+	}
+
+	if _, err := call(t, c, AuthenticationComponent, 0x46); err != nil {
+		t.Fatal(err)
+	}
+	if OnlineSession(player.Id) != nil {
+		t.Error("player is still online after logging out")
+	}
+
+	// The session key logs in again without the password
+	other := connect(t)
+	_, err = call(t, other, AuthenticationComponent, 0x32,
+		blaze.NewString("AUTH", "not a token"),
+		blaze.NewInt64("PID", int64(player.Id)),
+	)
+	if code := errorCode(t, err); code != AuthErrInvalidToken {
+		t.Errorf("invalid token gave error 0x%X", code)
+	}
+	response, err = call(t, other, AuthenticationComponent, 0x32,
+		blaze.NewString("AUTH", key),
+		blaze.NewInt64("PID", int64(player.Id)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session, ok := blaze.Find[blaze.StructTdf](response, "SESS"); !ok || session.Values.IntOr("UID", 0) != int64(player.Id) {
+		t.Errorf("silent login gave session %v", response)
+	}
+	if OnlineSession(player.Id) == nil {
+		t.Error("player isn't online after a silent login")
+	}
+}
+
+func TestLoginBanned(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Saren")
+	if err := Players.Update(player, func(player *game.Player) { player.Banned = true }); err != nil {
+		t.Fatal(err)
+	}
+	c := connect(t)
+	_, err := call(t, c, AuthenticationComponent, 0x28,
+		blaze.NewString("MAIL", player.Email),
+		blaze.NewString("PASS", "password"),
+	)
+	if code := errorCode(t, err); code != AuthErrBanned {
+		t.Errorf("banned player gave error 0x%X", code)
+	}
+	if OnlineSession(player.Id) != nil {
+		t.Error("banned player is online")
+	}
+}
+
+func TestLoginRehashesPassword(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Tali")
+	iterations := game.PasswordIterations
+	game.PasswordIterations = iterations / 2
+	weak := game.HashPassword("password")
+	game.PasswordIterations = iterations
+	if err := Players.Update(player, func(player *game.Player) { player.Password = weak }); err != nil {
+		t.Fatal(err)
+	}
+	loginAs(t, player)
+	Players.View(player, func(player *game.Player) {
+		if player.Password == weak || player.NeedsRehash() || !player.CheckPassword("password") {
+			t.Errorf("password wasn't rehashed %q", player.Password)
+		}
+	})
+}
+
+func TestAuthResponsesMatchSchemas(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Liara")
+	c := connect(t)
+	check := func(command uint16, content ...blaze.Tdf) blaze.Values {
+		t.Helper()
+		response, err := call(t, c, AuthenticationComponent, command, content...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema, ok := blaze.LookupSchema(AuthenticationComponent, command, blaze.ResponseType)
+		if !ok || schema.Open {
+			t.Fatalf("0x%X has no response schema", command)
+		}
+		if errs := schema.Validate(response); len(errs) > 0 {
+			t.Errorf("%s: %v", schema.Name, errs)
+		}
+		return response
+	}
+	response := check(0x28, blaze.NewString("MAIL", player.Email), blaze.NewString("PASS", "password"))
+	check(0x6E, blaze.NewString("PNAM", player.Name))
+	check(0x24)
+	check(0x32, blaze.NewString("AUTH", response.StringOr("SKEY", "")), blaze.NewInt64("PID", int64(player.Id)))
+}
@@ -0,0 +1,283 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedirectorHost is the host name the client expects the redirector
+// certificate to be issued for. The certificate is only presented by the
+// redirector when RedirectorTLS is set
+const RedirectorHost = "gosredirector.ea.com"
+
+// CertDir is the directory within the data directory that generated
+// certificates are stored in
+const CertDir = "certs"
+
+// CertKeySize is the size of the generated RSA keys. The client only
+// supports RSA keys of 1024 or 2048 bits signed with SHA-1
+var CertKeySize = 1024
+
+const certSignatureAlgorithm = x509.SHA1WithRSA
+
+// Files within CertDir
+const (
+	caCertFile     = "ca.pem"
+	caKeyFile      = "ca-key.pem"
+	serverCertFile = "cert.pem"
+	serverKeyFile  = "key.pem"
+)
+
+var (
+	ErrInvalidKeySize    = errors.New("certificate key size must be 1024 or 2048")
+	ErrCertificatesExist = errors.New("certificates already exist")
+)
+
+// GenerateCertificates creates a new CA and a server certificate signed by it
+// for the redirector host names writing them to the provided directory.
+// Existing certificates and keys are only replaced when overwrite is set
+func GenerateCertificates(dir string, overwrite bool) error {
+	if CertKeySize != 1024 && CertKeySize != 2048 {
+		return ErrInvalidKeySize
+	}
+	if !overwrite {
+		for _, name := range []string{caKeyFile, caCertFile, serverKeyFile, serverCertFile} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return fmt.Errorf("%w in %s", ErrCertificatesExist, dir)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	now := time.Now()
+
+	caKey, err := rsa.GenerateKey(rand.Reader, CertKeySize)
+	if err != nil {
+		return err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          randomSerial(),
+		Subject:               pkix.Name{CommonName: "GoMES Certificate Authority", Organization: []string{"GoMES"}},
+		NotBefore:             now.Add(-24 * time.Hour),
+		NotAfter:              now.AddDate(20, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SignatureAlgorithm:    certSignatureAlgorithm,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	serverKey, err := rsa.GenerateKey(rand.Reader, CertKeySize)
+	if err != nil {
+		return err
+	}
+	hosts := append([]string{RedirectorHost}, DnsHosts...)
+	serverTemplate := &x509.Certificate{
+		SerialNumber:       randomSerial(),
+		Subject:            pkix.Name{CommonName: RedirectorHost, Organization: []string{"GoMES"}},
+		DNSNames:           uniqueStrings(hosts),
+		NotBefore:          now.Add(-24 * time.Hour),
+		NotAfter:           now.AddDate(10, 0, 0),
+		KeyUsage:           x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		SignatureAlgorithm: certSignatureAlgorithm,
+	}
+	caCert, err := x509.ParseCertificate(caDer)
+	if err != nil {
+		return err
+	}
+	serverDer, err := x509.CreateCertificate(rand.Reader, serverTemplate, caCert, &serverKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	// The CA key is written first and the server certificate last so that
+	// a reload never sees a certificate without its key
+	files := []struct {
+		name  string
+		block *pem.Block
+	}{
+		{caKeyFile, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(caKey)}},
+		{caCertFile, &pem.Block{Type: "CERTIFICATE", Bytes: caDer}},
+		{serverKeyFile, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(serverKey)}},
+		{serverCertFile, &pem.Block{Type: "CERTIFICATE", Bytes: serverDer}},
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := os.WriteFile(path+".tmp", pem.EncodeToMemory(file.block), 0600); err != nil {
+			return err
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func randomSerial() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+	return serial
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// CertificatesExist checks whether the server certificate and key exist in
+// the provided directory
+func CertificatesExist(dir string) bool {
+	_, certErr := os.Stat(filepath.Join(dir, serverCertFile))
+	_, keyErr := os.Stat(filepath.Join(dir, serverKeyFile))
+	return certErr == nil && keyErr == nil
+}
+
+// Fingerprints returns the SHA-1 and SHA-256 fingerprints of each certificate
+// in the provided PEM data
+func Fingerprints(data []byte) []string {
+	var out []string
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return out
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		name := "unknown"
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			name = cert.Subject.CommonName
+		}
+		sha1Sum := sha1.Sum(block.Bytes)
+		sha256Sum := sha256.Sum256(block.Bytes)
+		out = append(out,
+			fmt.Sprintf("%s SHA1 %s", name, formatFingerprint(sha1Sum[:])),
+			fmt.Sprintf("%s SHA256 %s", name, formatFingerprint(sha256Sum[:])),
+		)
+	}
+}
+
+func formatFingerprint(sum []byte) string {
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// PrintFingerprints logs the fingerprints of the certificates in the directory
+func PrintFingerprints(dir string) {
+	for _, name := range []string{caCertFile, serverCertFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		for _, fingerprint := range Fingerprints(data) {
+			log.Println(fingerprint)
+		}
+	}
+}
+
+// Certificates provides the server certificate to TLS listeners reloading it
+// whenever the files in the certificate directory change. When the directory
+// has no certificates the embedded certificate is used
+type Certificates struct {
+	Dir string
+
+	lock     sync.RWMutex
+	cert     *tls.Certificate
+	modified time.Time
+	checked  time.Time
+}
+
+// LoadCertificates loads the certificates from the provided directory
+func LoadCertificates(dir string) (*Certificates, error) {
+	c := &Certificates{Dir: dir}
+	return c, c.Reload()
+}
+
+// Reload loads the certificate again from the certificate directory
+func (c *Certificates) Reload() error {
+	certPath := filepath.Join(c.Dir, serverCertFile)
+	keyPath := filepath.Join(c.Dir, serverKeyFile)
+	var cert tls.Certificate
+	var err error
+	var modified time.Time
+	if CertificatesExist(c.Dir) {
+		cert, err = tls.LoadX509KeyPair(certPath, keyPath)
+		if info, statErr := os.Stat(certPath); statErr == nil {
+			modified = info.ModTime()
+		}
+	} else {
+		cert, err = tls.X509KeyPair(CertFile, KeyFile)
+	}
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	c.cert = &cert
+	c.modified = modified
+	c.lock.Unlock()
+	return nil
+}
+
+// changed checks whether the certificate file has been modified since it was
+// loaded. The file is checked at most every few seconds
+func (c *Certificates) changed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	if now.Sub(c.checked) < 5*time.Second {
+		return false
+	}
+	c.checked = now
+	info, err := os.Stat(filepath.Join(c.Dir, serverCertFile))
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(c.modified)
+}
+
+// GetCertificate is used as the tls.Config GetCertificate function
+func (c *Certificates) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if c.changed() {
+		if err := c.Reload(); err != nil {
+			log.Println("Failed to reload certificates", err)
+		} else {
+			log.Println("Reloaded certificates from", c.Dir)
+		}
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.cert, nil
+}
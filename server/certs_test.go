@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze/client"
+)
+
+func TestGenerateCertificatesRefusesOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenerateCertificates(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	ca, err := os.ReadFile(filepath.Join(dir, caCertFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateCertificates(dir, false); !errors.Is(err, ErrCertificatesExist) {
+		t.Fatalf("second generate gave %v", err)
+	}
+	if unchanged, _ := os.ReadFile(filepath.Join(dir, caCertFile)); string(unchanged) != string(ca) {
+		t.Fatal("refused generate changed the CA")
+	}
+	if err := GenerateCertificates(dir, true); err != nil {
+		t.Fatal(err)
+	}
+	if replaced, _ := os.ReadFile(filepath.Join(dir, caCertFile)); string(replaced) == string(ca) {
+		t.Error("overwriting generate kept the CA")
+	}
+}
+
+func TestRedirectorTLS(t *testing.T) {
+	defer func(dir string, port int) { DataDir, RedirectorPort, RedirectorTLS = dir, port, false }(DataDir, RedirectorPort)
+	DataDir = t.TempDir()
+	RedirectorPort = 0
+	RedirectorTLS = true
+	if err := GenerateCertificates(filepath.Join(DataDir, CertDir), false); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := listenRedirector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handleConnectionRedirect(conn)
+		}
+	}()
+
+	var names []string
+	config := &tls.Config{
+		// The generated certificates are signed with SHA-1 for the client
+		// which Go refuses to verify so only the names are checked
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			names = state.PeerCertificates[0].DNSNames
+			return nil
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server, err := client.Resolve(ctx, listener.Addr().String(), &client.Options{RedirectorTLS: config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if net.ParseIP(server.Host) == nil || server.Port != GamePort {
+		t.Errorf("redirected to %s", server.Address())
+	}
+	if len(names) == 0 || names[0] != RedirectorHost {
+		t.Errorf("redirector presented a certificate for %v", names)
+	}
+}
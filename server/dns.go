@@ -0,0 +1,280 @@
+package server
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"time"
+)
+
+// DnsEnabled starts the DNS responder along with the redirector so that
+// clients can be pointed at this machine without editing their hosts file
+var DnsEnabled = false
+
+var DnsPort = 53
+
+// DnsHosts are the host names answered with DnsAddress
+var DnsHosts = []string{
+	"gosredirector.ea.com",
+	"gosredirector.online.ea.com",
+	"gosredirector.stest.ea.com",
+	"gosredirector.scert.ea.com",
+	"gosredirector.sdev.ea.com",
+}
+
+// DnsAddress is the IPv4 address given for the DnsHosts. When empty the
+// address of the interface used to reach the internet is used
+var DnsAddress = ""
+
+// DnsUpstream is the host:port address of the DNS server other queries are
+// forwarded to such as "1.1.1.1:53". When empty other queries are refused
+var DnsUpstream = ""
+
+const (
+	dnsTypeA     uint16 = 1
+	dnsTypeAAAA  uint16 = 28
+	dnsTypeAny   uint16 = 255
+	dnsClassIN   uint16 = 1
+	dnsRcodeFail byte   = 2
+	dnsRcodeRef  byte   = 5
+	dnsTTL       uint32 = 60
+)
+
+var errInvalidDns = errors.New("invalid dns message")
+
+func StartDns() {
+	log.Println("GoMES DNS Server Starting")
+
+	address := net.ParseIP(DnsAddress).To4()
+	if address == nil {
+		address = outboundAddress()
+	}
+	log.Printf("Answering DNS queries for %s with %s", strings.Join(DnsHosts, ", "), address)
+
+	go startDnsTcp(address)
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: DnsPort})
+	if err != nil {
+		log.Println("Failed to start DNS server", err)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			log.Println("Failed to read DNS query", err)
+			continue
+		}
+		query := make([]byte, n)
+		copy(query, buf[:n])
+		go func() {
+			if response := handleDnsQuery(query, address, "udp"); response != nil {
+				_, _ = conn.WriteToUDP(response, addr)
+			}
+		}()
+	}
+}
+
+func startDnsTcp(address net.IP) {
+	t, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", DnsPort))
+	if err != nil {
+		log.Println("Failed to start DNS TCP server", err)
+		return
+	}
+	defer func(t net.Listener) { _ = t.Close() }(t)
+	for {
+		c, err := t.Accept()
+		if err != nil {
+			log.Println("Failed to accept DNS connection", err)
+			continue
+		}
+		go handleConnectionDns(c, address)
+	}
+}
+
+// handleConnectionDns answers queries on a TCP connection where each message
+// is prefixed with its length as 2 bytes
+func handleConnectionDns(conn net.Conn, address net.IP) {
+	defer func() { _ = conn.Close() }()
+	for {
+		_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+		query, err := readDnsTcp(conn)
+		if err != nil {
+			return
+		}
+		response := handleDnsQuery(query, address, "tcp")
+		if response == nil {
+			return
+		}
+		if err := writeDnsTcp(conn, response); err != nil {
+			return
+		}
+	}
+}
+
+func readDnsTcp(r io.Reader) ([]byte, error) {
+	length := make([]byte, 2)
+	if _, err := io.ReadFull(r, length); err != nil {
+		return nil, err
+	}
+	message := make([]byte, binary.BigEndian.Uint16(length))
+	if _, err := io.ReadFull(r, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func writeDnsTcp(w io.Writer, message []byte) error {
+	out := make([]byte, 2+len(message))
+	binary.BigEndian.PutUint16(out, uint16(len(message)))
+	copy(out[2:], message)
+	_, err := w.Write(out)
+	return err
+}
+
+// outboundAddress finds the address of the interface used to reach the
+// internet. No packets are sent by dialing udp
+func outboundAddress() net.IP {
+	conn, err := net.Dial("udp", "8.8.8.8:53")
+	if err != nil {
+		return net.IPv4(127, 0, 0, 1).To4()
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.LocalAddr().(*net.UDPAddr).IP.To4()
+}
+
+// dnsQuestion is the single question of a query along with the offset of
+// the end of the question section
+type dnsQuestion struct {
+	Name  string
+	Type  uint16
+	Class uint16
+	End   int
+}
+
+// parseDnsQuestion reads the first question from a query
+func parseDnsQuestion(message []byte) (dnsQuestion, error) {
+	if len(message) < 12 || binary.BigEndian.Uint16(message[4:]) < 1 {
+		return dnsQuestion{}, errInvalidDns
+	}
+	var labels []string
+	offset := 12
+	for {
+		if offset >= len(message) {
+			return dnsQuestion{}, errInvalidDns
+		}
+		length := int(message[offset])
+		offset++
+		if length == 0 {
+			break
+		}
+		// Compression pointers are not valid in the first question
+		if length&0xC0 != 0 || offset+length > len(message) {
+			return dnsQuestion{}, errInvalidDns
+		}
+		labels = append(labels, string(message[offset:offset+length]))
+		offset += length
+	}
+	if offset+4 > len(message) {
+		return dnsQuestion{}, errInvalidDns
+	}
+	return dnsQuestion{
+		Name:  strings.ToLower(strings.Join(labels, ".")),
+		Type:  binary.BigEndian.Uint16(message[offset:]),
+		Class: binary.BigEndian.Uint16(message[offset+2:]),
+		End:   offset + 4,
+	}, nil
+}
+
+func isDnsHost(name string) bool {
+	for _, host := range DnsHosts {
+		if strings.EqualFold(strings.TrimSuffix(name, "."), host) {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsResponse creates a response to the query containing only the question
+// and the provided A record answer if there is one
+func dnsResponse(query []byte, question dnsQuestion, rcode byte, answer net.IP) []byte {
+	out := make([]byte, question.End, question.End+16)
+	copy(out, query[:question.End])
+	// Response flag, keep the opcode and recursion desired, recursion available
+	out[2] = 0x80 | (query[2] & 0x79)
+	out[3] = 0x80 | rcode
+	binary.BigEndian.PutUint16(out[4:], 1)
+	binary.BigEndian.PutUint16(out[6:], 0)
+	binary.BigEndian.PutUint16(out[8:], 0)
+	binary.BigEndian.PutUint16(out[10:], 0)
+	if answer != nil {
+		binary.BigEndian.PutUint16(out[6:], 1)
+		record := make([]byte, 16)
+		// Pointer to the name in the question
+		binary.BigEndian.PutUint16(record[0:], 0xC00C)
+		binary.BigEndian.PutUint16(record[2:], dnsTypeA)
+		binary.BigEndian.PutUint16(record[4:], dnsClassIN)
+		binary.BigEndian.PutUint32(record[6:], dnsTTL)
+		binary.BigEndian.PutUint16(record[10:], 4)
+		copy(record[12:], answer)
+		out = append(out, record...)
+	}
+	return out
+}
+
+// handleDnsQuery answers the query returning nil when no response can be
+// sent. Queries for our hosts are answered directly and anything else is
+// forwarded to the upstream server or refused
+func handleDnsQuery(query []byte, address net.IP, network string) []byte {
+	question, err := parseDnsQuestion(query)
+	if err != nil {
+		return nil
+	}
+	if isDnsHost(question.Name) && question.Class == dnsClassIN {
+		log.Printf("DNS answering %s", question.Name)
+		if question.Type == dnsTypeA || question.Type == dnsTypeAny {
+			return dnsResponse(query, question, 0, address)
+		}
+		// Other record types for our hosts get an empty answer
+		return dnsResponse(query, question, 0, nil)
+	}
+	if DnsUpstream == "" {
+		return dnsResponse(query, question, dnsRcodeRef, nil)
+	}
+	response, err := forwardDns(query, network)
+	if err != nil {
+		log.Println("Failed to forward DNS query", question.Name, err)
+		return dnsResponse(query, question, dnsRcodeFail, nil)
+	}
+	return response
+}
+
+// forwardDns sends the query to the upstream server returning its response
+func forwardDns(query []byte, network string) ([]byte, error) {
+	conn, err := net.DialTimeout(network, DnsUpstream, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if network == "tcp" {
+		if err := writeDnsTcp(conn, query); err != nil {
+			return nil, err
+		}
+		return readDnsTcp(conn)
+	}
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+// dnsQuery creates a query with a single question
+func dnsQuery(id uint16, name string, questionType uint16) []byte {
+	out := make([]byte, 12)
+	binary.BigEndian.PutUint16(out, id)
+	out[2] = 0x01
+	binary.BigEndian.PutUint16(out[4:], 1)
+	for _, label := range strings.Split(name, ".") {
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	out = append(out, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint16(out[len(out)-4:], questionType)
+	binary.BigEndian.PutUint16(out[len(out)-2:], dnsClassIN)
+	return out
+}
+
+func TestParseDnsQuestion(t *testing.T) {
+	valid := dnsQuery(1, "GOSredirector.ea.com", dnsTypeA)
+	pointer := append(dnsQuery(1, "a", dnsTypeA)[:12], 0xC0, 0x0C, 0, 1, 0, 1)
+	noQuestions := append([]byte{}, valid...)
+	noQuestions[5] = 0
+	for _, test := range []struct {
+		name    string
+		message []byte
+		valid   bool
+	}{
+		{"valid", valid, true},
+		{"short header", valid[:11], false},
+		{"no questions", noQuestions, false},
+		{"name cut short", valid[:20], false},
+		{"label past the end", valid[:len(valid)-12], false},
+		{"type cut short", valid[:len(valid)-3], false},
+		{"compression pointer", pointer, false},
+		{"empty", nil, false},
+	} {
+		question, err := parseDnsQuestion(test.message)
+		if test.valid != (err == nil) {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if test.valid && (question.Name != "gosredirector.ea.com" || question.Type != dnsTypeA || question.End != len(valid)) {
+			t.Errorf("%s: unexpected question %+v", test.name, question)
+		}
+	}
+}
+
+// dnsAnswer reads the rcode and the A record answer of a response
+func dnsAnswer(t *testing.T, query []byte, response []byte) (byte, net.IP) {
+	t.Helper()
+	if len(response) < len(query) || !bytes.Equal(response[:2], query[:2]) || response[2]&0x80 == 0 {
+		t.Fatalf("invalid response %x", response)
+	}
+	rcode := response[3] & 0xF
+	if binary.BigEndian.Uint16(response[6:]) == 0 {
+		return rcode, nil
+	}
+	record := response[len(query):]
+	if len(record) != 16 || binary.BigEndian.Uint16(record) != 0xC00C || binary.BigEndian.Uint16(record[2:]) != dnsTypeA {
+		t.Fatalf("invalid answer %x", record)
+	}
+	return rcode, net.IP(record[12:])
+}
+
+func TestHandleDnsQuery(t *testing.T) {
+	upstream := DnsUpstream
+	DnsUpstream = ""
+	defer func() { DnsUpstream = upstream }()
+	address := net.IPv4(10, 0, 0, 2).To4()
+	for _, test := range []struct {
+		name   string
+		host   string
+		qtype  uint16
+		rcode  byte
+		answer net.IP
+	}{
+		{"A", "gosredirector.ea.com", dnsTypeA, 0, address},
+		{"ANY", "gosredirector.online.ea.com", dnsTypeAny, 0, address},
+		{"AAAA", "gosredirector.ea.com", dnsTypeAAAA, 0, nil},
+		{"other host refused", "example.com", dnsTypeA, dnsRcodeRef, nil},
+	} {
+		query := dnsQuery(0x1234, test.host, test.qtype)
+		response := handleDnsQuery(query, address, "udp")
+		rcode, answer := dnsAnswer(t, query, response)
+		if rcode != test.rcode || !answer.Equal(test.answer) {
+			t.Errorf("%s: rcode %d answer %v", test.name, rcode, answer)
+		}
+	}
+	if response := handleDnsQuery([]byte{1, 2, 3}, address, "udp"); response != nil {
+		t.Errorf("truncated query was answered %x", response)
+	}
+}
+
+func TestHandleDnsQueryForwards(t *testing.T) {
+	upstream := DnsUpstream
+	defer func() { DnsUpstream = upstream }()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	forwarded := net.IPv4(93, 184, 216, 34).To4()
+	go func() {
+		buf := make([]byte, 512)
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		question, err := parseDnsQuestion(buf[:n])
+		if err != nil {
+			return
+		}
+		_, _ = conn.WriteToUDP(dnsResponse(buf[:n], question, 0, forwarded), addr)
+	}()
+	DnsUpstream = conn.LocalAddr().String()
+
+	query := dnsQuery(7, "example.com", dnsTypeA)
+	if _, answer := dnsAnswer(t, query, handleDnsQuery(query, nil, "udp")); !answer.Equal(forwarded) {
+		t.Errorf("forwarded query answered with %v", answer)
+	}
+
+	// Upstream servers that can't be reached give a server failure
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	DnsUpstream = listener.Addr().String()
+	_ = listener.Close()
+	if rcode, _ := dnsAnswer(t, query, handleDnsQuery(query, nil, "tcp")); rcode != dnsRcodeFail {
+		t.Errorf("unreachable upstream gave rcode %d", rcode)
+	}
+}
+
+func TestDnsTcpFraming(t *testing.T) {
+	query := dnsQuery(9, "gosredirector.ea.com", dnsTypeA)
+	var buf bytes.Buffer
+	if err := writeDnsTcp(&buf, query); err != nil {
+		t.Fatal(err)
+	}
+	if binary.BigEndian.Uint16(buf.Bytes()) != uint16(len(query)) {
+		t.Errorf("unexpected length prefix %x", buf.Bytes()[:2])
+	}
+	read, err := readDnsTcp(&buf)
+	if err != nil || !bytes.Equal(read, query) {
+		t.Errorf("read %x %v", read, err)
+	}
+	if _, err := readDnsTcp(bytes.NewReader([]byte{0, 10, 1, 2})); err == nil {
+		t.Error("expected an error for a truncated message")
+	}
+}
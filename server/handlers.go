@@ -0,0 +1,52 @@
+package server
+
+import (
+	"log"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+// Handler handles a request packet received by a session
+type Handler func(session *Session, packet *blaze.Packet)
+
+// Handlers maps the component and command of a packet using the same keys
+// as blaze.CommandNames to the handler for that command
+var Handlers = map[uint32]Handler{}
+
+// RegisterHandlers adds the provided command handlers for a component
+func RegisterHandlers(component uint16, handlers map[uint16]Handler) {
+	for command, handler := range handlers {
+		Handlers[uint32(component)<<16|uint32(command)] = handler
+	}
+}
+
+// ValidateRequests logs the values of requests that don't match the schema
+// registered for the command
+var ValidateRequests = false
+
+// LogPackets logs the component and command of every packet received
+var LogPackets = false
+
+// logPacket logs the packet received on the named connection when
+// LogPackets is set
+func logPacket(connection string, packet *blaze.Packet) {
+	if LogPackets {
+		log.Println("Received", connection, packet.ToDescriptor())
+	}
+}
+
+func handlePacket(session *Session, packet *blaze.Packet) {
+	if ValidateRequests {
+		_, problems := packet.ReadValidated()
+		for _, problem := range problems {
+			log.Println("Invalid request", packet.ToDescriptor(), problem)
+		}
+	}
+	handler, exists := Handlers[uint32(packet.Component)<<16|uint32(packet.Command)]
+	if !exists {
+		log.Println("Unhandled packet", packet.ToDescriptor())
+		session.RespondEmpty(packet)
+		return
+	}
+	handler(session, packet)
+}
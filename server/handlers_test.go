@@ -0,0 +1,85 @@
+package server
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+)
+
+func TestUnhandledCommandRespondsEmpty(t *testing.T) {
+	loadTestData(t)
+	c := connect(t)
+	response, err := call(t, c, 0x7777, 0x1, blaze.NewInt64("VALU", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response) != 0 {
+		t.Errorf("unhandled command responded with %v", response)
+	}
+}
+
+func TestDispatchRoutesToHandler(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	c := loginAs(t, player)
+	if _, err := call(t, c, UtilComponent, 0xB, blaze.NewString("DATA", "20"), blaze.NewString("KEY", "Base")); err != nil {
+		t.Fatal(err)
+	}
+	response, err := call(t, c, UtilComponent, 0xA, blaze.NewString("KEY", "Base"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data := response.StringOr("DATA", ""); data != "20" {
+		t.Errorf("loaded setting %q", data)
+	}
+}
+
+func TestValidateRequests(t *testing.T) {
+	loadTestData(t)
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	ValidateRequests = true
+	defer func() {
+		log.SetOutput(os.Stderr)
+		ValidateRequests = false
+	}()
+
+	c := connect(t)
+	// userSettingsLoad requires KEY and is still handled when invalid
+	_, err := call(t, c, UtilComponent, 0xA, blaze.NewInt64("KEY", 1))
+	if code := errorCode(t, err); code != UtilErrAuthRequired {
+		t.Errorf("invalid request gave error 0x%X", code)
+	}
+	if !strings.Contains(out.String(), "Invalid request") || !strings.Contains(out.String(), "KEY") {
+		t.Errorf("invalid request wasn't logged:\n%s", out.String())
+	}
+}
+
+func TestSessionClosedOnDisconnect(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	c := loginAs(t, player)
+	session := OnlineSession(player.Id)
+	if session == nil {
+		t.Fatal("player isn't online")
+	}
+	_ = c.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for OnlineSession(player.Id) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("session wasn't closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	sessionsLock.RLock()
+	_, exists := sessions[session.Id]
+	sessionsLock.RUnlock()
+	if exists {
+		t.Error("closed session is still registered")
+	}
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jacobtread/gomes/game"
+)
+
+var HttpPort = 80
+
+// ContentDir is the directory within the data directory that the store
+// catalog, challenge definitions and images are served from
+const ContentDir = "content"
+
+// galaxyPath is the path that the Galaxy at War endpoints are served under
+const galaxyPath = "/wal/masseffect-gaw-pc/"
+
+// StartHttp starts the HTTP server that the client fetches content and Galaxy
+// at War ratings from
+func StartHttp() {
+	log.Println("GoMES HTTP Server Starting")
+	if err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", HttpPort), logRequests(newHttpMux())); err != nil {
+		log.Println("Failed to start HTTP server", err)
+	}
+}
+
+// newHttpMux creates the mux serving every HTTP endpoint
+func newHttpMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc(galaxyPath+"authentication/sharedTokenLogin", handleSharedTokenLogin)
+	mux.HandleFunc(galaxyPath+"galaxyatwar/getRatings/", handleGetRatings)
+	mux.HandleFunc(galaxyPath+"galaxyatwar/increaseRatings/", handleIncreaseRatings)
+	mux.HandleFunc("/store/purchase", handlePurchase)
+	registerAdmin(mux)
+	mux.Handle("/", http.FileServer(http.Dir(filepath.Join(DataDir, ContentDir))))
+	return mux
+}
+
+func logRequests(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Println("HTTP", r.Method, r.URL.String())
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// pathPlayer reads the player from the session key at the end of the
+// request path
+func pathPlayer(r *http.Request) *game.Player {
+	return TokenPlayer(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+}
+
+// handleSharedTokenLogin logs into Galaxy at War using the auth token the
+// client was given by getAuthToken and gives it a session key to use in
+// the paths of later requests
+func handleSharedTokenLogin(w http.ResponseWriter, r *http.Request) {
+	auth := r.URL.Query().Get("auth")
+	player := TokenPlayer(auth)
+	if player == nil {
+		http.Error(w, "invalid auth token", http.StatusForbidden)
+		return
+	}
+	now := time.Now().Unix()
+	writeXml(w, fmt.Sprintf(
+		"<fulllogin><canageup>0</canageup><legaldochost/><needslegaldoc>0</needslegaldoc>"+
+			"<pclogintoken>%[5]s</pclogintoken><privacypolicyuri/><sessioninfo>"+
+			"<blazeuserid>%[1]d</blazeuserid><isfirstlogin>0</isfirstlogin><sessionkey>%[6]s</sessionkey>"+
+			"<lastlogindatetime>%[2]d</lastlogindatetime><email>%[3]s</email><personadetails>"+
+			"<displayname>%[4]s</displayname><lastauthenticated>%[2]d</lastauthenticated><personaid>%[1]d</personaid>"+
+			"<status>UNKNOWN</status><extid>0</extid><exttype>BLAZE_EXTERNAL_REF_TYPE_UNKNOWN</exttype>"+
+			"</personadetails><userid>%[1]d</userid></sessioninfo><isoflegalcontactage>0</isoflegalcontactage>"+
+			"<toshost/><termsofserviceuri/><tosuri/></fulllogin>",
+		player.Id, now, xmlEscape(player.Email), xmlEscape(player.Name), xmlEscape(auth), IssueToken(player.Id),
+	))
+}
+
+func writeRatings(w http.ResponseWriter, ratings game.GalaxyRatings) {
+	writeXml(w, fmt.Sprintf(
+		"<galaxyatwargetratings><ratings><ratings>%d</ratings><ratings>%d</ratings><ratings>%d</ratings>"+
+			"<ratings>%d</ratings><ratings>%d</ratings></ratings><level>%d</level><assets>"+
+			strings.Repeat("<assets>0</assets>", 10)+
+			"</assets></galaxyatwargetratings>",
+		ratings.A, ratings.B, ratings.C, ratings.D, ratings.E, ratings.Level(),
+	))
+}
+
+func handleGetRatings(w http.ResponseWriter, r *http.Request) {
+	player := pathPlayer(r)
+	if player == nil {
+		http.Error(w, "invalid session key", http.StatusForbidden)
+		return
+	}
+	ratings, err := Galaxy.Get(player.Id)
+	if err != nil {
+		log.Println("Failed to save galaxy at war ratings", err)
+	}
+	writeRatings(w, ratings)
+}
+
+func handleIncreaseRatings(w http.ResponseWriter, r *http.Request) {
+	player := pathPlayer(r)
+	if player == nil {
+		http.Error(w, "invalid session key", http.StatusForbidden)
+		return
+	}
+	// The client sends its query in the form "rinc|a=1&b=2" so the
+	// prefix is stripped before parsing
+	query := r.URL.RawQuery
+	if index := strings.Index(query, "|"); index != -1 {
+		query = query[index+1:]
+	}
+	values, _ := parseQuery(query)
+	ratings, err := Galaxy.Increase(player.Id, game.GalaxyRatings{
+		A: values["a"],
+		B: values["b"],
+		C: values["c"],
+		D: values["d"],
+		E: values["e"],
+	})
+	if err != nil {
+		log.Println("Failed to save galaxy at war ratings", err)
+	}
+	writeRatings(w, ratings)
+}
+
+// parseQuery parses the integer values from a query string
+func parseQuery(query string) (map[string]int64, error) {
+	out := map[string]int64{}
+	for _, pair := range strings.Split(query, "&") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return out, err
+		}
+		out[key] = n
+	}
+	return out, nil
+}
+
+// requestToken reads the token from the bearer Authorization header or the
+// "auth" form value
+func requestToken(r *http.Request) string {
+	if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); token != "" {
+		return token
+	}
+	return r.PostFormValue("auth")
+}
+
+// handlePurchase buys the pack named by the "pack" form value for the player
+// the token of the request was issued to and lists the items received.
+// Items rolled in place of a maxed out guaranteed item name it in replaces
+func handlePurchase(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "purchases must be POST", http.StatusMethodNotAllowed)
+		return
+	}
+	player := TokenPlayer(requestToken(r))
+	if player == nil {
+		http.Error(w, "invalid auth token", http.StatusForbidden)
+		return
+	}
+	result, err := Packs.Purchase(Players, player, r.PostFormValue("pack"))
+	switch err {
+	case nil:
+	case game.ErrUnknownPack:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case game.ErrNotEnoughCredits, game.ErrNoItemsAvailable:
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+		return
+	default:
+		log.Println("Failed to purchase pack", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	items := strings.Builder{}
+	for _, item := range result.Items {
+		items.WriteString(fmt.Sprintf("<item><name>%s</name><count>%d</count>", xmlEscape(item.Name), item.Count))
+		if item.Replaces != "" {
+			items.WriteString(fmt.Sprintf("<replaces>%s</replaces>", xmlEscape(item.Replaces)))
+		}
+		items.WriteString("</item>")
+	}
+	writeXml(w, fmt.Sprintf(
+		"<purchase><pack>%s</pack><credits>%d</credits><items>%s</items></purchase>",
+		xmlEscape(result.Pack), result.Credits, items.String(),
+	))
+}
+
+var xmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;", "'", "&apos;")
+
+func xmlEscape(value string) string {
+	return xmlReplacer.Replace(value)
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jacobtread/gomes/game"
+)
+
+// get fetches the path from the server returning the status and body
+func get(t *testing.T, server *httptest.Server, path string) (int, string) {
+	t.Helper()
+	response, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.StatusCode, string(body)
+}
+
+func TestGalaxyAtWarTokens(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	c := loginAs(t, player)
+	httpServer := httptest.NewServer(newHttpMux())
+	defer httpServer.Close()
+
+	// Player IDs aren't accepted in place of tokens
+	if status, _ := get(t, httpServer, galaxyPath+"authentication/sharedTokenLogin?auth=1"); status != http.StatusForbidden {
+		t.Errorf("login with a player ID gave %d", status)
+	}
+	if status, _ := get(t, httpServer, galaxyPath+"galaxyatwar/getRatings/1"); status != http.StatusForbidden {
+		t.Errorf("ratings with a player ID gave %d", status)
+	}
+
+	response, err := call(t, c, AuthenticationComponent, 0x24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, body := get(t, httpServer, galaxyPath+"authentication/sharedTokenLogin?auth="+response.StringOr("AUTH", ""))
+	if status != http.StatusOK {
+		t.Fatalf("login gave %d %s", status, body)
+	}
+	var login struct {
+		SessionKey string `xml:"sessioninfo>sessionkey"`
+	}
+	if err := xml.Unmarshal([]byte(body), &login); err != nil {
+		t.Fatal(err)
+	}
+	status, body = get(t, httpServer, galaxyPath+"galaxyatwar/increaseRatings/"+login.SessionKey+"?rinc|a=100")
+	if status != http.StatusOK || !strings.Contains(body, "<ratings>5100</ratings>") {
+		t.Errorf("increase gave %d %s", status, body)
+	}
+}
+
+func TestPurchaseRequiresToken(t *testing.T) {
+	loadTestData(t)
+	player := createPlayer(t, "Shepard")
+	err := Players.Update(player, func(player *game.Player) {
+		base, _ := game.ParseBaseSettings(game.DefaultBaseSetting)
+		base.Credits = 5000
+		player.Settings[game.BaseSettingKey] = base.String()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(newHttpMux())
+	defer httpServer.Close()
+
+	if status, _ := get(t, httpServer, "/store/purchase?pack=RecruitPack&auth=1"); status != http.StatusMethodNotAllowed {
+		t.Errorf("GET purchase gave %d", status)
+	}
+	purchase := func(form url.Values) int {
+		response, err := http.PostForm(httpServer.URL+"/store/purchase", form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = response.Body.Close()
+		return response.StatusCode
+	}
+	if status := purchase(url.Values{"pack": {"RecruitPack"}, "auth": {"1"}}); status != http.StatusForbidden {
+		t.Errorf("purchase with a player ID gave %d", status)
+	}
+	if status := purchase(url.Values{"pack": {"RecruitPack"}, "auth": {IssueToken(player.Id)}}); status != http.StatusOK {
+		t.Errorf("purchase with a token gave %d", status)
+	}
+	var base game.BaseSettings
+	Players.View(player, func(player *game.Player) { base, _ = player.BaseSettings() })
+	if base.Credits != 0 {
+		t.Errorf("%d credits left after purchase", base.Credits)
+	}
+}
@@ -1,30 +1,30 @@
 package server
 
 import (
-	"bytes"
 	"crypto/tls"
 	"fmt"
 	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
 	"log"
 	"net"
+	"path/filepath"
 )
 
 func StartMain() {
 	log.Println("GoMES Main Server Starting")
 
-	x509KeyPair, err := tls.X509KeyPair(CertFile, KeyFile)
+	certificates, err := LoadCertificates(filepath.Join(DataDir, CertDir))
 	if err != nil {
-		log.Fatalln("Failed to acquire x509KeyPair", err)
+		log.Fatalln("Failed to load certificates", err)
 		return
 	}
 
-	config := &tls.Config{Certificates: []tls.Certificate{x509KeyPair}}
+	config := &tls.Config{GetCertificate: certificates.GetCertificate}
 
 	// Listen using tcp on all addresses with the game port
 	t, err := tls.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", GamePort), config)
 	if err != nil {
 		panic(err)
-		return
 	}
 	// Deferred closing of the listener
 	defer func(t net.Listener) { _ = t.Close() }(t)
@@ -37,19 +37,23 @@ func StartMain() {
 			log.Println("Failed to accept main connection", err)
 			continue
 		}
-		log.Println("Accepted redirect connection", c)
+		log.Println("Accepted main connection", c.RemoteAddr())
 		go handleConnectionMain(c)
 	}
 }
 
 func handleConnectionMain(conn net.Conn) {
-	buf := blaze.PacketBuff{Buffer: &bytes.Buffer{}}
-	bc := blaze.Connection{Conn: conn, PacketBuff: &buf}
+	session := newSession(blaze.NewConnection(conn))
+	defer session.close()
 
 	for {
-		_, _ = buf.ReadFrom(bc.Conn)
-		packet := buf.ReadAllPackets().Front().Value.(blaze.Packet)
-		fmt.Println(packet.ToDescriptor())
-
+		packet, err := session.conn.ReadPacket()
+		if err != nil {
+			log.Println("Main connection closed", session.Id, err)
+			return
+		}
+		logPacket("main", packet)
+		recordPacket(session.recorder, capture.ClientToServer, packet)
+		handlePacket(session, packet)
 	}
 }
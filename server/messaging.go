@@ -0,0 +1,246 @@
+package server
+
+import (
+	"log"
+	"sort"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+	"github.com/jacobtread/gomes/types"
+)
+
+const MessagingComponent uint16 = 0xF
+
+const NotifyMessage uint16 = 0x1
+
+// Messaging error codes
+const (
+	MsgErrAuthRequired   uint16 = 0x1
+	MsgErrInvalidTarget  uint16 = 0x2
+	MsgErrMessageBlocked uint16 = 0x3
+)
+
+// Motd is the message of the day sent to every player as a server message
+// when they fetch their messages. An empty Motd is not sent
+var Motd = "Welcome to GoMES"
+
+func init() {
+	RegisterHandlers(MessagingComponent, map[uint16]Handler{
+		0x01: handleSendMessage,
+		0x02: handleFetchMessages,
+		0x03: handlePurgeMessages,
+		0x04: handleTouchMessages,
+		0x05: handleGetMessages,
+	})
+}
+
+// objectId creates the object ID triple the client uses to identify a player
+func objectId(label string, playerId uint32) blaze.TripleTdf {
+	return blaze.NewTriple(label, types.Triple{A: int64(UserSessionsComponent), B: 1, C: int64(playerId)})
+}
+
+func attributesTdf(label string, attributes map[int64]string) blaze.Map[int64, string] {
+	keys := make([]int64, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = attributes[key]
+	}
+	return blaze.NewMap(label, keys, values)
+}
+
+func readAttributes(values blaze.Values) map[int64]string {
+	out := map[int64]string{}
+	attributes, ok := blaze.Find[blaze.Map[int64, string]](values, "ATTR")
+	if !ok {
+		return out
+	}
+	for i, key := range attributes.Keys {
+		out[key] = attributes.Values[i]
+	}
+	return out
+}
+
+// messageValues creates the values of the server message struct used by
+// NotifyMessage and getMessages
+func messageValues(message game.Message) []blaze.Tdf {
+	name := ""
+	if message.Sender != 0 {
+		if sender := Players.Get(message.Sender); sender != nil {
+			name = sender.Name
+		}
+	}
+	var flags int64
+	if message.Read {
+		flags = 0x1
+	}
+	return []blaze.Tdf{
+		blaze.NewInt64("FLAG", flags),
+		blaze.NewInt64("MGID", int64(message.Id)),
+		blaze.NewString("NAME", name),
+		blaze.NewStruct("PYLD",
+			attributesTdf("ATTR", message.Attributes),
+			blaze.NewInt64("FLAG", message.Flags),
+			blaze.NewInt64("STAT", message.Status),
+			blaze.NewInt64("TAG", message.Tag),
+			objectId("TARG", message.Recipient),
+			blaze.NewInt64("TYPE", message.Type),
+		),
+		objectId("SRCE", message.Sender),
+		blaze.NewInt64("TIME", message.Time),
+	}
+}
+
+// deliverMessage sends the message to its recipient if they are online
+func deliverMessage(message game.Message) {
+	if session := OnlineSession(message.Recipient); session != nil {
+		session.Notify(MessagingComponent, NotifyMessage, messageValues(message))
+	}
+}
+
+// SendMessage stores a message for each of the recipients and delivers it
+// to the ones that are online
+func SendMessage(message game.Message, recipients []uint32) error {
+	stored, err := Messages.Add(message, recipients)
+	for _, message := range stored {
+		deliverMessage(message)
+	}
+	return err
+}
+
+// BroadcastMessage sends a server message with the provided text to every
+// registered player
+func BroadcastMessage(text string) error {
+	var recipients []uint32
+	for _, player := range Players.All() {
+		recipients = append(recipients, player.Id)
+	}
+	return SendMessage(game.Message{
+		Attributes: map[int64]string{game.MessageBodyAttr: text},
+	}, recipients)
+}
+
+// readMessageFilter reads the message filter from the request content
+func readMessageFilter(values blaze.Values) game.MessageFilter {
+	filter := game.MessageFilter{
+		Id:   uint32(values.IntOr("MGID", 0)),
+		Type: values.IntOr("TYPE", 0),
+	}
+	if source, ok := values.Triple("SRCE"); ok {
+		filter.Sender = uint32(source.C)
+	}
+	return filter
+}
+
+func handleSendMessage(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, MsgErrAuthRequired)
+	if !ok {
+		return
+	}
+	content := packet.ReadContent()
+	target, ok := content.Triple("TARG")
+	if !ok || Players.Get(uint32(target.C)) == nil {
+		session.RespondError(packet, MsgErrInvalidTarget)
+		return
+	}
+	recipient := uint32(target.C)
+	if Associations.Contains(recipient, game.BlockList, player.Id) {
+		session.RespondError(packet, MsgErrMessageBlocked)
+		return
+	}
+	stored, err := Messages.Add(game.Message{
+		Sender:     player.Id,
+		Type:       content.IntOr("TYPE", 0),
+		Tag:        content.IntOr("TAG", 0),
+		Status:     content.IntOr("STAT", 0),
+		Flags:      content.IntOr("FLAG", 0),
+		Attributes: readAttributes(content),
+	}, []uint32{recipient})
+	if err != nil {
+		log.Println("Failed to save messages", err)
+	}
+	ids := make([]int64, 0, len(stored))
+	for _, message := range stored {
+		ids = append(ids, int64(message.Id))
+		deliverMessage(message)
+	}
+	var id int64
+	if len(ids) > 0 {
+		id = ids[0]
+	}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewInt64("MGID", id),
+		blaze.NewList("MIDS", ids),
+	})
+}
+
+func handleFetchMessages(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, MsgErrAuthRequired)
+	if !ok {
+		return
+	}
+	messages := Messages.Find(player.Id, readMessageFilter(packet.ReadContent()))
+	count := len(messages)
+	if Motd != "" {
+		count++
+	}
+	session.Respond(packet, []blaze.Tdf{blaze.NewInt64("MCNT", int64(count))})
+
+	// The messages themselves are delivered as notifications after the response
+	if Motd != "" {
+		session.Notify(MessagingComponent, NotifyMessage, messageValues(game.Message{
+			Recipient:  player.Id,
+			Attributes: map[int64]string{game.MessageBodyAttr: Motd},
+		}))
+	}
+	for _, message := range messages {
+		session.Notify(MessagingComponent, NotifyMessage, messageValues(message))
+	}
+}
+
+func handlePurgeMessages(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, MsgErrAuthRequired)
+	if !ok {
+		return
+	}
+	count, err := Messages.Purge(player.Id, readMessageFilter(packet.ReadContent()))
+	if err != nil {
+		log.Println("Failed to save messages", err)
+	}
+	session.Respond(packet, []blaze.Tdf{blaze.NewInt64("MCNT", int64(count))})
+}
+
+func handleTouchMessages(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, MsgErrAuthRequired)
+	if !ok {
+		return
+	}
+	count, err := Messages.MarkRead(player.Id, readMessageFilter(packet.ReadContent()))
+	if err != nil {
+		log.Println("Failed to save messages", err)
+	}
+	session.Respond(packet, []blaze.Tdf{blaze.NewInt64("MCNT", int64(count))})
+}
+
+func handleGetMessages(session *Session, packet *blaze.Packet) {
+	player, ok := requirePlayer(session, packet, MsgErrAuthRequired)
+	if !ok {
+		return
+	}
+	var ids []uint32
+	if mids, ok := blaze.Find[blaze.List[int64]](packet.ReadLabels("MIDS"), "MIDS"); ok {
+		for _, id := range mids.Values {
+			ids = append(ids, uint32(id))
+		}
+	}
+	var out []blaze.StructTdf
+	for _, message := range Messages.Get(player.Id, ids) {
+		out = append(out, blaze.NewStructStub(messageValues(message), false))
+	}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewList("MSGS", out),
+	})
+}
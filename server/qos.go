@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+var QosHttpPort = 17502
+var QosUdpPort = 17499
+
+// QosServiceId is the service ID sent in the QoS config
+const QosServiceId = 0x45410805
+
+// StartQos starts the HTTP and UDP QoS responders that clients probe to work
+// out their NAT type and external address
+func StartQos() {
+	log.Println("GoMES QoS Server Starting")
+	go startQosUdp()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/qos/qos", handleQos)
+	mux.HandleFunc("/qos/firewall", handleQosFirewall)
+	mux.HandleFunc("/qos/firetype", handleQosFiretype)
+	if err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", QosHttpPort), mux); err != nil {
+		log.Println("Failed to start QoS HTTP server", err)
+	}
+}
+
+// observedAddress returns the IPv4 address and port the request came from
+// encoded as the integers the client expects
+func observedAddress(r *http.Request) (uint32, int) {
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return 0, 0
+	}
+	p, _ := strconv.Atoi(port)
+	return ipToInt(net.ParseIP(host)), p
+}
+
+func ipToInt(ip net.IP) uint32 {
+	ip = ip.To4()
+	if ip == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(ip)
+}
+
+// localIp returns the local address the request was received on
+func localIp(r *http.Request) uint32 {
+	addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	if !ok {
+		return 0
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return 0
+	}
+	return ipToInt(net.ParseIP(host))
+}
+
+func writeXml(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write([]byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" + body))
+}
+
+func handleQos(w http.ResponseWriter, r *http.Request) {
+	writeXml(w, fmt.Sprintf(
+		"<qos><numprobes>0</numprobes><qosport>%d</qosport><probesize>0</probesize><qosip>%d</qosip><requestid>1</requestid><reqsecret>0</reqsecret></qos>",
+		QosUdpPort, localIp(r),
+	))
+}
+
+func handleQosFirewall(w http.ResponseWriter, r *http.Request) {
+	ip, port := observedAddress(r)
+	writeXml(w, fmt.Sprintf(
+		"<firewall><ips><ips>%d</ips></ips><numinterfaces>1</numinterfaces><ports><ports>%d</ports></ports><requestid>1</requestid><reqsecret>0</reqsecret></firewall>",
+		ip, port,
+	))
+}
+
+func handleQosFiretype(w http.ResponseWriter, r *http.Request) {
+	writeXml(w, "<firetype><firetype>2</firetype></firetype>")
+}
+
+// startQosUdp echos every probe back to its sender with the address and port
+// the probe was observed from appended as 4 and 2 big endian bytes
+func startQosUdp() {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: QosUdpPort})
+	if err != nil {
+		log.Println("Failed to start QoS UDP server", err)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			log.Println("Failed to read QoS probe", err)
+			continue
+		}
+		out := make([]byte, n+6)
+		copy(out, buf[:n])
+		binary.BigEndian.PutUint32(out[n:], ipToInt(addr.IP))
+		binary.BigEndian.PutUint16(out[n+4:], uint16(addr.Port))
+		_, _ = conn.WriteToUDP(out, addr)
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
+)
+
+// RecordDir is the directory that the packets of every connection are
+// recorded to. Nothing is recorded when it is empty
+var RecordDir = ""
+
+// RecordingExtension is the file extension of recordings
+const RecordingExtension = ".gmr"
+
+var redirectorConnections uint32
+
+// newRecorder creates the recording for a connection returning nil when
+// recording is disabled or the file couldn't be created
+func newRecorder(kind string, id uint32) *capture.Recorder {
+	if RecordDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(RecordDir, 0755); err != nil {
+		log.Println("Failed to create recording directory", err)
+		return nil
+	}
+	name := fmt.Sprintf("%s-%s-%d%s", time.Now().Format("20060102-150405"), kind, id, RecordingExtension)
+	recorder, err := capture.CreateRecording(filepath.Join(RecordDir, name))
+	if err != nil {
+		log.Println("Failed to create recording", err)
+		return nil
+	}
+	return recorder
+}
+
+// nextRedirectorId returns the ID used to name redirector recordings
+func nextRedirectorId() uint32 {
+	return atomic.AddUint32(&redirectorConnections, 1)
+}
+
+// record writes the packet to the recording if there is one
+func record(recorder *capture.Recorder, direction capture.Direction, data []byte) {
+	if recorder == nil {
+		return
+	}
+	if err := recorder.RecordRaw(direction, data); err != nil {
+		log.Println("Failed to record packet", err)
+	}
+}
+
+// recordPacket writes a received packet to the recording if there is one
+func recordPacket(recorder *capture.Recorder, direction capture.Direction, packet *blaze.Packet) {
+	if recorder == nil {
+		return
+	}
+	if err := recorder.Record(direction, *packet); err != nil {
+		log.Println("Failed to record packet", err)
+	}
+}
@@ -1,22 +1,55 @@
 package server
 
 import (
-	"bytes"
+	"crypto/tls"
 	"fmt"
 	"github.com/jacobtread/gomes/blaze"
-	"io/ioutil"
+	"github.com/jacobtread/gomes/capture"
 	"log"
 	"net"
+	"path/filepath"
 )
 
+const RedirectorComponent uint16 = 0x5
+const getServerInstance uint16 = 0x1
+
+// MainHost is the host name or address of the main server that the redirector
+// sends clients to. When empty the address the client used to reach the
+// redirector is used which works when both run on the same machine
+var MainHost = ""
+
+// MainPort is the port of the main server sent to clients
+var MainPort = 0
+
+// RedirectorTLS makes the redirector accept TLS connections using the
+// certificates in the data directory instead of plain TCP
+var RedirectorTLS = false
+
+// listenRedirector listens on the redirector port with TLS when
+// RedirectorTLS is set
+func listenRedirector() (net.Listener, error) {
+	address := fmt.Sprintf("0.0.0.0:%d", RedirectorPort)
+	if !RedirectorTLS {
+		return net.Listen("tcp", address)
+	}
+	certificates, err := LoadCertificates(filepath.Join(DataDir, CertDir))
+	if err != nil {
+		return nil, err
+	}
+	return tls.Listen("tcp", address, &tls.Config{GetCertificate: certificates.GetCertificate})
+}
+
 func StartRedirector() {
 	log.Println("GoMES Redirector Starting")
 
-	// Listen using tcp on all addresses with the game port
-	t, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", RedirectorPort))
+	if DnsEnabled {
+		go StartDns()
+	}
+
+	// Listen on all addresses with the redirector port
+	t, err := listenRedirector()
 	if err != nil {
-		panic(err)
-		return
+		log.Fatalln("Failed to start redirector", err)
 	}
 	// Deferred closing of the listener
 	defer func(t net.Listener) { _ = t.Close() }(t)
@@ -35,19 +68,61 @@ func StartRedirector() {
 }
 
 func handleConnectionRedirect(conn net.Conn) {
-	buf := blaze.PacketBuff{}
-	bc := blaze.Connection{Conn: conn, PacketBuff: &buf}
+	bc := blaze.NewConnection(conn)
+	recorder := newRecorder("redirector", nextRedirectorId())
+	defer func() {
+		_ = bc.Close()
+		if recorder != nil {
+			_ = recorder.Close()
+		}
+	}()
 	for {
-		b, err := ioutil.ReadAll(conn)
+		packet, err := bc.ReadPacket()
 		if err != nil {
-			panic(err)
+			return
 		}
-		bc.Buffer = bytes.NewBuffer(b)
-		if buf.Len() > 0 {
-			packet := buf.ReadPacket()
-			fmt.Println(packet.ToDescriptor())
-
+		logPacket("redirector", packet)
+		recordPacket(recorder, capture.ClientToServer, packet)
+		var content []blaze.Tdf
+		if packet.Component == RedirectorComponent && packet.Command == getServerInstance {
+			content = serverInstanceContent(conn)
+		}
+		buf := blaze.PacketBuff{}
+		data := buf.EncodePacket(packet.Component, packet.Command, 0, blaze.ResponseType, packet.Id, content)
+		record(recorder, capture.ServerToClient, data)
+		if _, err := conn.Write(data); err != nil {
 			return
 		}
 	}
 }
+
+// serverInstanceContent creates the getServerInstance response pointing the
+// client at the main server
+func serverInstanceContent(conn net.Conn) []blaze.Tdf {
+	host := MainHost
+	if host == "" {
+		host, _, _ = net.SplitHostPort(conn.LocalAddr().String())
+	}
+	var ip int64
+	if addrs, err := net.LookupIP(host); err == nil {
+		for _, addr := range addrs {
+			if addr.To4() != nil {
+				ip = int64(ipToInt(addr))
+				break
+			}
+		}
+	}
+	port := MainPort
+	if port == 0 {
+		port = GamePort
+	}
+	return []blaze.Tdf{
+		blaze.NewUnion("ADDR", 0, blaze.NewStruct("VALU",
+			blaze.NewString("HOST", host),
+			blaze.NewInt64("IP", ip),
+			blaze.NewInt64("PORT", int64(port)),
+		)),
+		blaze.NewInt64("SECU", 1),
+		blaze.NewInt64("XDNS", 0),
+	}
+}
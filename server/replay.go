@@ -0,0 +1,171 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
+)
+
+// ReplayOptions change how a recording is replayed
+type ReplayOptions struct {
+	// Redirector replays against the redirector instead of the main server
+	Redirector bool
+	// Timeout is how long to wait for the responses to each request
+	Timeout time.Duration
+	// Ignore are the labels of values that aren't compared
+	Ignore []string
+}
+
+// ReplayResult is the outcome of replaying a recording
+type ReplayResult struct {
+	Requests    int
+	Differences []string
+}
+
+// quietPeriod is how long to wait for unexpected extra packets after all
+// the expected packets for a request have arrived
+const quietPeriod = 50 * time.Millisecond
+
+// Replay sends the client packets of the recording to an in-process server
+// comparing the packets it sends back with the recorded ones. The server
+// data must already be loaded
+func Replay(records []capture.Record, options ReplayOptions) (*ReplayResult, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = 5 * time.Second
+	}
+	client, serverConn := net.Pipe()
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		if options.Redirector {
+			handleConnectionRedirect(serverConn)
+		} else {
+			handleConnectionMain(serverConn)
+		}
+	}()
+	conn := blaze.NewConnection(client)
+	// The server side is closed before returning so that nothing is left
+	// running against the server data
+	defer func() {
+		_ = conn.Close()
+		<-served
+	}()
+
+	received := make(chan *blaze.Packet, 64)
+	go func() {
+		defer close(received)
+		for {
+			packet, err := conn.ReadPacket()
+			if err != nil {
+				return
+			}
+			received <- packet
+		}
+	}()
+
+	result := &ReplayResult{}
+	for i := 0; i < len(records); i++ {
+		if records[i].Direction != capture.ClientToServer {
+			continue
+		}
+		request := records[i].Packet
+		var expected []blaze.Packet
+		for i+1 < len(records) && records[i+1].Direction == capture.ServerToClient {
+			i++
+			expected = append(expected, records[i].Packet)
+		}
+		result.Requests++
+		if err := conn.WritePacket(&request); err != nil {
+			return result, fmt.Errorf("sending %s: %w", request.ToDescriptor(), err)
+		}
+		actual := collectResponses(received, request, len(expected), options.Timeout)
+		prefix := fmt.Sprintf("#%d %s", result.Requests, request.ToDescriptor())
+		result.Differences = append(result.Differences, comparePackets(prefix, expected, actual, options.Ignore)...)
+	}
+	return result, nil
+}
+
+// collectResponses reads packets until the response to the request and at
+// least the expected number of packets have arrived then waits briefly for
+// any extra packets
+func collectResponses(received <-chan *blaze.Packet, request blaze.Packet, expected int, timeout time.Duration) []blaze.Packet {
+	var out []blaze.Packet
+	responded := request.QType&0xF000 != blaze.RequestType
+	deadline := time.After(timeout)
+	for {
+		done := responded && len(out) >= expected
+		var quiet <-chan time.Time
+		if done {
+			quiet = time.After(quietPeriod)
+		}
+		select {
+		case packet, ok := <-received:
+			if !ok {
+				return out
+			}
+			out = append(out, *packet)
+			qType := packet.QType & 0xF000
+			if packet.Id == request.Id && (qType == blaze.ResponseType || qType == blaze.ErrorType) {
+				responded = true
+			}
+		case <-quiet:
+			return out
+		case <-deadline:
+			return out
+		}
+	}
+}
+
+// packetKey identifies which recorded packet a received packet matches
+func packetKey(packet blaze.Packet) string {
+	qType := packet.QType & 0xF000
+	if qType == blaze.NotificationType {
+		return fmt.Sprintf("%d/%d/%d", qType, packet.Component, packet.Command)
+	}
+	return fmt.Sprintf("%d/%d/%d/%d", qType, packet.Component, packet.Command, packet.Id)
+}
+
+// comparePackets pairs each expected packet with the first unused actual
+// packet with the same key describing any differences between them
+func comparePackets(prefix string, expected []blaze.Packet, actual []blaze.Packet, ignore []string) []string {
+	var out []string
+	used := make([]bool, len(actual))
+	for _, packet := range expected {
+		match := -1
+		key := packetKey(packet)
+		for j := range actual {
+			if !used[j] && packetKey(actual[j]) == key {
+				match = j
+				break
+			}
+		}
+		if match < 0 {
+			out = append(out, fmt.Sprintf("%s: missing %s", prefix, describePacket(packet)))
+			continue
+		}
+		used[match] = true
+		for _, difference := range capture.DiffPackets(packet, actual[match], ignore) {
+			out = append(out, fmt.Sprintf("%s: %s %s", prefix, describePacket(packet), difference))
+		}
+	}
+	for j, packet := range actual {
+		if !used[j] {
+			out = append(out, fmt.Sprintf("%s: unexpected %s", prefix, describePacket(packet)))
+		}
+	}
+	return out
+}
+
+func describePacket(packet blaze.Packet) string {
+	switch packet.QType & 0xF000 {
+	case blaze.NotificationType:
+		return "notification " + packet.ToDescriptor()
+	case blaze.ErrorType:
+		return fmt.Sprintf("error %s id=%d", packet.ToDescriptor(), packet.Id)
+	default:
+		return fmt.Sprintf("response %s id=%d", packet.ToDescriptor(), packet.Id)
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
+	"github.com/jacobtread/gomes/game"
+)
+
+// recordSession records a session that logs in, saves a setting and adds a
+// friend returning the recorded packets
+func recordSession(t *testing.T) []capture.Record {
+	t.Helper()
+	defer func(dir string) { RecordDir = dir }(RecordDir)
+	RecordDir = t.TempDir()
+
+	// The subtest waits for the session to close which closes the recording
+	t.Run("record", func(t *testing.T) {
+		loadTestData(t)
+		player := createPlayer(t, "Shepard")
+		friend := createPlayer(t, "Garrus")
+		c := loginAs(t, player)
+		if _, err := call(t, c, UtilComponent, 0xB, blaze.NewString("DATA", "20"), blaze.NewString("KEY", "Base")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := call(t, c, AssociationComponent, 0x1, friendListId(), usersTdf(friend)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := call(t, c, UtilComponent, 0xA, blaze.NewString("KEY", "Base")); err != nil {
+			t.Fatal(err)
+		}
+	})
+	paths, _ := filepath.Glob(filepath.Join(RecordDir, "*-main-*"+RecordingExtension))
+	if len(paths) != 1 {
+		t.Fatalf("found recordings %v", paths)
+	}
+	file, err := os.Open(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := capture.ReadRecording(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 8 {
+		t.Fatalf("recorded %d packets", len(records))
+	}
+	return records
+}
+
+// replayData loads fresh data with the players the recorded session used
+func replayData(t *testing.T) {
+	t.Helper()
+	loadTestData(t)
+	createPlayer(t, "Shepard")
+	createPlayer(t, "Garrus")
+}
+
+func TestReplayRecordedSession(t *testing.T) {
+	records := recordSession(t)
+	replayData(t)
+	result, err := Replay(records, ReplayOptions{Ignore: capture.DefaultIgnoredLabels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Requests != 4 {
+		t.Errorf("replayed %d requests", result.Requests)
+	}
+	for _, difference := range result.Differences {
+		t.Error(difference)
+	}
+}
+
+func TestReplayReportsDifferences(t *testing.T) {
+	records := recordSession(t)
+	// The friend is already in the list so adding them again adds nobody
+	replayData(t)
+	shepard := Players.ByName("Shepard")
+	garrus := Players.ByName("Garrus")
+	if _, _, err := Associations.Add(shepard.Id, game.ListConfigs[0], []uint32{garrus.Id}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Replay(records, ReplayOptions{Ignore: capture.DefaultIgnoredLabels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Differences) == 0 {
+		t.Fatal("no differences were reported")
+	}
+	for _, difference := range result.Differences {
+		if !strings.HasPrefix(difference, "#3 ") {
+			t.Errorf("difference outside the add request: %s", difference)
+		}
+	}
+}
+
+func TestReplayRedirector(t *testing.T) {
+	request := blaze.NewPacket(RedirectorComponent, getServerInstance, 0, blaze.RequestType, 1, nil)
+	response := blaze.NewPacket(RedirectorComponent, getServerInstance, 0, blaze.ResponseType, 1, nil)
+	records := []capture.Record{
+		{Direction: capture.ClientToServer, Packet: request},
+		{Direction: capture.ServerToClient, Packet: response},
+	}
+	result, err := Replay(records, ReplayOptions{Redirector: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The recorded response is empty so every value the redirector sends
+	// is a difference
+	if result.Requests != 1 || len(result.Differences) == 0 {
+		t.Errorf("replayed %d requests with differences %v", result.Requests, result.Differences)
+	}
+}
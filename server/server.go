@@ -1,6 +1,12 @@
 package server
 
-import _ "embed"
+import (
+	_ "embed"
+	"log"
+
+	"github.com/jacobtread/gomes/game"
+	"github.com/jacobtread/gomes/store"
+)
 
 //go:embed cert/cert.pem
 var CertFile []byte
@@ -8,5 +14,61 @@ var CertFile []byte
 //go:embed cert/key.pem
 var KeyFile []byte
 
-const RedirectorPort = 42127
-const GamePort = 14219
+var RedirectorPort = 42127
+var GamePort = 14219
+
+// DataDir is the directory the persisted server data is stored in
+var DataDir = "data"
+
+var (
+	Store        *store.Store
+	Players      *game.Players
+	Associations *game.AssociationLists
+	Messages     *game.Messages
+	Galaxy       *game.GalaxyAtWar
+	Packs        *game.PackStore
+)
+
+// PackSeed is the seed used when rolling pack items. Zero uses the time the
+// server started
+var PackSeed int64 = 0
+
+// LoadData opens the data store in the provided directory and loads all
+// the persisted collections from it
+func LoadData(dir string) error {
+	s, err := store.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := game.CheckSchema(s); err != nil {
+		return err
+	}
+	players, err := game.LoadPlayers(s)
+	if err != nil {
+		return err
+	}
+	associations, err := game.LoadAssociationLists(s)
+	if err != nil {
+		return err
+	}
+	messages, err := game.LoadMessages(s)
+	if err != nil {
+		return err
+	}
+	galaxy, err := game.LoadGalaxyAtWar(s)
+	if err != nil {
+		return err
+	}
+	packs, err := game.LoadPackStore(s, PackSeed)
+	if err != nil {
+		return err
+	}
+	Store = s
+	Players = players
+	Associations = associations
+	Messages = messages
+	Galaxy = galaxy
+	Packs = packs
+	log.Printf("Loaded %d players from %s", len(players.Players), dir)
+	return nil
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/blaze/client"
+	"github.com/jacobtread/gomes/game"
+)
+
+func TestMain(m *testing.M) {
+	// Hashing with the default iterations makes every login slow
+	game.PasswordIterations = 1000
+	os.Exit(m.Run())
+}
+
+// loadTestData loads empty collections from a temporary data directory
+func loadTestData(t *testing.T) {
+	t.Helper()
+	if err := LoadData(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// connect starts a main server session over a pipe and returns a client
+// connected to it. The session is closed when the test ends
+func connect(t *testing.T) *client.Client {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		handleConnectionMain(serverConn)
+		close(done)
+	}()
+	c := client.New(clientConn, &client.Options{PingInterval: -1})
+	t.Cleanup(func() {
+		_ = c.Close()
+		<-done
+	})
+	return c
+}
+
+func call(t *testing.T, c *client.Client, component uint16, command uint16, content ...blaze.Tdf) (blaze.Values, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	packet, err := c.Call(ctx, component, command, content)
+	if err != nil {
+		return nil, err
+	}
+	return packet.ReadContent(), nil
+}
+
+// errorCode returns the code of an error response or fails the test when
+// the error isn't an error response
+func errorCode(t *testing.T, err error) uint16 {
+	t.Helper()
+	var response *client.ResponseError
+	if !errors.As(err, &response) {
+		t.Fatalf("expected an error response got %v", err)
+	}
+	return response.Code
+}
+
+// createPlayer registers a player named after the provided name
+func createPlayer(t *testing.T, name string) *game.Player {
+	t.Helper()
+	player, err := Players.Create(name, name+"@example.com", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return player
+}
+
+// loginAs connects a new client and logs it in as the provided player
+func loginAs(t *testing.T, player *game.Player) *client.Client {
+	t.Helper()
+	c := connect(t)
+	_, err := call(t, c, AuthenticationComponent, 0x28,
+		blaze.NewString("MAIL", player.Email),
+		blaze.NewString("PASS", "password"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// receive waits for the next notification of the subscription
+func receive(t *testing.T, subscription *client.Subscription) blaze.Values {
+	t.Helper()
+	select {
+	case packet := <-subscription.C:
+		return packet.ReadContent()
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+		return nil
+	}
+}
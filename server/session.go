@@ -0,0 +1,197 @@
+package server
+
+import (
+	"log"
+	"sync"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/capture"
+	"github.com/jacobtread/gomes/game"
+)
+
+// Session is a single client connected to the main server
+type Session struct {
+	Id uint32
+
+	conn      *blaze.Connection
+	writeLock sync.Mutex
+	recorder  *capture.Recorder
+
+	lock sync.Mutex
+	// player is the player the session is authenticated as or nil
+	player *game.Player
+	// subscriptions are the association lists the session wants to
+	// receive updates for
+	subscriptions map[game.ListType]bool
+}
+
+var (
+	sessions      = map[uint32]*Session{}
+	sessionsLock  sync.RWMutex
+	nextSessionId uint32 = 1
+)
+
+func newSession(conn *blaze.Connection) *Session {
+	sessionsLock.Lock()
+	defer sessionsLock.Unlock()
+	session := &Session{
+		Id:            nextSessionId,
+		conn:          conn,
+		subscriptions: map[game.ListType]bool{},
+	}
+	nextSessionId++
+	sessions[session.Id] = session
+	session.recorder = newRecorder("main", session.Id)
+	return session
+}
+
+// close removes the session from the active sessions and closes the
+// underlying connection
+func (s *Session) close() {
+	sessionsLock.Lock()
+	delete(sessions, s.Id)
+	sessionsLock.Unlock()
+	if player := s.Player(); player != nil {
+		notifyPresence(player, false)
+	}
+	_ = s.conn.Close()
+	if s.recorder != nil {
+		_ = s.recorder.Close()
+	}
+}
+
+// OnlineSession returns the session that the player with the provided ID is
+// authenticated on or nil if the player is not online
+func OnlineSession(playerId uint32) *Session {
+	sessionsLock.RLock()
+	defer sessionsLock.RUnlock()
+	for _, session := range sessions {
+		if player := session.Player(); player != nil && player.Id == playerId {
+			return session
+		}
+	}
+	return nil
+}
+
+// playerSessions returns every session the player with the provided ID is
+// authenticated on
+func playerSessions(playerId uint32) []*Session {
+	sessionsLock.RLock()
+	defer sessionsLock.RUnlock()
+	var out []*Session
+	for _, session := range sessions {
+		if player := session.Player(); player != nil && player.Id == playerId {
+			out = append(out, session)
+		}
+	}
+	return out
+}
+
+// Disconnect logs the session out and closes its connection
+func (s *Session) Disconnect() {
+	s.ClearPlayer()
+	_ = s.conn.Close()
+}
+
+// Player returns the player the session is authenticated as or nil when
+// the session hasn't logged in
+func (s *Session) Player() *game.Player {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.player
+}
+
+// SetPlayer associates the session with the provided player once it has
+// been authenticated and lets anyone watching the player know they are online.
+// Banned players are rejected
+func (s *Session) SetPlayer(player *game.Player) error {
+	banned := false
+	Players.View(player, func(player *game.Player) { banned = player.Banned })
+	if banned {
+		return game.ErrPlayerBanned
+	}
+	s.lock.Lock()
+	previous := s.player
+	s.player = player
+	s.lock.Unlock()
+	if previous != nil && previous != player {
+		notifyPresence(previous, false)
+	}
+	if previous != player {
+		notifyPresence(player, true)
+	}
+	return nil
+}
+
+// requirePlayer returns the player the session is authenticated as or
+// responds with the provided error code when the session has not been
+// authenticated
+func requirePlayer(session *Session, packet *blaze.Packet, code uint16) (*game.Player, bool) {
+	player := session.Player()
+	if player == nil {
+		session.RespondError(packet, code)
+		return nil, false
+	}
+	return player, true
+}
+
+// ClearPlayer logs the session out letting anyone watching the player
+// know they are offline
+func (s *Session) ClearPlayer() {
+	s.lock.Lock()
+	previous := s.player
+	s.player = nil
+	s.lock.Unlock()
+	if previous != nil {
+		notifyPresence(previous, false)
+	}
+}
+
+// Subscribed checks whether the session is subscribed to updates for the
+// association list with the provided type
+func (s *Session) Subscribed(t game.ListType) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.subscriptions[t]
+}
+
+// SetSubscribed changes whether the session is subscribed to the association
+// list with the provided type
+func (s *Session) SetSubscribed(t game.ListType, subscribed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.subscriptions[t] = subscribed
+}
+
+func (s *Session) send(comp uint16, cmd uint16, err uint16, qType uint16, id uint16, content []blaze.Tdf) {
+	encoder := blaze.AcquireEncoder()
+	defer encoder.Release()
+	data := encoder.Packet(comp, cmd, err, qType, id, content)
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	record(s.recorder, capture.ServerToClient, data)
+	if _, e := s.conn.Write(data); e != nil {
+		log.Println("Failed to write packet to session", s.Id, e)
+	}
+}
+
+// Respond sends a response to the provided request packet
+func (s *Session) Respond(packet *blaze.Packet, content []blaze.Tdf) {
+	s.send(packet.Component, packet.Command, 0, blaze.ResponseType, packet.Id, content)
+}
+
+// RespondEmpty sends a response with no content to the provided request packet
+func (s *Session) RespondEmpty(packet *blaze.Packet) {
+	s.Respond(packet, nil)
+}
+
+// RespondError sends an error response with the provided error code to
+// the provided request packet
+func (s *Session) RespondError(packet *blaze.Packet, code uint16) {
+	s.send(packet.Component, packet.Command, code, blaze.ErrorType, packet.Id, nil)
+}
+
+// Notify sends a notification packet to the session
+func (s *Session) Notify(comp uint16, cmd uint16, content []blaze.Tdf) {
+	s.send(comp, cmd, 0, blaze.NotificationType, 0, content)
+}
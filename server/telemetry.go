@@ -0,0 +1,216 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var TelemetryPort = 9988
+var TickerPort = 8999
+
+// TelemetryKey is the key given to clients to encode their telemetry with
+const TelemetryKey = "The truth is back in style."
+
+// TelemetryLogSize is the size in bytes the telemetry log can reach before it
+// is rotated and TelemetryLogCount is the number of old logs that are kept
+const TelemetryLogSize = 5 * 1024 * 1024
+const TelemetryLogCount = 3
+
+// telemetryRecords buffers decoded records so that slow disk writes never
+// hold up a client connection. Records are dropped when it is full
+var telemetryRecords = make(chan string, 256)
+
+func StartTelemetry() {
+	log.Println("GoMES Telemetry Server Starting")
+
+	t, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", TelemetryPort))
+	if err != nil {
+		log.Println("Failed to start telemetry server", err)
+		return
+	}
+	defer func(t net.Listener) { _ = t.Close() }(t)
+
+	writer := &rotatingWriter{Path: filepath.Join(DataDir, "telemetry.log"), MaxSize: TelemetryLogSize, Count: TelemetryLogCount}
+	go writeTelemetry(writer)
+
+	for {
+		c, err := t.Accept()
+		if err != nil {
+			log.Println("Failed to accept telemetry connection", err)
+			continue
+		}
+		go handleConnectionTelemetry(c)
+	}
+}
+
+func writeTelemetry(writer *rotatingWriter) {
+	for record := range telemetryRecords {
+		if _, err := writer.Write([]byte(record)); err != nil {
+			log.Println("Failed to write telemetry", err)
+		}
+	}
+}
+
+func handleConnectionTelemetry(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	addr := conn.RemoteAddr().String()
+	for {
+		message, err := readTelemetryMessage(conn)
+		if err != nil {
+			return
+		}
+		record := fmt.Sprintf("%s %s %s\n", time.Now().Format(time.RFC3339), addr, formatTelemetry(message))
+		select {
+		case telemetryRecords <- record:
+		default:
+		}
+	}
+}
+
+// readTelemetryMessage reads a single telemetry message. Messages start with
+// a 12 byte header where the last two bytes are the length of the message
+// including the header
+func readTelemetryMessage(r io.Reader) ([]byte, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint16(header[10:]))
+	if length < len(header) {
+		return nil, fmt.Errorf("invalid telemetry message length %d", length)
+	}
+	body := make([]byte, length-len(header))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// formatTelemetry turns the key value lines of a telemetry message into a
+// single line decoding any TLM3 encoded values
+func formatTelemetry(message []byte) string {
+	var parts []string
+	for _, line := range bytes.Split(message, []byte{'\n'}) {
+		line = bytes.TrimRight(line, "\x00\r")
+		if len(line) == 0 {
+			continue
+		}
+		key, value, found := strings.Cut(string(line), "=")
+		if !found {
+			parts = append(parts, fmt.Sprintf("%q", line))
+			continue
+		}
+		if key == "TLM3" {
+			value = decodeTLM3(value)
+		}
+		parts = append(parts, fmt.Sprintf("%s=%q", key, value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// decodeTLM3 decodes a TLM3 value which is the text after the first '-'
+// xored with the telemetry key
+func decodeTLM3(value string) string {
+	_, data, found := strings.Cut(value, "-")
+	if !found {
+		return value
+	}
+	key := []byte(TelemetryKey)
+	out := make([]byte, len(data))
+	for i := 0; i < len(data); i++ {
+		k := key[i%len(key)]
+		v := data[i] ^ k
+		if v > 0x80 {
+			v = k ^ (data[i] - 0x80)
+		}
+		out[i] = v
+	}
+	return string(out)
+}
+
+// rotatingWriter appends to a log file moving it to a numbered backup once
+// it grows beyond the max size
+type rotatingWriter struct {
+	Path    string
+	MaxSize int64
+	Count   int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+func (w *rotatingWriter) Write(data []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.file == nil || w.size+int64(len(data)) > w.MaxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(data)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) rotate() error {
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+		for i := w.Count - 1; i > 0; i-- {
+			_ = os.Rename(fmt.Sprintf("%s.%d", w.Path, i), fmt.Sprintf("%s.%d", w.Path, i+1))
+		}
+		_ = os.Rename(w.Path, w.Path+".1")
+	}
+	if err := os.MkdirAll(filepath.Dir(w.Path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(w.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+func StartTicker() {
+	log.Println("GoMES Ticker Server Starting")
+
+	t, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", TickerPort))
+	if err != nil {
+		log.Println("Failed to start ticker server", err)
+		return
+	}
+	defer func(t net.Listener) { _ = t.Close() }(t)
+
+	for {
+		c, err := t.Accept()
+		if err != nil {
+			log.Println("Failed to accept ticker connection", err)
+			continue
+		}
+		go handleConnectionTicker(c)
+	}
+}
+
+// handleConnectionTicker keeps the ticker connection open discarding anything
+// the client sends, there is no ticker content to send back
+func handleConnectionTicker(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_, _ = io.Copy(io.Discard, conn)
+}
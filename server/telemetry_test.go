@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeTLM3(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		value string
+		out   string
+	}{
+		// Each byte is xored with the telemetry key
+		{"xored", "AAAA-042d377349211d1118411b170f2f28275611", "PERS=Shepard/MID=1"},
+		// Bytes above 0x80 have 0x80 taken off before the xor
+		{"high", "AAAA-84adb7f3c9a19d9198c19b978fafa8a7d691", "PERS=Shepard/MID=1"},
+	} {
+		prefix, data, _ := bytes.Cut([]byte(test.value), []byte("-"))
+		raw, err := hex.DecodeString(string(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out := decodeTLM3(string(prefix) + "-" + string(raw)); out != test.out {
+			t.Errorf("%s: decoded %q", test.name, out)
+		}
+	}
+	if out := decodeTLM3("no separator"); out != "no separator" {
+		t.Errorf("value without a separator decoded as %q", out)
+	}
+}
+
+// telemetryMessage adds the 12 byte header to a telemetry message body
+func telemetryMessage(body string) []byte {
+	out := make([]byte, 12, 12+len(body))
+	binary.BigEndian.PutUint16(out[10:], uint16(12+len(body)))
+	return append(out, body...)
+}
+
+func TestReadTelemetryMessage(t *testing.T) {
+	raw, _ := hex.DecodeString("042d377349211d1118411b170f2f28275611")
+	stream := append(telemetryMessage("AUTH=1\nTLM3=AAAA-"+string(raw)+"\x00"), telemetryMessage("BOOT")...)
+	r := bytes.NewReader(stream)
+	message, err := readTelemetryMessage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := formatTelemetry(message); text != `AUTH="1" TLM3="PERS=Shepard/MID=1"` {
+		t.Errorf("formatted as %s", text)
+	}
+	message, err = readTelemetryMessage(r)
+	if err != nil || formatTelemetry(message) != `"BOOT"` {
+		t.Errorf("second message %q %v", message, err)
+	}
+	if _, err := readTelemetryMessage(r); err == nil {
+		t.Error("expected an error at the end of the stream")
+	}
+
+	short := telemetryMessage("")
+	binary.BigEndian.PutUint16(short[10:], 4)
+	if _, err := readTelemetryMessage(bytes.NewReader(short)); err == nil {
+		t.Error("expected an error for a length shorter than the header")
+	}
+	if _, err := readTelemetryMessage(bytes.NewReader(telemetryMessage("cut short")[:15])); err == nil {
+		t.Error("expected an error for a truncated body")
+	}
+}
+
+func TestRotatingWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "telemetry.log")
+	writer := &rotatingWriter{Path: path, MaxSize: 10, Count: 2}
+	defer func() { _ = writer.file.Close() }()
+	for _, record := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := writer.Write([]byte(record)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Each record fills the file past half its size so every write rotates
+	// and only Count old logs are kept
+	for name, expected := range map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	} {
+		data, err := os.ReadFile(name)
+		if err != nil || string(data) != expected {
+			t.Errorf("%s holds %q %v", filepath.Base(name), data, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("more than %d old logs were kept", writer.Count)
+	}
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jacobtread/gomes/blaze"
+	"github.com/jacobtread/gomes/game"
+)
+
+const UtilComponent uint16 = 0x9
+
+func init() {
+	RegisterHandlers(UtilComponent, map[uint16]Handler{
+		0x01: handleFetchClientConfig,
+		0x05: handleGetTelemetryServer,
+		0x06: handleGetTickerServer,
+		0x07: handlePreAuth,
+		0x08: handlePostAuth,
+		0x0A: handleUserSettingsLoad,
+		0x0B: handleUserSettingsSave,
+		0x0C: handleUserSettingsLoadAll,
+		0x15: handleFetchQosConfig,
+	})
+}
+
+// localHost returns the address of this server as seen by the session so
+// that the addresses given to the client are always reachable by it
+func (s *Session) localHost() string {
+	host, _, err := net.SplitHostPort(s.conn.LocalAddr().String())
+	if err != nil {
+		return "127.0.0.1"
+	}
+	return host
+}
+
+func playerId(session *Session) int64 {
+	player := session.Player()
+	if player == nil {
+		return 0
+	}
+	return int64(player.Id)
+}
+
+func telemetryTdf(label string, session *Session) blaze.StructTdf {
+	return blaze.NewStruct(label,
+		blaze.NewString("ADRS", session.localHost()),
+		blaze.NewInt64("ANON", 0),
+		blaze.NewString("DISA", ""),
+		blaze.NewString("FILT", "-UION/****"),
+		blaze.NewInt64("LOC", 0x656e5553),
+		blaze.NewString("NOOK", "US,CA,MX"),
+		blaze.NewInt64("PORT", int64(TelemetryPort)),
+		blaze.NewInt64("SDLY", 15000),
+		blaze.NewString("SESS", fmt.Sprintf("gomes%d", session.Id)),
+		blaze.NewString("SKEY", TelemetryKey),
+		blaze.NewInt64("SPCT", 75),
+		blaze.NewString("STIM", ""),
+	)
+}
+
+func tickerTdf(label string, session *Session) blaze.StructTdf {
+	host := session.localHost()
+	return blaze.NewStruct(label,
+		blaze.NewString("ADRS", host),
+		blaze.NewInt64("PORT", int64(TickerPort)),
+		blaze.NewString("SKEY", fmt.Sprintf("%d,%s:%d,masseffect-3-pc,10,50,50,50,50,0,12", playerId(session), host, TickerPort)),
+	)
+}
+
+func handleGetTelemetryServer(session *Session, packet *blaze.Packet) {
+	session.Respond(packet, telemetryTdf("TELE", session).Values)
+}
+
+func handleGetTickerServer(session *Session, packet *blaze.Packet) {
+	session.Respond(packet, tickerTdf("TICK", session).Values)
+}
+
+func handlePostAuth(session *Session, packet *blaze.Packet) {
+	session.Respond(packet, []blaze.Tdf{
+		telemetryTdf("TELE", session),
+		tickerTdf("TICK", session),
+		blaze.NewStruct("UROP",
+			blaze.NewInt64("TMOP", 1),
+			blaze.NewInt64("UID", playerId(session)),
+		),
+	})
+}
+
+// ClientConfig is the config sent to the client in the preAuth response
+var ClientConfig = map[string]string{
+	"connIdleTimeout":           "90s",
+	"defaultRequestTimeout":     "60s",
+	"pingPeriod":                "15s",
+	"voipHeadsetUpdateRate":     "1000",
+	"xlspConnectionIdleTimeout": "300",
+}
+
+func stringMapTdf(label string, values map[string]string) blaze.Map[string, string] {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	items := make([]string, len(keys))
+	for i, key := range keys {
+		items[i] = values[key]
+	}
+	return blaze.NewMap(label, keys, items)
+}
+
+// qosTdf creates the QoS config pointing the client at the QoS responder
+func qosTdf(label string, session *Session) blaze.StructTdf {
+	host := session.localHost()
+	server := func(label string) blaze.StructTdf {
+		return blaze.NewStruct(label,
+			blaze.NewString("PSA", host),
+			blaze.NewInt64("PSP", int64(QosHttpPort)),
+			blaze.NewString("SNA", "gomes"),
+		)
+	}
+	return blaze.NewStruct(label,
+		server("BWPS"),
+		blaze.NewInt64("LNP", 0xA),
+		blaze.NewMap("LTPS", []string{"gomes"}, []blaze.StructTdf{blaze.NewStructStub(server("").Values, false)}),
+		blaze.NewInt64("SVID", QosServiceId),
+	)
+}
+
+func handlePreAuth(session *Session, packet *blaze.Packet) {
+	components := []int64{0x1, 0x19, 0x4, 0x1C, 0x7, 0x9, 0xF, 0x7802, 0x7800, 0x7D0}
+	session.Respond(packet, []blaze.Tdf{
+		blaze.NewInt64("ANON", 0),
+		blaze.NewString("ASRC", "303107"),
+		blaze.NewVarIntList("CIDS", components),
+		blaze.NewString("CNGN", ""),
+		blaze.NewStruct("CONF", stringMapTdf("CONF", ClientConfig)),
+		blaze.NewString("INST", "masseffect-3-pc"),
+		blaze.NewInt64("MINR", 0),
+		blaze.NewString("NASP", "cem_ea_id"),
+		blaze.NewString("PILD", ""),
+		blaze.NewString("PLAT", "pc"),
+		blaze.NewString("PTAG", ""),
+		qosTdf("QOSS", session),
+		blaze.NewString("RSRC", "303107"),
+		blaze.NewString("SVER", "Blaze 3.15.08.0 (CL# 1060080)"),
+	})
+}
+
+// ClientConfigDir is the directory within the data directory holding the
+// responses to fetchClientConfig. Each config is a file named after its ID
+// written in the blaze text format, for example ME3_DATA.tdf containing
+//
+//	CONF: { "GAW_SERVER_BASE_URL": "http://127.0.0.1/wal/masseffect-gaw-pc/" }
+const ClientConfigDir = "config"
+
+// loadClientConfig reads the config with the provided ID. Configs that
+// don't exist have an empty CONF map
+func loadClientConfig(id string) (blaze.Values, error) {
+	empty := []blaze.Tdf{stringMapTdf("CONF", map[string]string{})}
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return empty, nil
+	}
+	data, err := os.ReadFile(filepath.Join(DataDir, ClientConfigDir, id+".tdf"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return empty, nil
+		}
+		return nil, err
+	}
+	return blaze.ParseContent(string(data))
+}
+
+func handleFetchClientConfig(session *Session, packet *blaze.Packet) {
+	id := packet.ReadLabels("CFID").StringOr("CFID", "")
+	content, err := loadClientConfig(id)
+	if err != nil {
+		log.Println("Failed to load client config", id, err)
+		session.RespondError(packet, UtilErrConfigInvalid)
+		return
+	}
+	session.Respond(packet, content)
+}
+
+func handleFetchQosConfig(session *Session, packet *blaze.Packet) {
+	session.Respond(packet, qosTdf("QOSS", session).Values)
+}
+
+// Util error codes
+const (
+	UtilErrAuthRequired   uint16 = 0x1
+	UtilErrSettingMissing uint16 = 0x2
+	UtilErrConfigInvalid  uint16 = 0x3
+)
+
+func handleUserSettingsLoad(session *Session, packet *blaze.Packet) {
+	current, ok := requirePlayer(session, packet, UtilErrAuthRequired)
+	if !ok {
+		return
+	}
+	key := packet.ReadLabels("KEY").StringOr("KEY", "")
+	var value string
+	var exists bool
+	Players.View(current, func(player *game.Player) {
+		value, exists = player.Settings[key]
+	})
+	if !exists {
+		session.RespondError(packet, UtilErrSettingMissing)
+		return
+	}
+	session.Respond(packet, []blaze.Tdf{blaze.NewString("DATA", value)})
+}
+
+func handleUserSettingsSave(session *Session, packet *blaze.Packet) {
+	current, ok := requirePlayer(session, packet, UtilErrAuthRequired)
+	if !ok {
+		return
+	}
+	content := packet.ReadContent()
+	key := content.StringOr("KEY", "")
+	value := content.StringOr("DATA", "")
+	err := Players.Update(current, func(player *game.Player) {
+		if player.Settings == nil {
+			player.Settings = map[string]string{}
+		}
+		player.Settings[key] = value
+	})
+	if err != nil {
+		log.Println("Failed to save player settings", err)
+	}
+	session.RespondEmpty(packet)
+}
+
+func handleUserSettingsLoadAll(session *Session, packet *blaze.Packet) {
+	current, ok := requirePlayer(session, packet, UtilErrAuthRequired)
+	if !ok {
+		return
+	}
+	settings := map[string]string{}
+	Players.View(current, func(player *game.Player) {
+		for key, value := range player.Settings {
+			settings[key] = value
+		}
+	})
+	session.Respond(packet, []blaze.Tdf{stringMapTdf("SMAP", settings)})
+}
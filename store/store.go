@@ -0,0 +1,71 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store is a directory of json files used to persist server data
+// between restarts
+type Store struct {
+	Dir string
+
+	lock sync.Mutex
+}
+
+// Open opens the store at the provided directory creating the
+// directory if it doesn't already exist
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{Dir: dir}, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.Dir, name+".json")
+}
+
+// Load reads the json file with the provided name into value. Missing
+// files are not an error and leave value untouched
+func (s *Store) Load(name string, value any) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+// Save writes value as json to the file with the provided name. The
+// data is written to a temporary file first and then renamed over the
+// original so that a crash never leaves a partially written file
+func (s *Store) Save(name string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	path := s.path(name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Exists checks whether the file with the provided name exists
+func (s *Store) Exists(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err := os.Stat(s.path(name))
+	return err == nil
+}